@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ddalab/launcher/internal/app"
+	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/bootstrap"
+	"github.com/ddalab/launcher/pkg/controller"
+)
+
+// Exit codes reported to scripts. They are documented in README.md.
+const (
+	exitOK                 = 0
+	exitError              = 1   // Any other failure
+	exitUsage              = 2   // Bad flags or arguments
+	exitConfig             = 3   // Launcher configuration missing or invalid
+	exitBackendUnavailable = 4   // DDALAB API cannot be reached
+	exitDockerNotRunning   = 5   // Docker is not installed or its daemon cannot be reached
+	exitUnhealthy          = 6   // services --fail-on-unhealthy found an unhealthy service
+	exitTimeout            = 124 // An operation timed out
+)
+
+// codedError attaches an explicit exit code to an error
+type codedError struct {
+	code int
+	err  error
+}
+
+func (e *codedError) Error() string { return e.err.Error() }
+func (e *codedError) Unwrap() error { return e.err }
+
+// withCode marks err to exit with code
+func withCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &codedError{code: code, err: err}
+}
+
+// exitCode maps an error to the exit code scheme
+func exitCode(err error) int {
+	var coded *codedError
+
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &coded):
+		return coded.code
+	case errors.Is(err, context.DeadlineExceeded):
+		return exitTimeout
+	case errors.Is(err, bootstrap.ErrDockerNotRunning), errors.Is(err, bootstrap.ErrDockerNotInstalled):
+		return exitDockerNotRunning
+	case errors.Is(err, controller.ErrAPIUnavailable), api.IsTransientError(err):
+		return exitBackendUnavailable
+	case errors.Is(err, app.ErrNotConfigured), errors.Is(err, app.ErrInstallationMissing):
+		return exitConfig
+	case errors.Is(err, app.ErrUnhealthy):
+		return exitUnhealthy
+	default:
+		return exitError
+	}
+}
@@ -1,37 +1,134 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
-	"log"
 	"os"
-	"runtime"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/ddalab/launcher/internal/app"
 	"github.com/ddalab/launcher/internal/terminal"
 	"github.com/ddalab/launcher/pkg/config"
+	"github.com/ddalab/launcher/pkg/mode"
+	"github.com/ddalab/launcher/pkg/theme"
+	"github.com/ddalab/launcher/pkg/ui"
 )
 
+// apiCheckTimeout bounds the reachability check for --mode api
+const apiCheckTimeout = 5 * time.Second
+
 // Version is set by build flags
 var version = "dev"
 
+// options holds the command line settings applied to the launcher
+type options struct {
+	configPath      string
+	forceMode       string
+	apiEndpoint     string
+	installDir      string
+	palette         string
+	noEmoji         bool
+	offline         bool
+	forceAPI        bool // API mode without verification for this session
+	assumeYes       bool
+	jsonOutput      bool
+	failOnUnhealthy bool
+}
+
 func main() {
 	// Handle CLI flags
 	var showVersion = flag.Bool("version", false, "Show version information")
+	var versionJSON = flag.Bool("json", false, "Print --version, dump-env, services, events and config show output as JSON")
+	var failOnUnhealthy = flag.Bool("fail-on-unhealthy", false, "Make the services command exit non-zero if a service is unhealthy")
 	var forceMode = flag.String("mode", "", "Force operation mode: 'local', 'api', or 'auto'")
-	var apiEndpoint = flag.String("api-endpoint", "", "Docker extension API endpoint (default: http://localhost:8080/api)")
+	var apiEndpoint = flag.String("api-endpoint", "", "Docker extension API endpoint (default: "+config.DefaultAPIEndpoint+")")
+	var configPath = flag.String("config", "", "Path to the launcher config file (.json, .toml, .yaml or .yml)")
+	var forceAPI = flag.Bool("force-api", false, "Use API mode for this session without checking the health endpoint (operations may fail)")
+	var offline = flag.Bool("offline", false, "Disable update checks and other internet access for this session")
+	var installDir = flag.String("install-dir", "", "Set the DDALAB installation path without the interactive picker")
+	var noEmoji = flag.Bool("no-emoji", false, "Show plain ASCII icons instead of emoji")
+	var simpleUI = flag.Bool("simple-ui", false, "Use plain numbered menus and line prompts instead of the full-screen UI")
+	var palette = flag.String("palette", "", "Color palette: "+strings.Join(theme.PaletteNames(), ", ")+" (default: from config)")
+	var assumeYes bool
+	flag.BoolVar(&assumeYes, "yes", false, "Confirm all prompts automatically (also skips the uninstall double confirmation)")
+	flag.BoolVar(&assumeYes, "y", false, "Shorthand for --yes")
+	flag.Usage = usage
 	flag.Parse()
 
+	// Allow flags after the command as well, e.g. "ddalab-launcher stop -y"
+	command := ""
+	if flag.NArg() > 0 {
+		command = flag.Arg(0)
+		if !app.IsCommand(command) {
+			fmt.Fprintf(os.Stderr, "Unknown command '%s'\n\n", command)
+			usage()
+			os.Exit(exitUsage)
+		}
+		args := flag.Args()[1:]
+		if command == "config" {
+			if len(args) == 0 || args[0] != "show" {
+				fmt.Fprintln(os.Stderr, "Usage: ddalab-launcher config show [--json]")
+				os.Exit(exitUsage)
+			}
+			args = args[1:]
+		}
+		if err := flag.CommandLine.Parse(args); err != nil {
+			os.Exit(exitUsage)
+		}
+		if flag.NArg() > 0 {
+			fmt.Fprintf(os.Stderr, "Unexpected arguments: %s\n", strings.Join(flag.Args(), " "))
+			os.Exit(exitUsage)
+		}
+	}
+
 	if *showVersion {
-		fmt.Printf("DDALAB Launcher %s\n", version)
-		fmt.Printf("Built with %s\n", runtime.Version())
-		fmt.Printf("Platform: %s/%s\n", runtime.GOOS, runtime.GOARCH)
-		os.Exit(0)
+		exitWithError(printVersion(*versionJSON))
+		os.Exit(exitOK)
 	}
 
-	// Check if we're running in a terminal
-	if !terminal.IsTerminal() {
+	if *noEmoji {
+		theme.SetPlain(true)
+	}
+	if *simpleUI {
+		ui.SetSimple(true)
+	}
+
+	// Set the version in the config package so it's available throughout the application
+	config.SetVersion(version)
+
+	opts := options{
+		configPath:      *configPath,
+		forceMode:       *forceMode,
+		apiEndpoint:     *apiEndpoint,
+		installDir:      *installDir,
+		palette:         *palette,
+		noEmoji:         *noEmoji,
+		offline:         *offline,
+		forceAPI:        *forceAPI,
+		assumeYes:       assumeYes,
+		jsonOutput:      *versionJSON,
+		failOnUnhealthy: *failOnUnhealthy,
+	}
+
+	// Commands run a single operation without the menu
+	if command != "" {
+		exitWithError(runCommand(command, opts))
+		os.Exit(exitOK)
+	}
+
+	// Provisioning scripts run without a terminal: configure and exit
+	if *installDir != "" && !terminal.IsTerminal() {
+		exitWithError(provision(opts))
+		os.Exit(exitOK)
+	}
+
+	// Check if we're running in a terminal. The plain UI also works on
+	// redirected input, e.g. a serial console or a scripted session.
+	if !terminal.IsTerminal() && !*simpleUI {
 		// Try to relaunch in a terminal
 		if err := terminal.RelaunchInTerminal(); err != nil {
 			// If that fails, show a GUI error message
@@ -39,29 +136,24 @@ func main() {
 				"DDALAB Launcher requires a terminal to run.\n\n"+
 					"Please run this application from a terminal:\n"+
 					"./ddalab-launcher")
-			os.Exit(1)
+			os.Exit(exitError)
 		}
 		// If relaunch succeeded, exit this instance
-		os.Exit(0)
-	}
-
-	// Set terminal title
-	if runtime.GOOS != "windows" {
-		fmt.Print("\033]0;DDALAB Launcher\007")
+		os.Exit(exitOK)
 	}
 
-	// Set the version in the config package so it's available throughout the application
-	config.SetVersion(version)
+	// Set the terminal title; shutdown restores the previous one. The defer
+	// also covers a panic and does nothing after shutdown.
+	terminal.SetTitle("DDALAB Launcher")
+	defer terminal.ResetTitle()
 
-	launcher, err := app.NewLauncher()
+	launcher, err := newConfiguredLauncher(opts)
 	if err != nil {
-		log.Fatalf("Failed to initialize launcher: %v", err)
+		terminal.ResetTitle()
+		exitWithError(err)
 	}
 
-	// Apply CLI overrides if provided
-	if err := applyModeOverrides(launcher, *forceMode, *apiEndpoint); err != nil {
-		log.Fatalf("Failed to apply mode overrides: %v", err)
-	}
+	handleShutdownSignals(launcher)
 
 	if err := launcher.Run(); err != nil {
 		fmt.Printf("Error: %v\n", err)
@@ -69,40 +161,233 @@ func main() {
 		// On error, wait for user input before closing
 		fmt.Println("\nPress Enter to exit...")
 		_, _ = fmt.Scanln()
-		os.Exit(1)
+		shutdown(launcher)
+		os.Exit(exitCode(err))
+	}
+
+	shutdown(launcher)
+	if err := launcher.Relaunch(); err != nil {
+		fmt.Printf("Warning: could not restart the launcher: %v\n", err)
+		fmt.Println("Please start it again to use the new version.")
+	}
+}
+
+// usage prints the command line help
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s [flags] [command]\n\n", os.Args[0])
+	fmt.Fprintln(out, "Without a command the interactive menu is shown.")
+	fmt.Fprintln(out, "\nCommands:")
+	for _, line := range app.CommandUsage() {
+		fmt.Fprintln(out, line)
+	}
+	fmt.Fprintln(out, "\nFlags:")
+	flag.PrintDefaults()
+	fmt.Fprintln(out, "\nExit codes: 0 ok, 1 error, 2 usage, 3 config, 4 backend unavailable,")
+	fmt.Fprintln(out, "5 Docker not installed or not running, 6 services unhealthy, 124 timeout")
+}
+
+// newConfiguredLauncher creates a launcher and applies the CLI overrides
+func newConfiguredLauncher(opts options) (*app.Launcher, error) {
+	launcher, err := app.NewLauncherWithConfigPath(opts.configPath)
+	if err != nil {
+		return nil, withCode(exitConfig, fmt.Errorf("failed to initialize launcher: %w", err))
+	}
+
+	configManager := launcher.GetConfigManager()
+	if opts.offline {
+		configManager.SetOfflineSession()
+		configManager.Override("offline", true, "--offline flag")
+	}
+	if opts.noEmoji {
+		configManager.Override("theme", config.ThemePlain, "--no-emoji flag")
+	}
+
+	// The flag overrides the palette from the config
+	if opts.palette != "" {
+		if err := theme.SetPalette(opts.palette); err != nil {
+			launcher.Close()
+			return nil, withCode(exitUsage, fmt.Errorf("invalid --palette: %w", err))
+		}
+		configManager.Override("palette", opts.palette, "--palette flag")
+	}
+
+	// --force-api neither verifies nor saves the mode
+	forceMode := opts.forceMode
+	if opts.forceAPI {
+		if forceMode != "" && !strings.EqualFold(forceMode, string(config.ModeAPI)) {
+			launcher.Close()
+			return nil, withCode(exitUsage, fmt.Errorf("--force-api cannot be combined with --mode %s", forceMode))
+		}
+		forceMode = ""
 	}
+
+	if err := applyModeOverrides(launcher, forceMode, opts.apiEndpoint, opts.assumeYes); err != nil {
+		launcher.Close()
+		return nil, err
+	}
+	if opts.forceAPI {
+		launcher.GetModeManager().ForceAPIMode()
+		configManager.Override("operation_mode", config.ModeAPI, "--force-api flag")
+	}
+
+	// Preset the installation path if provided (non-interactive provisioning)
+	if opts.installDir != "" {
+		if err := launcher.SetInstallDir(opts.installDir); err != nil {
+			launcher.Close()
+			return nil, withCode(exitConfig, fmt.Errorf("invalid --install-dir: %w", err))
+		}
+		configManager.SetSource("ddalab_path", "--install-dir flag")
+	}
+
+	return launcher, nil
+}
+
+// runCommand runs a single non-interactive command
+func runCommand(command string, opts options) error {
+	launcher, err := newConfiguredLauncher(opts)
+	if err != nil {
+		return err
+	}
+	// No terminal title was set, so only the launcher needs closing
+	defer func() {
+		if err := launcher.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}()
+
+	handleShutdownSignals(launcher)
+
+	launcher.SetJSONOutput(opts.jsonOutput)
+	launcher.SetFailOnUnhealthy(opts.failOnUnhealthy)
+	return launcher.RunCommand(command, opts.assumeYes)
+}
+
+// provision applies the CLI settings to the launcher config without
+// starting the interactive UI
+func provision(opts options) error {
+	launcher, err := newConfiguredLauncher(opts)
+	if err != nil {
+		return err
+	}
+	defer launcher.Close()
+
+	configManager := launcher.GetConfigManager()
+	fmt.Printf("DDALAB installation set to %s (config: %s)\n",
+		configManager.GetDDALABPath(), configManager.GetConfigPath())
+	return nil
+}
+
+// exitWithError prints err and exits with its mapped exit code. It
+// returns normally if err is nil.
+func exitWithError(err error) {
+	if err == nil {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(exitCode(err))
+}
+
+// handleShutdownSignals shuts the launcher down cleanly on SIGINT/SIGTERM
+func handleShutdownSignals(launcher *app.Launcher) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		for sig := range sigCh {
+			// Ctrl+C during an operation only cancels that operation
+			if sig == os.Interrupt && launcher.IsOperationActive() {
+				continue
+			}
+
+			fmt.Println("\nShutting down...")
+			shutdown(launcher)
+			if sig == syscall.SIGTERM {
+				os.Exit(143)
+			}
+			os.Exit(130)
+		}
+	}()
 }
 
-// applyModeOverrides applies CLI flag overrides to the launcher configuration
-func applyModeOverrides(launcher *app.Launcher, forceMode, apiEndpoint string) error {
+// shutdown runs the launcher cleanup routine and restores the terminal
+func shutdown(launcher *app.Launcher) {
+	if err := launcher.Close(); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+	terminal.ResetTitle()
+}
+
+// applyModeOverrides applies CLI flag overrides to the launcher
+// configuration. With assumeYes, prompts are answered with yes.
+func applyModeOverrides(launcher *app.Launcher, forceMode, apiEndpoint string, assumeYes bool) error {
 	configManager := launcher.GetConfigManager()
 
 	// Override API endpoint if provided
 	if apiEndpoint != "" {
-		configManager.SetAPIEndpoint(apiEndpoint)
+		if err := configManager.SetAPIEndpoint(apiEndpoint); err != nil {
+			return withCode(exitUsage, fmt.Errorf("invalid --api-endpoint: %w", err))
+		}
+		configManager.SetSource("api_endpoint", "--api-endpoint flag")
 	}
 
 	// Override operation mode if provided
 	if forceMode != "" {
-		var mode config.OperationMode
+		var operationMode config.OperationMode
 		switch strings.ToLower(forceMode) {
 		case "local":
-			mode = config.ModeLocal
+			operationMode = config.ModeLocal
 		case "api":
-			mode = config.ModeAPI
+			operationMode = config.ModeAPI
 		case "auto":
-			mode = config.ModeAuto
+			operationMode = config.ModeAuto
 		default:
-			return fmt.Errorf("invalid mode '%s'. Valid modes: local, api, auto", forceMode)
+			return withCode(exitUsage, fmt.Errorf("invalid mode '%s'. Valid modes: local, api, auto", forceMode))
 		}
 
-		configManager.SetOperationMode(mode)
+		configManager.SetOperationMode(operationMode)
+		configManager.SetSource("operation_mode", "--mode flag")
+
+		if operationMode == config.ModeAPI {
+			confirmAPIEndpoint(configManager, assumeYes)
+		}
 
 		// Save the configuration with overrides
 		if err := configManager.Save(); err != nil {
-			return fmt.Errorf("failed to save mode overrides: %w", err)
+			return withCode(exitConfig, fmt.Errorf("failed to save mode overrides: %w", err))
 		}
 	}
 
 	return nil
 }
+
+// confirmAPIEndpoint checks a forced API endpoint right away so an
+// unreachable backend is reported at launch rather than at the first
+// operation. In a terminal the user may fall back to auto mode; with
+// assumeYes that happens without asking.
+func confirmAPIEndpoint(configManager *config.ConfigManager, assumeYes bool) {
+	endpoint := configManager.GetAPIEndpoint()
+
+	ctx, cancel := context.WithTimeout(context.Background(), apiCheckTimeout)
+	defer cancel()
+
+	err := mode.NewAPIClient(configManager, endpoint).HealthCheck(ctx)
+	if err == nil {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Warning: API endpoint %s is not reachable: %v\n", endpoint, err)
+	if assumeYes {
+		fmt.Fprintln(os.Stderr, "Falling back to auto mode instead of API mode (--yes)")
+		configManager.SetOperationMode(config.ModeAuto)
+		return
+	}
+	if !terminal.IsTerminal() {
+		return
+	}
+
+	fallback, err := ui.RunConfirm("Fall back to auto mode instead of API mode?")
+	if err == nil && fallback {
+		configManager.SetOperationMode(config.ModeAuto)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+)
+
+// Build metadata, set with -ldflags "-X main.commit=... -X main.buildDate=..."
+var (
+	commit    = ""
+	buildDate = ""
+)
+
+// buildInfo describes the running binary
+type buildInfo struct {
+	Version   string `json:"version"`
+	GoVersion string `json:"goVersion"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"buildDate"`
+}
+
+// currentBuildInfo returns the build metadata, falling back to the VCS
+// information Go embeds when the ldflags were not set
+func currentBuildInfo() buildInfo {
+	info := buildInfo{
+		Version:   version,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Commit:    commit,
+		BuildDate: buildDate,
+	}
+
+	if embedded, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range embedded.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			case setting.Key == "vcs.time" && info.BuildDate == "":
+				info.BuildDate = setting.Value
+			}
+		}
+	}
+
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildDate == "" {
+		info.BuildDate = "unknown"
+	}
+
+	return info
+}
+
+// printVersion prints the build metadata as text or JSON
+func printVersion(asJSON bool) error {
+	info := currentBuildInfo()
+
+	if asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(info)
+	}
+
+	fmt.Printf("DDALAB Launcher %s\n", info.Version)
+	fmt.Printf("Built with %s\n", info.GoVersion)
+	fmt.Printf("Platform: %s/%s\n", info.OS, info.Arch)
+	fmt.Printf("Commit: %s (%s)\n", info.Commit, info.BuildDate)
+	return nil
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ddalab/launcher/pkg/certs"
+	"github.com/ddalab/launcher/pkg/config"
+)
+
+// defaultCertDomain is used when the .env file sets no DOMAIN
+const defaultCertDomain = "localhost"
+
+// handleRegenerateCertsCommand recreates the installation's TLS
+// certificates, e.g. when they expired and browsers refuse to connect
+func (l *Launcher) handleRegenerateCertsCommand() error {
+	if err := l.checkAllowed("regenerate-certs"); err != nil {
+		return err
+	}
+
+	ddalabPath := l.configManager.GetDDALABPath()
+	if ddalabPath == "" {
+		return fmt.Errorf("%w - use 'Configure Installation' first", ErrNotConfigured)
+	}
+
+	domain := l.certDomain()
+	if script := certs.FindScript(ddalabPath); script != "" {
+		l.ui.ShowInfo(fmt.Sprintf("Using the installation's certificate script: %s", script))
+	} else {
+		l.ui.ShowInfo(fmt.Sprintf("Generating a self-signed certificate for %s in %s", domain, filepath.Join(ddalabPath, certs.Dir)))
+		l.ui.ShowWarning("Browsers do not trust self-signed certificates. Accept the browser warning once, " +
+			"or add the certificate to your system's trust store - only do so on machines you control.")
+	}
+
+	if !l.ui.ConfirmOperation("regenerate the TLS certificates (existing ones are backed up)") {
+		return nil
+	}
+
+	result, err := certs.Regenerate(l.ctx, ddalabPath, domain, l.ui.ShowInfo)
+	if err != nil {
+		return fmt.Errorf("failed to regenerate certificates: %w", err)
+	}
+
+	for _, backup := range result.Backups {
+		l.ui.ShowInfo(fmt.Sprintf("Backed up %s", backup))
+	}
+	if result.Script != "" {
+		l.ui.ShowSuccess("Certificates regenerated")
+	} else {
+		l.ui.ShowSuccess(fmt.Sprintf("Certificate written to %s (valid until %s)",
+			result.CertPath, result.NotAfter.Format("2006-01-02")))
+	}
+	l.ui.ShowInfo("Restart DDALAB for the new certificates to take effect")
+	return nil
+}
+
+// certDomain returns the DOMAIN from the installation's .env file, falling
+// back to localhost
+func (l *Launcher) certDomain() string {
+	envPath, err := l.configManager.EnvFilePath()
+	if err != nil {
+		return defaultCertDomain
+	}
+
+	envConfig, err := config.LoadEnvFile(envPath)
+	if err != nil {
+		return defaultCertDomain
+	}
+
+	if domain := envConfig.ResolveValue("DOMAIN"); domain != "" {
+		return domain
+	}
+	return defaultCertDomain
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ddalab/launcher/internal/terminal"
+)
+
+// ErrNotConfigured is returned when a command needs a DDALAB installation
+// but none has been configured yet
+var ErrNotConfigured = errors.New("DDALAB is not configured")
+
+// ErrNotPermitted is returned when the configured role does not allow an
+// operation
+var ErrNotPermitted = errors.New("operation not permitted")
+
+// cliCommand is an operation that can run without the interactive menu,
+// e.g. "ddalab-launcher stop --yes"
+type cliCommand struct {
+	handler     func(l *Launcher) error
+	description string
+	destructive bool // Needs confirmation (or --yes when non-interactive)
+	local       bool // Only reads local files, so backend detection is skipped
+}
+
+var cliCommands = map[string]cliCommand{
+	"start":             {(*Launcher).handleStartCommand, "Start all DDALAB services", false, false},
+	"stop":              {(*Launcher).handleStopCommand, "Stop all DDALAB services", true, false},
+	"deep-stop":         {(*Launcher).handleDeepStopCommand, "Stop DDALAB and remove its containers, including orphans", true, false},
+	"restart":           {(*Launcher).handleRestartCommand, "Restart all DDALAB services", true, false},
+	"restart-unhealthy": {(*Launcher).handleRestartUnhealthyCommand, "Restart only the services that are not healthy", true, false},
+	"status":            {(*Launcher).handleStatusCommand, "Show service status", false, false},
+	"services":          {(*Launcher).handleServicesCommand, "List services with status, health and uptime (--json for JSON)", false, false},
+	"dashboard":         {(*Launcher).handleDashboardCommand, "Watch live service status until q is pressed", false, false},
+	"events":            {(*Launcher).handleEventsCommand, "Follow the backend's application events until Ctrl+C (--json for JSON lines)", false, false},
+	"config":            {(*Launcher).handleConfigShowCommand, "Print each effective setting and where it comes from (config show, --json for JSON)", false, true},
+	"dump-env":          {(*Launcher).handleDumpEnvCommand, "Print the .env configuration with secrets redacted (--json for JSON)", false, true},
+	"regenerate-certs":  {(*Launcher).handleRegenerateCertsCommand, "Regenerate the TLS certificates in the installation's certs/ folder", true, true},
+	"backup":            {(*Launcher).handleBackupCommand, "Create a database backup", false, false},
+	"selftest":          {(*Launcher).handleSelftestCommand, "Start, check, back up and stop DDALAB to verify the installation", false, false},
+	"update":            {(*Launcher).handleUpdateCommand, "Update DDALAB to the latest version", true, false},
+	"uninstall":         {(*Launcher).handleUninstallCommand, "Remove DDALAB and all its data", true, false},
+}
+
+// IsCommand returns true if name is a non-interactive command
+func IsCommand(name string) bool {
+	_, ok := cliCommands[name]
+	return ok
+}
+
+// CommandUsage returns one help line per non-interactive command
+func CommandUsage() []string {
+	names := make([]string, 0, len(cliCommands))
+	for name := range cliCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		lines = append(lines, fmt.Sprintf("  %-17s %s", name, cliCommands[name].description))
+	}
+	return lines
+}
+
+// RunCommand runs a single operation without the interactive menu. With
+// assumeYes all confirmations are accepted automatically. Without it,
+// destructive commands prompt when a terminal is attached and refuse to
+// run otherwise, rather than waiting for input that never comes.
+func (l *Launcher) RunCommand(name string, assumeYes bool) error {
+	command, ok := cliCommands[name]
+	if !ok {
+		return fmt.Errorf("unknown command '%s'", name)
+	}
+
+	// The configuration is shown however incomplete it is
+	if name == "config" {
+		return command.handler(l)
+	}
+
+	if l.configManager.GetDDALABPath() == "" {
+		return fmt.Errorf("%w - run the launcher interactively or pass --install-dir", ErrNotConfigured)
+	}
+	if err := l.checkInstallation(); err != nil && !installIndependentCommands[name] {
+		return fmt.Errorf("%w - mount it again or reconfigure with --install-dir", err)
+	}
+
+	interactive := terminal.IsTerminal()
+	if command.destructive && !assumeYes && !interactive {
+		return fmt.Errorf("refusing to %s without confirmation - pass --yes to confirm non-interactively", name)
+	}
+
+	l.ui.SetAssumeYes(assumeYes)
+	l.ui.SetSpinnerEnabled(interactive)
+
+	if !command.local {
+		l.startSSHForward()
+		if err := l.modeManager.Initialize(); err != nil {
+			l.ui.ShowWarning(fmt.Sprintf("Mode initialization warning: %v", err))
+		}
+	}
+
+	err := command.handler(l)
+	l.recordFailure(name, err)
+	return err
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ddalab/launcher/internal/terminal"
+	"github.com/ddalab/launcher/pkg/compose"
+)
+
+// handleComposeCommand shows the docker compose invocation matching a
+// launcher operation for the configured deployment and copies it to the
+// clipboard. Nothing is executed.
+func (l *Launcher) handleComposeCommand() error {
+	if l.configManager.GetDDALABPath() == "" {
+		return ErrNotConfigured
+	}
+
+	operation, err := l.ui.SelectComposeOperation(compose.Operations)
+	if err != nil {
+		return err
+	}
+
+	project := l.controller.ComposeProject()
+	lines, err := compose.Commands(compose.DetectCommand(l.ctx), project, operation)
+	if err != nil {
+		return err
+	}
+
+	l.ui.ShowInfo(fmt.Sprintf("Compose command for '%s' (not executed):", operation))
+	l.ui.Println("")
+	for _, line := range lines {
+		l.ui.Println("  " + line)
+	}
+	l.ui.Println("")
+
+	if err := terminal.CopyToClipboard(strings.Join(lines, "\n")); err != nil {
+		l.ui.ShowWarning(fmt.Sprintf("Could not copy the command to clipboard: %v", err))
+	} else {
+		l.ui.ShowInfo("Command copied to clipboard")
+	}
+	return nil
+}
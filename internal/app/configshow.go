@@ -0,0 +1,38 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ddalab/launcher/pkg/config"
+)
+
+// handleConfigShowCommand prints every effective setting with where it
+// comes from: a flag, an environment variable, the config file or the
+// default. Secrets are redacted.
+func (l *Launcher) handleConfigShowCommand() error {
+	settings := l.configManager.EffectiveSettings()
+
+	if l.jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(struct {
+			FilePath string           `json:"file_path"`
+			Settings []config.Setting `json:"settings"`
+		}{l.configManager.GetConfigPath(), settings})
+	}
+
+	fmt.Printf("# %s\n", l.configManager.GetConfigPath())
+	for _, setting := range settings {
+		if setting.Value == "" {
+			setting.Value = `""`
+		}
+		if setting.Source == config.SourceDefault {
+			fmt.Printf("%s = %s (default)\n", setting.Key, setting.Value)
+			continue
+		}
+		fmt.Printf("%s = %s (from %s)\n", setting.Key, setting.Value, setting.Source)
+	}
+	return nil
+}
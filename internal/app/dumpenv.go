@@ -0,0 +1,118 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/ddalab/launcher/pkg/config"
+)
+
+// dumpEnvRedacted replaces secret values in dump-env output
+const dumpEnvRedacted = "***"
+
+// dumpEnvSection is one section of the dump-env output
+type dumpEnvSection struct {
+	Name      string            `json:"name"`
+	Variables []dumpEnvVariable `json:"variables"`
+}
+
+// dumpEnvVariable is a single variable of the dump-env output
+type dumpEnvVariable struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Comment  string `json:"comment,omitempty"`
+	Required bool   `json:"required,omitempty"`
+	Secret   bool   `json:"secret,omitempty"`
+	Local    bool   `json:"local,omitempty"` // From the .env.local overlay
+}
+
+// handleDumpEnvCommand prints the effective .env configuration grouped by
+// section with all secret values redacted, so it can be pasted into a bug
+// report as is
+func (l *Launcher) handleDumpEnvCommand() error {
+	envPath, err := l.configManager.EnvFilePath()
+	if err != nil {
+		return err
+	}
+
+	envConfig, err := config.LoadEnvFile(envPath)
+	if err != nil {
+		return fmt.Errorf("failed to load .env file: %w", err)
+	}
+
+	sections := redactedSections(envConfig)
+
+	if l.jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(struct {
+			FilePath  string           `json:"file_path"`
+			LocalPath string           `json:"local_path,omitempty"`
+			Sections  []dumpEnvSection `json:"sections"`
+		}{envPath, envConfig.LocalPath, sections})
+	}
+
+	fmt.Printf("# %s\n", envPath)
+	if envConfig.LocalPath != "" {
+		fmt.Printf("# overridden by %s\n", envConfig.LocalPath)
+	}
+	for _, section := range sections {
+		fmt.Printf("\n# === %s ===\n", section.Name)
+		for _, envVar := range section.Variables {
+			if envVar.Local {
+				fmt.Printf("# from %s\n", filepath.Base(envConfig.LocalPath))
+			}
+			fmt.Printf("%s=%s\n", envVar.Key, envVar.Value)
+		}
+	}
+	return nil
+}
+
+// redactedSections groups the variables of envConfig by section in file
+// order, replacing the values of non-empty secrets
+func redactedSections(envConfig *config.EnvConfig) []dumpEnvSection {
+	bySection := envConfig.GetVariablesBySection()
+
+	// Variables before the first section header come first
+	order := append([]string{"General"}, envConfig.Sections...)
+	var remaining []string
+	for name := range bySection {
+		if !slices.Contains(order, name) {
+			remaining = append(remaining, name)
+		}
+	}
+	sort.Strings(remaining)
+	order = append(order, remaining...)
+
+	sections := make([]dumpEnvSection, 0, len(bySection))
+	seen := make(map[string]bool, len(order))
+	for _, name := range order {
+		vars, ok := bySection[name]
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		section := dumpEnvSection{Name: name, Variables: make([]dumpEnvVariable, 0, len(vars))}
+		for _, envVar := range vars {
+			value := envVar.Value
+			if envVar.IsSecret && value != "" {
+				value = dumpEnvRedacted
+			}
+			section.Variables = append(section.Variables, dumpEnvVariable{
+				Key:      envVar.Key,
+				Value:    value,
+				Comment:  envVar.Comment,
+				Required: envVar.IsRequired,
+				Secret:   envVar.IsSecret,
+				Local:    envVar.Local,
+			})
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/ddalab/launcher/internal/terminal"
+	"github.com/ddalab/launcher/pkg/config"
+	"github.com/ddalab/launcher/pkg/theme"
+)
+
+// offerEndpointPicker offers to look for the API at other addresses after
+// the configured endpoint did not answer, and saves the endpoint the user
+// picks. It returns true if the endpoint was changed.
+func (l *Launcher) offerEndpointPicker() bool {
+	if !terminal.IsTerminal() || l.ui.AssumesYes() {
+		return false
+	}
+
+	current := l.configManager.GetAPIEndpoint()
+	l.ui.ShowWarning(fmt.Sprintf("The DDALAB API did not answer at %s", current))
+	if !l.ui.ConfirmOperation("look for the DDALAB API at other common addresses") {
+		return false
+	}
+	return l.pickAPIEndpoint()
+}
+
+// expectsAPI reports whether the configuration says a backend should be
+// answering: API mode or an endpoint other than the default. On a fresh
+// machine with DDALAB stopped, no endpoint answers and the picker would
+// only be noise.
+func (l *Launcher) expectsAPI() bool {
+	return l.configManager.IsAPIMode() || l.configManager.GetAPIEndpoint() != config.DefaultAPIEndpoint
+}
+
+// pickAPIEndpoint probes the candidate endpoints concurrently, lets the
+// user choose one of them or another address, and saves it
+func (l *Launcher) pickAPIEndpoint() bool {
+	l.ui.ShowProgress("Probing API endpoints")
+	probes := l.modeManager.ProbeEndpoints(l.ctx)
+
+	endpoints := make([]string, len(probes))
+	labels := make([]string, len(probes))
+	for i, probe := range probes {
+		endpoints[i] = probe.Endpoint
+		if probe.Err != nil {
+			labels[i] = fmt.Sprintf("%s %s  no answer", theme.Failed, probe.Endpoint)
+		} else {
+			labels[i] = fmt.Sprintf("%s %s  %dms", theme.Done, probe.Endpoint, probe.Latency.Milliseconds())
+		}
+	}
+
+	current := l.configManager.GetAPIEndpoint()
+	endpoint, err := l.ui.SelectAPIEndpoint(endpoints, labels, current)
+	if err != nil || endpoint == "" {
+		return false
+	}
+
+	if err := l.configManager.SetAPIEndpoint(endpoint); err != nil {
+		l.ui.ShowError(err.Error())
+		return false
+	}
+	if err := l.configManager.Save(); err != nil {
+		l.ui.ShowWarning(fmt.Sprintf("Could not save the API endpoint: %v", err))
+	}
+	l.ui.ShowSuccess(fmt.Sprintf("API endpoint set to %s", l.configManager.GetAPIEndpoint()))
+
+	if err := l.modeManager.Initialize(); err != nil {
+		l.ui.ShowWarning(fmt.Sprintf("Mode initialization warning: %v", err))
+	}
+	l.ui.ShowInfo(l.modeManager.GetModeDescription())
+	return true
+}
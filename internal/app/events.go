@@ -0,0 +1,62 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ddalab/launcher/internal/terminal"
+	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/controller"
+	"github.com/ddalab/launcher/pkg/ui"
+)
+
+// handleEventsCommand follows the backend's application events, e.g.
+// logins and finished analyses. In a terminal they are shown live with a
+// filter; otherwise, or with --json, they are printed one per line until
+// interrupted.
+func (l *Launcher) handleEventsCommand() error {
+	client := l.modeManager.GetAPIClient()
+	if !l.modeManager.IsAPIMode() || client == nil {
+		return fmt.Errorf("events need the DDALAB API: %w", controller.ErrAPIUnavailable)
+	}
+	if !client.HasFeature(api.FeatureEvents) {
+		l.ui.ShowInfo("The DDALAB backend does not offer an event stream - use 'View Logs' for container logs")
+		return nil
+	}
+
+	if terminal.IsTerminal() && !ui.IsSimple() && !l.jsonOutput {
+		return ui.RunEvents(l.controller.StreamEvents)
+	}
+	return l.printEvents()
+}
+
+// printEvents prints events as they arrive until Ctrl+C or the stream ends
+func (l *Launcher) printEvents() error {
+	ctx, cancel := l.interruptHandler.WithCancellableContext(l.ctx)
+	defer cancel()
+
+	events := make(chan api.Event, 64)
+	ended := make(chan error, 1)
+	go func() {
+		ended <- l.controller.StreamEvents(ctx, events)
+	}()
+
+	encoder := json.NewEncoder(os.Stdout)
+	for {
+		select {
+		case event := <-events:
+			if l.jsonOutput {
+				if err := encoder.Encode(event); err != nil {
+					return err
+				}
+				continue
+			}
+			fmt.Println(ui.FormatEvent(event, lipgloss.NewStyle()))
+		case err := <-ended:
+			return err
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// checkFeatureRegression compares the features the backend announces with
+// the ones seen on the previous run and warns about any that disappeared,
+// e.g. after a downgrade or a misconfiguration. The current set is stored
+// for the next run.
+func (l *Launcher) checkFeatureRegression() {
+	if !l.modeManager.IsAPIMode() {
+		return
+	}
+	client := l.modeManager.GetAPIClient()
+	if client == nil {
+		return
+	}
+	features, ok := client.Features()
+	if !ok {
+		return // The version info was not fetched, so nothing is known
+	}
+
+	serverVersion := client.ServerVersion()
+	previousVersion := l.configManager.GetServerVersion()
+	previous := l.configManager.GetServerFeatures()
+
+	var lost []string
+	for _, feature := range previous {
+		if !slices.Contains(features, feature) {
+			lost = append(lost, feature)
+		}
+	}
+
+	if len(lost) > 0 {
+		l.ui.ShowWarning(fmt.Sprintf("The DDALAB backend no longer offers: %s", strings.Join(lost, ", ")))
+		if previousVersion != "" && previousVersion != serverVersion {
+			l.ui.ShowInfo(fmt.Sprintf("They were available with backend %s, now running %s - it may have been downgraded", previousVersion, serverVersion))
+		} else {
+			l.ui.ShowInfo("They were available on the previous run - check the backend configuration")
+		}
+	}
+
+	if serverVersion == previousVersion && slices.Equal(features, previous) {
+		return
+	}
+	l.configManager.SetServerCapabilities(serverVersion, features)
+	if err := l.configManager.Save(); err != nil {
+		l.ui.ShowWarning(fmt.Sprintf("Failed to save backend features: %v", err))
+	}
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrInstallationMissing is returned when the configured installation
+// directory is gone, e.g. deleted or on an unmounted drive, or no longer
+// contains DDALAB
+var ErrInstallationMissing = errors.New("configured installation not found")
+
+// installIndependent lists the menu actions that work without the local
+// installation, so a missing one can still be diagnosed and fixed
+var installIndependent = map[string]bool{
+	"Check Status":               true,
+	"Live Dashboard":             true,
+	"View Logs":                  true,
+	"Events":                     true,
+	"Configure Installation":     true,
+	"Export Diagnostics":         true,
+	"Telemetry Settings":         true,
+	"Reload Settings":            true,
+	"Check for Launcher Updates": true,
+	"Switch Update Channel":      true,
+	"Exit":                       true,
+}
+
+// installIndependentCommands lists the commands that only ask the backend
+// for the service status
+var installIndependentCommands = map[string]bool{
+	"status":    true,
+	"services":  true,
+	"dashboard": true,
+	"events":    true,
+}
+
+// checkInstallation returns ErrInstallationMissing if the configured
+// installation does not exist or is not a valid DDALAB installation. An
+// unconfigured path is not checked.
+func (l *Launcher) checkInstallation() error {
+	path := l.configManager.GetDDALABPath()
+	if path == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("%w at %s", ErrInstallationMissing, path)
+	}
+	if info := l.detector.DetectInstallation(path); !info.Valid {
+		return fmt.Errorf("%w at %s: the directory no longer contains a valid DDALAB installation (%s)", ErrInstallationMissing, path, info.Problem)
+	}
+	return nil
+}
+
+// ensureInstallation checks the configured installation and, if it is
+// missing, lets the user mount it again or choose another one. The config
+// is never reset automatically: a drive that is only unmounted comes back
+// with the same path. It returns an error if the installation is still
+// missing afterwards.
+func (l *Launcher) ensureInstallation() error {
+	err := l.checkInstallation()
+	for err != nil {
+		l.ui.ShowError(err.Error())
+		l.ui.ShowInfo("If it is on an external drive or network share, mount it again and retry")
+
+		choice, selectErr := l.ui.SelectMissingInstallationAction()
+		if selectErr != nil || choice == "continue" {
+			return err
+		}
+
+		if choice == "reconfigure" {
+			if configureErr := l.handleConfigureCommand(); configureErr != nil {
+				l.ui.ShowWarning(configureErr.Error())
+			}
+		}
+
+		l.detector.ClearCache()
+		err = l.checkInstallation()
+	}
+	return nil
+}
@@ -2,22 +2,42 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/ddalab/launcher/internal/terminal"
 	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/bootstrap"
 	"github.com/ddalab/launcher/pkg/commands"
 	"github.com/ddalab/launcher/pkg/config"
+	"github.com/ddalab/launcher/pkg/controller"
 	"github.com/ddalab/launcher/pkg/detector"
+	"github.com/ddalab/launcher/pkg/diagnostics"
+	"github.com/ddalab/launcher/pkg/i18n"
 	"github.com/ddalab/launcher/pkg/interrupt"
 	"github.com/ddalab/launcher/pkg/mode"
+	"github.com/ddalab/launcher/pkg/progress"
+	"github.com/ddalab/launcher/pkg/sshforward"
 	"github.com/ddalab/launcher/pkg/status"
+	"github.com/ddalab/launcher/pkg/telemetry"
+	"github.com/ddalab/launcher/pkg/theme"
 	"github.com/ddalab/launcher/pkg/ui"
 	"github.com/ddalab/launcher/pkg/updater"
 )
 
+// autoUpdateCountdown is how long the user has to cancel an automatic
+// launcher update
+const autoUpdateCountdown = 10 * time.Second
+
+// activeJobsTimeout bounds the running analyses check before a stop
+const activeJobsTimeout = 5 * time.Second
+
 // Launcher is the main application struct
 type Launcher struct {
 	configManager    *config.ConfigManager
@@ -27,33 +47,70 @@ type Launcher struct {
 	commander        *commands.Commander
 	interruptHandler *interrupt.Handler
 	statusMonitor    *status.Monitor
+	reachability     *status.Reachability
 	modeManager      *mode.Manager
-	dispatcher       *commands.Dispatcher
+	controller       *controller.Controller
+	operations       *controller.OperationRunner
+	telemetry        *telemetry.Client
+	jsonOutput       bool                   // Commands print machine-readable JSON
+	failOnUnhealthy  bool                   // The services command fails if a service is unhealthy
+	updateCheck      chan updateCheckResult // Result of the background update check
+	sshForward       *sshforward.Forward    // Forward of a remote DDALAB's ports, if configured
+	sshForwardEvents chan error             // Drops (the reason) and reconnects (nil) of sshForward
+	relaunch         *updater.Updater       // Installed an update the launcher restarts into on exit
+
+	ctx       context.Context    // Root context, cancelled on Close
+	cancel    context.CancelFunc // Cancels ctx
+	closeOnce sync.Once
 }
 
-// NewLauncher creates a new launcher instance
+// NewLauncher creates a new launcher instance using the default config file
 func NewLauncher() (*Launcher, error) {
-	configManager, err := config.NewConfigManager()
+	return NewLauncherWithConfigPath("")
+}
+
+// NewLauncherWithConfigPath creates a new launcher instance that reads its
+// settings from configPath. An empty path selects the default config file.
+func NewLauncherWithConfigPath(configPath string) (*Launcher, error) {
+	var configManager *config.ConfigManager
+	var err error
+	if configPath != "" {
+		configManager, err = config.NewConfigManagerWithPath(configPath)
+	} else {
+		configManager, err = config.NewConfigManager()
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize config manager: %w", err)
 	}
 
-	// Create API client with endpoint from config
-	apiEndpoint := configManager.GetAPIEndpoint()
-	if apiEndpoint == "" {
-		apiEndpoint = "http://localhost:8080" // Default Docker extension endpoint
+	i18n.SetLocale(i18n.Detect(configManager.GetLocale()))
+	if configManager.GetTheme() == config.ThemePlain {
+		theme.SetPlain(true)
+	}
+	if err := theme.SetPalette(configManager.GetPalette()); err != nil {
+		return nil, fmt.Errorf("invalid palette in config: %w", err)
 	}
-	apiClient := api.NewClient(apiEndpoint)
+
+	// Share the mode manager's API client so endpoint detection also
+	// applies to the status monitor
+	modeManager := mode.NewManager(configManager)
+	apiClient := modeManager.APIClient()
 
 	detector := detector.NewDetector()
+	detector.SetExpectedServices(configManager.GetExpectedServices())
 	ui := ui.NewUI(configManager, detector)
+	modeManager.SetProgressReporter(ui.ShowProgress)
 	commander := commands.NewCommander(configManager, apiClient)
 	interruptHandler := interrupt.NewHandler()
 	statusMonitor := status.NewMonitor(apiClient)
-	modeManager := mode.NewManager(configManager)
-	dispatcher := commands.NewDispatcher(modeManager, commander)
+	statusMonitor.SetTimeout(configManager.GetStatusTimeout())
+	controller := controller.NewWithModeManager(configManager, modeManager)
+	controller.SetHookOutput(func(line string) {
+		ui.ShowInfo("[hook] " + line)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
 
-	return &Launcher{
+	l := &Launcher{
 		configManager:    configManager,
 		detector:         detector,
 		ui:               ui,
@@ -61,9 +118,144 @@ func NewLauncher() (*Launcher, error) {
 		commander:        commander,
 		interruptHandler: interruptHandler,
 		statusMonitor:    statusMonitor,
+		reachability:     status.NewReachability(apiClient, configManager.GetPingInterval()),
 		modeManager:      modeManager,
-		dispatcher:       dispatcher,
-	}, nil
+		controller:       controller,
+		telemetry:        telemetry.New(configManager.GetTelemetryEndpoint(), config.GetVersion(), configManager.IsTelemetryEnabled),
+		ctx:              ctx,
+		cancel:           cancel,
+	}
+	l.operations = l.newOperationRunner()
+
+	return l, nil
+}
+
+// newOperationRunner connects lifecycle operations to the terminal UI
+func (l *Launcher) newOperationRunner() *controller.OperationRunner {
+	return controller.NewOperationRunner(l.controller, controller.Callbacks{
+		Confirm:       l.ui.ConfirmOperation,
+		ConfirmDanger: l.ui.ConfirmDangerousOperation,
+		Progress:      l.ui.ShowProgress,
+		Info:          l.ui.ShowInfo,
+		Success:       l.ui.ShowSuccess,
+		Execute:       l.executeWithInterrupt,
+		Started:       l.operationStarted,
+		Completed:     l.operationCompleted,
+		Outcomes:      l.reportServiceOutcomes,
+		WebUI:         l.reportWebUI,
+	})
+}
+
+// operationStarted shows elapsed time for operations without progress data
+func (l *Launcher) operationStarted(op controller.Operation) {
+	if op == controller.OpUpdate {
+		// The backend reports no pull progress, so only elapsed time is shown
+		l.ui.TrackProgress(progress.NewTracker(0))
+	}
+}
+
+// reportServiceOutcomes lists the state of each service after a lifecycle
+// operation. If some services failed, it offers to show their logs right
+// away, so a partial failure points at the service to look at.
+func (l *Launcher) reportServiceOutcomes(op controller.Operation, outcomes []controller.ServiceOutcome) {
+	var parts, failed []string
+	for _, outcome := range outcomes {
+		parts = append(parts, fmt.Sprintf("%s: %s", outcome.Service, outcome.Describe(op)))
+		if outcome.Failed && outcome.After != "" {
+			failed = append(failed, outcome.Service)
+		}
+	}
+
+	summary := strings.Join(parts, ", ")
+	if len(failed) == 0 {
+		l.ui.ShowInfo(summary)
+		return
+	}
+	l.ui.ShowWarning(summary + " - see logs")
+
+	if op.Stops() || !terminal.IsTerminal() {
+		return
+	}
+	for _, service := range failed {
+		if !l.ui.ConfirmOperation(fmt.Sprintf("view the logs of %s", service)) {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(l.ctx, 30*time.Second)
+		logs, err := l.controller.Logs(ctx, controller.LogOptions{Service: service, Tail: 50})
+		cancel()
+		if err != nil {
+			l.ui.ShowWarning(err.Error())
+			continue
+		}
+		l.ui.Println(logs)
+	}
+}
+
+// reportWebUI reports whether the web UI answered after a start
+func (l *Launcher) reportWebUI(err error) {
+	if err != nil {
+		l.ui.ShowWarning(fmt.Sprintf("DDALAB started but the web UI is not responding yet: %v", err))
+		return
+	}
+	l.ui.ShowSuccess("Web UI reachable at " + l.controller.WebProbeURL())
+}
+
+// operationCompleted refreshes the status after a lifecycle operation
+func (l *Launcher) operationCompleted(op controller.Operation) {
+	switch op {
+	case controller.OpStart, controller.OpRestart, controller.OpUpdate, controller.OpBootstrap:
+		// Give the backend a grace period while services come up
+		l.statusMonitor.MarkStarted()
+	}
+
+	if op == controller.OpBootstrap {
+		l.ui.ShowInfo("Launcher will now use API mode for future operations")
+	}
+	if op == controller.OpStart || op == controller.OpBootstrap {
+		l.ui.ShowInfo("Access DDALAB at: " + controller.DefaultAccessURL)
+	}
+
+	if op != controller.OpBackup {
+		l.statusMonitor.CheckNow()
+	}
+}
+
+// Close releases launcher resources: it cancels in-flight operations, stops
+// the status monitor and flushes unsaved settings to disk. The config file
+// is left alone if nothing changed, so read-only commands keep hand edits
+// and comments. It is safe to call multiple times and from any exit path.
+func (l *Launcher) Close() error {
+	var err error
+	l.closeOnce.Do(func() {
+		l.telemetry.Flush(context.Background())
+		l.cancel()
+		l.stopSSHForward()
+		l.statusMonitor.Stop()
+		if !l.configManager.HasUnsavedChanges() {
+			return
+		}
+		if saveErr := l.configManager.Save(); saveErr != nil {
+			err = fmt.Errorf("failed to save configuration: %w", saveErr)
+		}
+	})
+	return err
+}
+
+// IsOperationActive returns true while a cancellable operation is running
+func (l *Launcher) IsOperationActive() bool {
+	return l.interruptHandler.IsActive()
+}
+
+// SetJSONOutput makes commands that support it print JSON
+func (l *Launcher) SetJSONOutput(enabled bool) {
+	l.jsonOutput = enabled
+}
+
+// SetFailOnUnhealthy makes the services command fail when a service is
+// unhealthy
+func (l *Launcher) SetFailOnUnhealthy(enabled bool) {
+	l.failOnUnhealthy = enabled
 }
 
 // GetConfigManager returns the config manager (for CLI overrides)
@@ -71,8 +263,59 @@ func (l *Launcher) GetConfigManager() *config.ConfigManager {
 	return l.configManager
 }
 
+// SetInstallDir validates path and stores it as the DDALAB installation
+// without any prompts. In API mode the backend validates the path, otherwise
+// the local detector does. First-time setup is skipped afterwards.
+func (l *Launcher) SetInstallDir(path string) error {
+	// Operators may provision a fresh install but not move an existing one
+	if l.configManager.GetDDALABPath() != "" {
+		if err := l.checkAllowed("configure"); err != nil {
+			return err
+		}
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("invalid install directory '%s': %w", path, err)
+	}
+
+	if l.configManager.IsAPIMode() {
+		ctx, cancel := context.WithTimeout(l.ctx, 30*time.Second)
+		defer cancel()
+
+		result, err := l.apiClient.ValidatePath(ctx, absPath)
+		if err != nil {
+			return fmt.Errorf("failed to validate install directory via API: %w", err)
+		}
+		if !result.Valid {
+			return fmt.Errorf("invalid DDALAB installation at %s: %s", absPath, result.Message)
+		}
+	} else if err := l.detector.ValidateInstallation(l.ctx, absPath); err != nil {
+		return err
+	}
+
+	changed := absPath != l.configManager.GetDDALABPath()
+	l.configManager.SetDDALABPath(absPath)
+	if changed {
+		l.installationChanged()
+	}
+	if err := l.configManager.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	return nil
+}
+
 // Run starts the launcher application
 func (l *Launcher) Run() error {
+	// Recover from an update that was interrupted on the last run
+	if err := updater.NewUpdater(config.GetVersion()).CleanupUpdateArtifacts(); err != nil {
+		l.ui.ShowWarning(fmt.Sprintf("Could not clean up after an interrupted update: %v", err))
+	}
+
+	// A remote DDALAB must be reachable before the mode is detected
+	l.startSSHForward()
+
 	// Initialize operation mode
 	if err := l.modeManager.Initialize(); err != nil {
 		l.ui.ShowWarning(fmt.Sprintf("Mode initialization warning: %v", err))
@@ -81,48 +324,128 @@ func (l *Launcher) Run() error {
 
 	// Show mode information
 	l.ui.ShowInfo(l.modeManager.GetModeDescription())
+	l.checkFeatureRegression()
 
 	// Check if this is the first run
 	if l.configManager.IsFirstRun() {
 		return l.runFirstTimeSetup()
 	}
 
+	// A deleted installation or an unmounted drive is reported before the
+	// menu rather than by the first operation that fails
+	if err := l.ensureInstallation(); err != nil {
+		l.ui.ShowWarning("Continuing without the installation - most operations will not work until it is back")
+	}
+
 	// Show main menu for existing users
 	return l.runMainLoop()
 }
 
-// runFirstTimeSetup handles the initial setup process
+// showDockerIssue explains why Docker or the DDALAB extension is not
+// usable, with what to do about it
+func (l *Launcher) showDockerIssue() {
+	bootstrapper := l.modeManager.GetBootstrapper()
+	issue := bootstrapper.Issue()
+	if issue == nil {
+		return
+	}
+
+	if errors.Is(issue, bootstrap.ErrDockerNotInstalled) || errors.Is(issue, bootstrap.ErrDockerNotRunning) {
+		l.ui.ShowWarning(fmt.Sprintf("DDALAB needs Docker: %v", issue))
+	} else {
+		l.ui.ShowInfo(issue.Error())
+	}
+	l.ui.ShowInfo(bootstrapper.Remediation(issue))
+}
+
+// runFirstTimeSetup handles the initial setup process. Ctrl+C during
+// detection or validation aborts the setup without saving anything, so the
+// next launch starts the setup again.
 func (l *Launcher) runFirstTimeSetup() error {
 	l.ui.ShowWelcome()
+	l.askTelemetryConsent()
+	l.showDockerIssue()
+	if !l.modeManager.IsAPIMode() && l.expectsAPI() {
+		l.offerEndpointPicker()
+	}
+
+	ctx, cancel := l.interruptHandler.WithCancellableContext(l.ctx)
+	ddalabPath, err := l.setupInstallation(ctx)
+	cancel()
+	if interrupt.IsInterruptError(err) {
+		l.ui.ShowWarning("Setup was cancelled - nothing was saved")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	l.ui.ShowSuccess("DDALAB Launcher configured successfully!")
+	l.ui.ShowInfo(fmt.Sprintf("Installation path: %s", ddalabPath))
+
+	// Fresh installations only ship .env.example; offer to create a secured .env
+	if envPath, err := l.configManager.EnvFilePath(); err != nil && strings.Contains(err.Error(), ".env.example exists") {
+		l.ui.ShowInfo("No .env file found - DDALAB needs one before it can start")
+		if _, err := l.createEnvFromExample(envPath); err != nil {
+			l.ui.ShowWarning(err.Error())
+		}
+	}
+
+	// Ask if user wants to start DDALAB now. The start runs with its own
+	// interruptible context, like every operation from the main menu.
+	if l.ui.ConfirmOperation("start DDALAB now") {
+		return l.handleStartCommand()
+	}
+
+	return nil
+}
 
+// setupInstallation selects, validates and saves the DDALAB installation
+// for the first-time setup. The configuration is only saved if ctx is
+// still live after validation.
+func (l *Launcher) setupInstallation(ctx context.Context) (string, error) {
 	// Detect or configure DDALAB installation
-	ddalabPath, err := l.ui.SelectInstallation()
+	ddalabPath, err := l.ui.SelectInstallation(ctx)
 	if err != nil {
-		return fmt.Errorf("installation selection failed: %w", err)
+		return "", fmt.Errorf("installation selection failed: %w", err)
 	}
 
 	// Validate the installation
 	l.ui.ShowProgress("Validating DDALAB installation")
-	if err := l.detector.ValidateInstallation(ddalabPath); err != nil {
-		l.ui.ShowError(fmt.Sprintf("Installation validation failed: %v", err))
-		return err
+	if err := l.detector.ValidateInstallation(ctx, ddalabPath); err != nil {
+		if !interrupt.IsInterruptError(err) {
+			l.ui.ShowError(fmt.Sprintf("Installation validation failed: %v", err))
+		}
+		return "", err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return "", err
 	}
 
 	// Save configuration
+	changed := ddalabPath != l.configManager.GetDDALABPath()
 	l.configManager.SetDDALABPath(ddalabPath)
+	if changed {
+		l.installationChanged()
+	}
 	if err := l.configManager.Save(); err != nil {
-		return fmt.Errorf("failed to save configuration: %w", err)
+		return "", fmt.Errorf("failed to save configuration: %w", err)
 	}
 
-	l.ui.ShowSuccess("DDALAB Launcher configured successfully!")
-	l.ui.ShowInfo(fmt.Sprintf("Installation path: %s", ddalabPath))
+	return ddalabPath, nil
+}
 
-	// Ask if user wants to start DDALAB now
-	if l.ui.ConfirmOperation("start DDALAB now") {
-		return l.handleStartCommand()
-	}
+// menuStatus shows the service status next to the API reachability in the
+// menu header
+type menuStatus struct {
+	services *status.Monitor
+	api      *status.Reachability
+}
 
-	return nil
+// FormatStatus returns e.g. "🟢 Up (live) • API: connected (23ms)"
+func (s menuStatus) FormatStatus() string {
+	return s.services.FormatStatus() + " • " + s.api.FormatStatus()
 }
 
 // runMainLoop handles the main menu loop with enhanced error handling
@@ -132,15 +455,25 @@ func (l *Launcher) runMainLoop() error {
 		l.statusMonitor.Start()
 		defer l.statusMonitor.Stop()
 	}
+	l.reachability.Start()
+	defer l.reachability.Stop()
 
-	// Check for launcher updates on startup (background check)
+	// Check for launcher updates in the background
 	l.checkForUpdatesOnStartup()
 
 	for {
-		// Clear screen for better UX
-		fmt.Print("\033[2J\033[H")
+		l.handleUpdateCheckResult()
+		if l.relaunch != nil {
+			return nil
+		}
+		l.handleSSHForwardEvents()
 
-		choice, err := l.ui.ShowMainMenuWithStatus(l.statusMonitor)
+		// Clear screen for better UX, but keep the history on plain terminals
+		if !ui.IsSimple() {
+			fmt.Print("\033[2J\033[H")
+		}
+
+		choice, err := l.ui.ShowMainMenuWithStatus(menuStatus{l.statusMonitor, l.reachability})
 		if err != nil {
 			// Handle user cancellation gracefully
 			if err.Error() == "^C" || err.Error() == "interrupt" {
@@ -159,13 +492,19 @@ func (l *Launcher) runMainLoop() error {
 
 		// Handle the menu choice with error recovery
 		if err := l.handleMenuChoice(choice); err != nil {
+			l.recordFailure(choice, err)
 			l.ui.ShowError(err.Error())
 			l.ui.WaitForUser("Press Enter to return to main menu...")
 			continue
 		}
 
+		// A restart into an update leaves the menu right away
+		if l.relaunch != nil {
+			return nil
+		}
+
 		// Show success message and brief pause before returning to menu
-		fmt.Println("\n✅ Operation completed successfully!")
+		fmt.Printf("\n%s Operation completed successfully!\n", theme.Success)
 		l.ui.WaitForUser("Press Enter to return to main menu...")
 	}
 
@@ -174,12 +513,13 @@ func (l *Launcher) runMainLoop() error {
 
 // executeWithInterrupt executes a function with interrupt handling
 func (l *Launcher) executeWithInterrupt(operation string, fn func(ctx context.Context) error) error {
-	fmt.Printf("ℹ️  Press Ctrl+C to cancel %s\n", operation)
-
-	ctx, cancel := l.interruptHandler.WithCancellableContext(context.Background())
+	ctx, cancel := l.interruptHandler.WithCancellableContext(l.ctx)
 	defer cancel()
 
+	// The spinner shows the Ctrl+C hint and keeps operation output above it
+	stopSpinner := l.ui.StartSpinner(operation)
 	err := fn(ctx)
+	stopSpinner(err != nil)
 
 	if interrupt.IsInterruptError(err) {
 		l.ui.ShowWarning("Operation was cancelled")
@@ -194,34 +534,81 @@ func (l *Launcher) executeWithInterrupt(operation string, fn func(ctx context.Co
 	return err
 }
 
+// checkAllowed returns ErrNotPermitted if the configured role may not
+// perform the menu action. The menu already hides such actions; this guards
+// the commands and any other way of reaching the handlers.
+func (l *Launcher) checkAllowed(action string) error {
+	role := l.configManager.GetRole()
+	if !role.Allows(action) {
+		return fmt.Errorf("%w: '%s' requires the %s role (current role: %s)", ErrNotPermitted, action, config.RoleAdmin, role)
+	}
+	return nil
+}
+
 // handleMenuChoice processes the user's menu selection
 func (l *Launcher) handleMenuChoice(choice string) error {
-	fmt.Printf("\n🔄 Processing: %s\n", choice)
+	fmt.Printf("\n%s Processing: %s\n", theme.Progress, choice)
 	fmt.Println("═════════════════════════════════════")
 
+	if !installIndependent[choice] {
+		if err := l.ensureInstallation(); err != nil {
+			return err
+		}
+	}
+
 	switch choice {
 	case "Start DDALAB":
 		return l.handleStartCommand()
 	case "Stop DDALAB":
 		return l.handleStopCommand()
+	case "Deep Stop (remove orphans)":
+		return l.handleDeepStopCommand()
 	case "Restart DDALAB":
 		return l.handleRestartCommand()
+	case "Restart Unhealthy Services":
+		return l.handleRestartUnhealthyCommand()
 	case "Check Status":
 		return l.handleStatusCommand()
+	case "Live Dashboard":
+		return l.handleDashboardCommand()
+	case "Select Environment":
+		return l.handleEnvironmentCommand()
 	case "View Logs":
 		return l.handleLogsCommand()
+	case "Events":
+		return l.handleEventsCommand()
 	case "Bootstrap DDALAB":
 		return l.handleBootstrapCommand()
 	case "Edit Configuration":
 		return l.handleEditConfigCommand()
+	case "Apply .env and Restart":
+		return l.handleApplyEnvCommand()
+	case "Restore Previous .env":
+		return l.handleRestoreEnvCommand()
 	case "Configure Installation":
 		return l.handleConfigureCommand()
+	case "Open Installation Folder":
+		return l.handleOpenFolderCommand()
+	case "Show Compose Command":
+		return l.handleComposeCommand()
+	case "Regenerate Certificates":
+		return l.handleRegenerateCertsCommand()
 	case "Backup Database":
 		return l.handleBackupCommand()
 	case "Update DDALAB":
 		return l.handleUpdateCommand()
+	case "Check for DDALAB Updates":
+		return l.handleCheckDDALABUpdatesCommand()
+	case "Telemetry Settings":
+		return l.handleTelemetryCommand()
+	case "Reload Settings":
+		return l.handleReloadSettingsCommand()
 	case "Check for Launcher Updates":
 		return l.handleCheckUpdatesCommand()
+	case "Switch Update Channel":
+		return l.handleUpdateChannelCommand()
+	case "Export Diagnostics":
+		return l.handleExportDiagnosticsCommand()
 	case "Uninstall DDALAB":
 		return l.handleUninstallCommand()
 	case "Exit":
@@ -234,96 +621,239 @@ func (l *Launcher) handleMenuChoice(choice string) error {
 
 // handleStartCommand starts DDALAB services
 func (l *Launcher) handleStartCommand() error {
-	return l.executeWithInterrupt("starting DDALAB", func(ctx context.Context) error {
-		l.ui.ShowProgress("Starting DDALAB services")
-		if err := l.dispatcher.ExecuteCommand("start"); err != nil {
-			return fmt.Errorf("failed to start DDALAB: %w", err)
-		}
-
-		l.configManager.SetLastOperation("start")
-		l.ui.ShowSuccess("DDALAB started successfully!")
-		l.ui.ShowInfo("Access DDALAB at: https://localhost")
-
-		// Refresh status after starting
-		l.statusMonitor.CheckNow()
-		return nil
-	})
+	return l.operations.Run(controller.OpStart)
 }
 
 // handleStopCommand stops DDALAB services
 func (l *Launcher) handleStopCommand() error {
-	if !l.ui.ConfirmOperation("stop DDALAB") {
-		return nil
+	return l.stopDDALAB(controller.OpStop)
+}
+
+// handleDeepStopCommand stops DDALAB services and removes their containers,
+// including orphans left behind by services renamed in an upgrade
+func (l *Launcher) handleDeepStopCommand() error {
+	return l.stopDDALAB(controller.OpDeepStop)
+}
+
+// stopDDALAB runs a stopping operation. Running analyses would be lost,
+// so they are listed and must be confirmed separately.
+func (l *Launcher) stopDDALAB(op controller.Operation) error {
+	jobs := l.activeJobs()
+	if len(jobs) == 0 {
+		return l.operations.Run(op)
 	}
 
-	return l.executeWithInterrupt("stopping DDALAB", func(ctx context.Context) error {
-		l.ui.ShowProgress("Stopping DDALAB services")
-		if err := l.dispatcher.ExecuteCommand("stop"); err != nil {
-			return fmt.Errorf("failed to stop DDALAB: %w", err)
+	l.ui.ShowWarning(fmt.Sprintf("%d analyses running - stopping DDALAB aborts them", len(jobs)))
+	for _, job := range jobs {
+		name := job.Name
+		if name == "" {
+			name = job.ID
+		}
+		if !job.StartedAt.IsZero() {
+			name += fmt.Sprintf(" (started %s)", job.StartedAt.Local().Format("2006-01-02 15:04"))
 		}
+		l.ui.ShowInfo(name)
+	}
+	if !l.ui.ConfirmDangerousOperation("stop DDALAB anyway") {
+		return nil
+	}
+	return l.operations.RunConfirmed(op)
+}
 
-		l.configManager.SetLastOperation("stop")
-		l.ui.ShowSuccess("DDALAB stopped successfully!")
+// activeJobs returns the running analyses. Backends without the jobs
+// endpoint and failed requests yield none, so stopping falls back to the
+// normal confirmation.
+func (l *Launcher) activeJobs() []api.Job {
+	ctx, cancel := context.WithTimeout(l.ctx, activeJobsTimeout)
+	defer cancel()
 
-		// Refresh status after stopping
-		l.statusMonitor.CheckNow()
+	jobs, err := l.controller.ActiveJobs(ctx)
+	if err != nil {
 		return nil
-	})
+	}
+	return jobs
 }
 
 // handleRestartCommand restarts DDALAB services
 func (l *Launcher) handleRestartCommand() error {
-	if !l.ui.ConfirmOperation("restart DDALAB") {
-		return nil
-	}
+	return l.operations.Run(controller.OpRestart)
+}
 
-	return l.executeWithInterrupt("restarting DDALAB", func(ctx context.Context) error {
-		l.ui.ShowProgress("Restarting DDALAB services")
-		if err := l.dispatcher.ExecuteCommand("restart"); err != nil {
-			return fmt.Errorf("failed to restart DDALAB: %w", err)
+// restartDDALAB restarts DDALAB services without asking for confirmation
+func (l *Launcher) restartDDALAB() error {
+	return l.operations.RunConfirmed(controller.OpRestart)
+}
+
+// handleRestartUnhealthyCommand restarts only the services whose health
+// check fails, based on the last known status, instead of the whole stack
+func (l *Launcher) handleRestartUnhealthyCommand() error {
+	apiStatus := l.statusMonitor.GetDetails()
+	if apiStatus == nil {
+		l.ui.ShowProgress("Checking DDALAB status")
+		ctx, cancel := context.WithTimeout(l.ctx, 30*time.Second)
+		defer cancel()
+
+		var err error
+		if apiStatus, err = l.controller.Status(ctx); err != nil {
+			return fmt.Errorf("failed to check status: %w", err)
 		}
+	}
 
-		l.configManager.SetLastOperation("restart")
-		l.ui.ShowSuccess("DDALAB restarted successfully!")
+	unhealthy := controller.UnhealthyServices(apiStatus)
+	if len(unhealthy) == 0 {
+		l.ui.ShowSuccess("All services are healthy - nothing to restart")
+		return nil
+	}
 
-		// Refresh status after restarting
-		l.statusMonitor.CheckNow()
+	names := make([]string, len(unhealthy))
+	for i, service := range unhealthy {
+		names[i] = service.Name
+		l.ui.ShowInfo(fmt.Sprintf("%s is %s", service.Name, service.Health))
+	}
+	if !l.ui.ConfirmOperation(fmt.Sprintf("restart %d unhealthy service(s)", len(names))) {
 		return nil
+	}
+
+	var results []controller.ServiceResult
+	err := l.executeWithInterrupt("restarting unhealthy services", func(ctx context.Context) error {
+		var err error
+		results, err = l.controller.RestartServices(ctx, names, func(result controller.ServiceResult) {
+			l.reportStep(result.Service, result.Elapsed, result.Err)
+		})
+		if err != nil {
+			return err
+		}
+		return ctx.Err()
 	})
+	l.statusMonitor.CheckNow()
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d services failed to restart", failed, len(names))
+	}
+
+	l.ui.ShowSuccess(fmt.Sprintf("Restarted %d unhealthy service(s)", len(names)))
+	return nil
 }
 
 // handleStatusCommand shows DDALAB service status
 func (l *Launcher) handleStatusCommand() error {
 	l.ui.ShowProgress("Checking DDALAB status")
 
-	if err := l.dispatcher.ExecuteCommand("status"); err != nil {
+	ctx, cancel := context.WithTimeout(l.ctx, 30*time.Second)
+	defer cancel()
+
+	apiStatus, err := l.controller.Status(ctx)
+	if err != nil {
 		return fmt.Errorf("failed to check status: %w", err)
 	}
 
+	fmt.Print(commands.FormatStatus(apiStatus))
+	if environment := l.configManager.GetEnvironment(); environment != "" {
+		fmt.Printf("\nEnvironment: %s (%s)\n", environment, l.configManager.EnvironmentDir())
+	}
+	if apiClient := l.modeManager.GetAPIClient(); apiClient != nil && apiClient.ServerVersion() != "" {
+		fmt.Printf("\nServer: %s (API %s)\n", apiClient.ServerVersion(), apiClient.APIVersion())
+	}
+	if unhealthy := controller.UnhealthyServices(apiStatus); len(unhealthy) > 0 {
+		fmt.Printf("\n%d service(s) unhealthy - use 'Restart Unhealthy Services' (or the restart-unhealthy command) to restart only those\n", len(unhealthy))
+	}
+
 	return nil
 }
 
+// handleDashboardCommand shows the live status dashboard until the user
+// quits it
+func (l *Launcher) handleDashboardCommand() error {
+	if !terminal.IsTerminal() || ui.IsSimple() {
+		return fmt.Errorf("the dashboard needs an interactive terminal, use 'Check Status' instead")
+	}
+
+	if !l.statusMonitor.IsRunning() {
+		l.statusMonitor.Start()
+		defer l.statusMonitor.Stop()
+	}
+
+	return ui.RunDashboard(l.statusMonitor, controller.DefaultAccessURL, l.dashboardLogs)
+}
+
+// dashboardLogs fetches a service's logs for the dashboard's log view
+func (l *Launcher) dashboardLogs(service string, tail int, level string) (string, error) {
+	ctx, cancel := context.WithTimeout(l.ctx, 15*time.Second)
+	defer cancel()
+
+	return l.controller.Logs(ctx, controller.LogOptions{Service: service, Tail: tail, Level: level})
+}
+
 // handleLogsCommand shows DDALAB service logs
 func (l *Launcher) handleLogsCommand() error {
+	service, tail, err := l.ui.SelectLogOptions(l.knownServices())
+	if err != nil {
+		return nil // Selection cancelled
+	}
+
 	return l.executeWithInterrupt("fetching logs", func(ctx context.Context) error {
 		l.ui.ShowProgress("Fetching DDALAB logs")
 
-		if err := l.dispatcher.ExecuteCommand("logs"); err != nil {
-			return fmt.Errorf("failed to get logs: %w", err)
+		logs, err := l.controller.Logs(ctx, controller.LogOptions{Service: service, Tail: tail})
+		if err != nil {
+			return err
 		}
+		l.ui.Println(logs)
 
 		l.ui.ShowInfo("To view live logs, use: docker-compose logs -f")
 		return nil
 	})
 }
 
+// knownServices returns the service names from the current backend status,
+// or from the installation's compose file while the backend does not answer
+func (l *Launcher) knownServices() []string {
+	apiClient := l.modeManager.GetAPIClient()
+	if apiClient == nil {
+		return l.composeServices()
+	}
+
+	ctx, cancel := context.WithTimeout(l.ctx, 5*time.Second)
+	defer cancel()
+
+	apiStatus, err := apiClient.GetStatus(ctx)
+	if err != nil {
+		return l.composeServices()
+	}
+
+	services := make([]string, 0, len(apiStatus.Services))
+	for _, service := range apiStatus.Services {
+		services = append(services, service.Name)
+	}
+	return services
+}
+
+// composeServices returns the services defined in the compose file of the
+// configured installation
+func (l *Launcher) composeServices() []string {
+	path := l.configManager.GetDDALABPath()
+	if path == "" {
+		return nil
+	}
+	return l.detector.DetectInstallation(path).Services
+}
+
 // handleBootstrapCommand bootstraps DDALAB services when the API backend is not available
 func (l *Launcher) handleBootstrapCommand() error {
 	// Check if bootstrap is available
 	bootstrapper := l.modeManager.GetBootstrapper()
-	if !bootstrapper.CanBootstrap() {
-		l.ui.ShowError("Bootstrap is not available")
-		l.ui.ShowInfo("Bootstrap requires Docker to be running")
+	if err := bootstrapper.CheckDocker(); err != nil {
+		l.ui.ShowError(fmt.Sprintf("Bootstrap is not available: %v", err))
+		l.ui.ShowInfo(bootstrapper.Remediation(err))
 		return nil
 	}
 
@@ -331,46 +861,34 @@ func (l *Launcher) handleBootstrapCommand() error {
 	l.ui.ShowInfo("Bootstrap will start minimal DDALAB services")
 	l.ui.ShowInfo(fmt.Sprintf("Bootstrap mode: %s", bootstrapper.GetBootstrapMode()))
 
-	if !l.ui.ConfirmOperation("bootstrap DDALAB services") {
-		return nil
-	}
-
-	return l.executeWithInterrupt("bootstrapping DDALAB", func(ctx context.Context) error {
-		l.ui.ShowProgress("Bootstrapping DDALAB services")
-		l.ui.ShowInfo("This may take a few minutes...")
-
-		if err := l.modeManager.PerformBootstrap(); err != nil {
-			return fmt.Errorf("bootstrap failed: %w", err)
-		}
-
-		l.configManager.SetLastOperation("bootstrap")
-		l.ui.ShowSuccess("DDALAB bootstrap completed successfully!")
-		l.ui.ShowInfo("Launcher will now use API mode for future operations")
-		l.ui.ShowInfo("Access DDALAB at: https://localhost")
-
-		// Refresh status after bootstrap
-		l.statusMonitor.CheckNow()
-		return nil
-	})
+	return l.operations.Run(controller.OpBootstrap)
 }
 
 // handleConfigureCommand reconfigures the DDALAB installation
 func (l *Launcher) handleConfigureCommand() error {
+	if err := l.checkAllowed("configure"); err != nil {
+		return err
+	}
+
 	l.ui.ShowInfo("Reconfiguring DDALAB installation...")
 
-	ddalabPath, err := l.ui.SelectInstallation()
+	ddalabPath, err := l.ui.SelectInstallation(l.ctx)
 	if err != nil {
 		return fmt.Errorf("installation selection failed: %w", err)
 	}
 
 	// Validate the new installation
 	l.ui.ShowProgress("Validating new installation")
-	if err := l.detector.ValidateInstallation(ddalabPath); err != nil {
+	if err := l.detector.ValidateInstallation(l.ctx, ddalabPath); err != nil {
 		return fmt.Errorf("installation validation failed: %w", err)
 	}
 
 	// Save new configuration
+	changed := ddalabPath != l.configManager.GetDDALABPath()
 	l.configManager.SetDDALABPath(ddalabPath)
+	if changed {
+		l.installationChanged()
+	}
 	if err := l.configManager.Save(); err != nil {
 		return fmt.Errorf("failed to save configuration: %w", err)
 	}
@@ -387,51 +905,94 @@ func (l *Launcher) handleConfigureCommand() error {
 	return nil
 }
 
-// handleBackupCommand creates a database backup
-func (l *Launcher) handleBackupCommand() error {
-	return l.executeWithInterrupt("creating backup", func(ctx context.Context) error {
-		l.ui.ShowProgress("Creating database backup")
-
-		if err := l.dispatcher.ExecuteCommand("backup"); err != nil {
-			return fmt.Errorf("backup failed: %w", err)
+// installationChanged drops what was derived from the previous
+// installation, so the menu and status show only the new one: the
+// detector's file cache, an environment the new installation lacks, and
+// the monitor's last status and service details
+func (l *Launcher) installationChanged() {
+	l.detector.ClearCache()
+
+	if environment := l.configManager.GetEnvironment(); environment != "" {
+		if err := l.configManager.SetEnvironment(environment); err != nil {
+			_ = l.configManager.SetEnvironment("")
+			l.ui.ShowInfo(fmt.Sprintf("Environment '%s' does not exist in the new installation, using the default", environment))
 		}
+	}
 
-		l.configManager.SetLastOperation("backup")
-		l.ui.ShowSuccess("Database backup created successfully!")
-		return nil
-	})
+	l.statusMonitor.Reset()
 }
 
-// handleUpdateCommand updates DDALAB to the latest version
-func (l *Launcher) handleUpdateCommand() error {
-	if !l.ui.ConfirmOperation("update DDALAB to the latest version") {
-		return nil
+// handleEnvironmentCommand lets the user pick the deployment directory
+// whose compose file and .env the launcher operates on
+func (l *Launcher) handleEnvironmentCommand() error {
+	if err := l.checkAllowed("environment"); err != nil {
+		return err
 	}
 
-	return l.executeWithInterrupt("updating DDALAB", func(ctx context.Context) error {
-		l.ui.ShowProgress("Updating DDALAB")
-		l.ui.ShowInfo("This may take a few minutes...")
+	environments := config.FindEnvironments(l.configManager.GetDDALABPath())
+	if len(environments) == 0 {
+		return fmt.Errorf("no deployment environments found in %s", l.configManager.GetDDALABPath())
+	}
 
-		if err := l.dispatcher.ExecuteCommand("update"); err != nil {
-			return fmt.Errorf("update failed: %w", err)
-		}
+	name, err := l.ui.SelectEnvironment(environments, l.configManager.GetEnvironment())
+	if err != nil {
+		return err
+	}
 
-		l.configManager.SetLastOperation("update")
-		l.ui.ShowSuccess("DDALAB updated successfully!")
-		return nil
-	})
+	if err := l.configManager.SetEnvironment(name); err != nil {
+		return err
+	}
+	if err := l.configManager.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	l.ui.ShowSuccess(fmt.Sprintf("Active environment: %s (%s)", name, l.configManager.EnvironmentDir()))
+	return nil
+}
+
+// handleOpenFolderCommand opens the DDALAB installation in the file manager
+func (l *Launcher) handleOpenFolderCommand() error {
+	ddalabPath := l.configManager.GetDDALABPath()
+	if ddalabPath == "" {
+		return fmt.Errorf("no DDALAB installation configured - use 'Configure Installation' first")
+	}
+
+	if info, err := os.Stat(ddalabPath); err != nil || !info.IsDir() {
+		return fmt.Errorf("installation folder not found: %s", ddalabPath)
+	}
+
+	if err := terminal.Open(ddalabPath); err != nil {
+		return err
+	}
+
+	l.ui.ShowSuccess(fmt.Sprintf("Opened %s", ddalabPath))
+	return nil
+}
+
+// handleBackupCommand creates a database backup
+func (l *Launcher) handleBackupCommand() error {
+	return l.operations.Run(controller.OpBackup)
+}
+
+// handleUpdateCommand updates DDALAB to the latest version
+func (l *Launcher) handleUpdateCommand() error {
+	return l.operations.Run(controller.OpUpdate)
 }
 
 // handleUninstallCommand removes DDALAB installation
 func (l *Launcher) handleUninstallCommand() error {
+	if err := l.checkAllowed("uninstall"); err != nil {
+		return err
+	}
+
 	l.ui.ShowWarning("This will stop all DDALAB services and remove all data!")
 
-	if !l.ui.ConfirmOperation("completely uninstall DDALAB") {
+	if !l.ui.ConfirmDangerousOperation("completely uninstall DDALAB") {
 		return nil
 	}
 
 	// Double confirmation for destructive operation
-	if !l.ui.ConfirmOperation("permanently delete all DDALAB data") {
+	if !l.ui.ConfirmDangerousOperation("permanently delete all DDALAB data") {
 		return nil
 	}
 
@@ -449,23 +1010,29 @@ func (l *Launcher) handleUninstallCommand() error {
 
 // handleEditConfigCommand opens the configuration editor
 func (l *Launcher) handleEditConfigCommand() error {
+	if err := l.checkAllowed("edit-config"); err != nil {
+		return err
+	}
+
+	servicesRunning := l.areServicesRunning()
+	if servicesRunning {
+		l.ui.ShowWarning("DDALAB is currently running!")
+		l.ui.ShowInfo("Configuration changes only take effect after a restart.")
+		l.ui.ShowInfo("Changing database credentials while services are up can cause authentication failures.")
+	}
+
 	// Find the .env file in the DDALAB installation
-	ddalabPath := l.configManager.GetDDALABPath()
-	envPath, err := config.GetEnvFilePath(ddalabPath)
+	envPath, err := l.configManager.EnvFilePath()
 	if err != nil {
 		if strings.Contains(err.Error(), ".env.example exists") {
 			l.ui.ShowWarning("No .env file found!")
 			l.ui.ShowInfo("You need to create a .env file first from the .env.example template.")
 
-			examplePath := strings.Replace(envPath, ".env", ".env.example", 1)
-			l.ui.ShowInfo(fmt.Sprintf("Example file location: %s", examplePath))
-
-			if l.ui.ConfirmOperation("copy .env.example to .env now") {
-				if copyErr := config.CopyFile(examplePath, envPath); copyErr != nil {
-					return fmt.Errorf("failed to copy .env.example: %w", copyErr)
-				}
-				l.ui.ShowSuccess("Created .env file from template")
-			} else {
+			created, createErr := l.createEnvFromExample(envPath)
+			if createErr != nil {
+				return createErr
+			}
+			if !created {
 				return nil
 			}
 		} else {
@@ -473,34 +1040,330 @@ func (l *Launcher) handleEditConfigCommand() error {
 		}
 	}
 
+	if err := l.checkEnvFile(envPath); err != nil {
+		return err
+	}
+
+	if ui.IsSimple() {
+		l.ui.ShowWarning("The configuration editor needs the full-screen terminal UI")
+		l.ui.ShowInfo(fmt.Sprintf("Edit %s with a text editor instead", envPath))
+		return nil
+	}
+
 	l.ui.ShowInfo(fmt.Sprintf("Opening configuration editor for: %s", envPath))
-	l.ui.ShowInfo("Use arrow keys to navigate, Enter to edit, / to search, s to save, q to quit")
+	if servicesRunning {
+		l.ui.ShowInfo("Use arrow keys to navigate, Enter to edit, / to search, s to save, a to save and restart, q to quit")
+	} else {
+		l.ui.ShowInfo("Use arrow keys to navigate, Enter to edit, / to search, s to save, q to quit")
+	}
 	l.ui.WaitForUser("Press Enter to open editor...")
 
 	// Clear screen before launching editor
 	fmt.Print("\033[2J\033[H")
 
 	// Run the configuration editor
-	if err := config.RunConfigEditor(envPath); err != nil {
+	savedKeys, restart, err := config.RunConfigEditor(envPath, config.EditorOptions{
+		OfferRestart: servicesRunning,
+		Validate:     l.envValidator(),
+	})
+	if err != nil {
 		return fmt.Errorf("configuration editor failed: %w", err)
 	}
 
 	// Clear screen and show completion message
 	fmt.Print("\033[2J\033[H")
 	l.ui.ShowSuccess("Configuration editor closed")
-	l.ui.ShowInfo("If you made changes, you may need to restart DDALAB for them to take effect")
+
+	var sensitiveKeys, unsafeKeys []string
+	for _, key := range savedKeys {
+		if config.IsRestartSensitiveVar(key) {
+			sensitiveKeys = append(sensitiveKeys, key)
+		}
+		if config.IsUnsafeWhileRunningVar(key) {
+			unsafeKeys = append(unsafeKeys, key)
+		}
+	}
+
+	if restart {
+		if len(unsafeKeys) > 0 {
+			l.ui.ShowWarning(fmt.Sprintf("Credentials changed while running: %s - existing data volumes may still use the old values", strings.Join(unsafeKeys, ", ")))
+		}
+		l.ui.ShowInfo("Configuration saved - restarting DDALAB to apply it")
+		return l.restartDDALAB()
+	}
+
+	if !servicesRunning || len(sensitiveKeys) == 0 {
+		if len(savedKeys) > 0 {
+			l.ui.ShowInfo("If you made changes, you may need to restart DDALAB for them to take effect")
+		}
+		return nil
+	}
+
+	l.ui.ShowWarning(fmt.Sprintf("Changed settings require a restart: %s", strings.Join(sensitiveKeys, ", ")))
+	if len(unsafeKeys) > 0 {
+		l.ui.ShowWarning(fmt.Sprintf("Credentials changed while running: %s - existing data volumes may still use the old values", strings.Join(unsafeKeys, ", ")))
+	}
+
+	if l.ui.ConfirmOperation("restart DDALAB now to apply the changes") {
+		return l.restartDDALAB()
+	}
+
+	return nil
+}
+
+// envValidator returns the editor's backend check for API mode, or nil if
+// the backend cannot validate .env changes
+func (l *Launcher) envValidator() func([]config.EnvVar) ([]config.EnvIssue, error) {
+	if !l.modeManager.IsAPIMode() {
+		return nil
+	}
+	client := l.modeManager.GetAPIClient()
+	if client == nil || !client.HasFeature(api.FeatureEnvValidate) {
+		return nil
+	}
+
+	return func(variables []config.EnvVar) ([]config.EnvIssue, error) {
+		ctx, cancel := context.WithTimeout(l.ctx, 10*time.Second)
+		defer cancel()
+
+		report, err := l.controller.ValidateEnv(ctx, variables)
+		if err != nil {
+			return nil, err
+		}
+		var issues []config.EnvIssue
+		rejected := false
+		for _, issue := range report.Issues {
+			isError := issue.Severity == "error"
+			rejected = rejected || isError
+			issues = append(issues, config.EnvIssue{Key: issue.Key, Message: issue.Message, Error: isError})
+		}
+		if !report.Valid && !rejected {
+			// Never save a configuration the backend rejects, even unexplained
+			issues = append(issues, config.EnvIssue{Key: ".env", Message: "rejected without details", Error: true})
+		}
+		return issues, nil
+	}
+}
+
+// handleApplyEnvCommand restarts DDALAB so that changes made to the .env
+// file outside the editor, e.g. in a text editor, take effect
+func (l *Launcher) handleApplyEnvCommand() error {
+	envPath, err := l.configManager.EnvFilePath()
+	if err != nil {
+		return fmt.Errorf("could not find .env file: %w", err)
+	}
+
+	envConfig, err := config.LoadEnvFile(envPath)
+	if err != nil {
+		return fmt.Errorf("failed to load .env file: %w", err)
+	}
+	if invalid := envConfig.InvalidVariables(); len(invalid) > 0 {
+		return fmt.Errorf("not applied: %s %s", invalid[0].Key, invalid[0].ValidateValue(invalid[0].Value))
+	}
+
+	if !l.areServicesRunning() {
+		l.ui.ShowInfo("DDALAB is not running - the .env is used the next time it starts")
+		return nil
+	}
+
+	if !l.ui.ConfirmOperation("restart DDALAB now to apply the .env") {
+		return nil
+	}
+	return l.restartDDALAB()
+}
+
+// handleRestoreEnvCommand rolls the .env file back to one of the backups
+// kept by the configuration editor
+func (l *Launcher) handleRestoreEnvCommand() error {
+	if err := l.checkAllowed("restore-env"); err != nil {
+		return err
+	}
+
+	envPath, err := l.configManager.EnvFilePath()
+	if err != nil {
+		return fmt.Errorf("could not find .env file: %w", err)
+	}
+
+	backups, err := config.ListEnvBackups(envPath)
+	if err != nil {
+		return fmt.Errorf("failed to list .env backups: %w", err)
+	}
+	if len(backups) == 0 {
+		l.ui.ShowInfo("No .env backups yet - one is kept each time the configuration editor saves")
+		return nil
+	}
+
+	backup, err := l.ui.SelectEnvBackup(backups)
+	if err != nil {
+		return err
+	}
+
+	if !l.ui.ConfirmOperation(fmt.Sprintf("replace .env with the version from %s", backup.Time.Format("2006-01-02 15:04:05"))) {
+		return nil
+	}
+
+	if err := config.RestoreEnvBackup(envPath, backup); err != nil {
+		return err
+	}
+	l.ui.ShowSuccess(fmt.Sprintf("Restored .env from %s", filepath.Base(backup.Path)))
+
+	if l.areServicesRunning() {
+		if l.ui.ConfirmOperation("restart DDALAB now to apply the restored configuration") {
+			return l.restartDDALAB()
+		}
+		return nil
+	}
+	l.ui.ShowInfo("The restored configuration is used the next time DDALAB starts")
+	return nil
+}
+
+// checkEnvFile makes sure the editor can load and save the .env file. A
+// permission problem is offered to be fixed; a file that is not text is
+// reported with the offending line.
+func (l *Launcher) checkEnvFile(envPath string) error {
+	err := config.CheckEnvAccess(envPath)
+	if errors.Is(err, os.ErrPermission) {
+		l.ui.ShowWarning(fmt.Sprintf("%s cannot be read or written by the launcher", envPath))
+		if !l.ui.ConfirmOperation("change its permissions so only you can read and write it (chmod 600)") {
+			return fmt.Errorf("cannot edit %s: %w", envPath, err)
+		}
+		if err := config.FixEnvPermissions(envPath); err != nil {
+			if runtime.GOOS != "windows" {
+				l.ui.ShowInfo(fmt.Sprintf("The file may belong to another user - take it over with: sudo chown $USER %s", envPath))
+			}
+			return err
+		}
+		if err := config.CheckEnvAccess(envPath); err != nil {
+			return fmt.Errorf("cannot edit %s: %w", envPath, err)
+		}
+		l.ui.ShowSuccess("Permissions fixed")
+	} else if err != nil {
+		return fmt.Errorf("cannot open %s: %w", envPath, err)
+	}
+
+	if _, err := config.LoadEnvFile(envPath); err != nil {
+		var encodingErr *config.EncodingError
+		if errors.As(err, &encodingErr) {
+			l.ui.ShowInfo("Fix the line in a text editor or roll back with 'Restore Previous .env'")
+		}
+		return err
+	}
+	return nil
+}
+
+// areServicesRunning reports whether the status monitor sees DDALAB as up
+func (l *Launcher) areServicesRunning() bool {
+	currentStatus := l.statusMonitor.GetStatus()
+	if !l.statusMonitor.IsRunning() {
+		currentStatus = l.statusMonitor.CheckNow()
+	}
+	return currentStatus == status.StatusUp || currentStatus == status.StatusStarting
+}
+
+// handleExportDiagnosticsCommand writes a diagnostics report for bug reports
+// and copies it to the clipboard
+func (l *Launcher) handleExportDiagnosticsCommand() error {
+	l.ui.ShowProgress("Collecting diagnostics")
+
+	report := diagnostics.Collect(l.configManager, l.modeManager, l.detector)
+
+	reportPath, err := diagnostics.DefaultPath()
+	if err != nil {
+		return err
+	}
+
+	if err := report.WriteFile(reportPath); err != nil {
+		return err
+	}
+	l.ui.ShowSuccess(fmt.Sprintf("Diagnostics saved to %s", reportPath))
+
+	if err := terminal.CopyToClipboard(report.Markdown()); err != nil {
+		l.ui.ShowWarning(fmt.Sprintf("Could not copy diagnostics to clipboard: %v", err))
+	} else {
+		l.ui.ShowInfo("Diagnostics copied to clipboard - paste them into your bug report")
+	}
+
+	l.ui.ShowInfo("Secrets have been redacted, but please review the report before sharing")
+
+	l.offerSupportBundle()
+
+	if !report.ModeStatus.APIAvailable {
+		l.offerEndpointPicker()
+	}
+	return nil
+}
+
+// createEnvFromExample offers to copy .env.example to envPath and then to
+// replace template placeholders with generated secrets. It returns false if
+// the user declined to create the file.
+func (l *Launcher) createEnvFromExample(envPath string) (bool, error) {
+	examplePath := strings.Replace(envPath, ".env", ".env.example", 1)
+	l.ui.ShowInfo(fmt.Sprintf("Example file location: %s", examplePath))
+
+	if !l.ui.ConfirmOperation("copy .env.example to .env now") {
+		return false, nil
+	}
+
+	if err := config.CopyFile(examplePath, envPath); err != nil {
+		return false, fmt.Errorf("failed to copy .env.example: %w", err)
+	}
+	l.ui.ShowSuccess("Created .env file from template")
+
+	if l.ui.ConfirmOperation("secure this configuration by generating strong secrets") {
+		if err := l.secureEnvFile(envPath); err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+// secureEnvFile replaces placeholder secrets in the .env file with generated ones
+func (l *Launcher) secureEnvFile(envPath string) error {
+	envConfig, err := config.LoadEnvFile(envPath)
+	if err != nil {
+		return fmt.Errorf("failed to load .env file: %w", err)
+	}
+
+	result, err := envConfig.SecureDefaults()
+	if err != nil {
+		return fmt.Errorf("failed to generate secrets: %w", err)
+	}
+
+	if len(result.Generated) > 0 {
+		if err := envConfig.SaveEnvFile(); err != nil {
+			return fmt.Errorf("failed to save .env file: %w", err)
+		}
+		l.ui.ShowSuccess(fmt.Sprintf("Generated %d secrets: %s", len(result.Generated), strings.Join(result.Generated, ", ")))
+	} else {
+		l.ui.ShowInfo("No placeholder secrets found")
+	}
+
+	if len(result.NeedsAttention) > 0 {
+		l.ui.ShowWarning(fmt.Sprintf("These variables still need to be set by hand: %s", strings.Join(result.NeedsAttention, ", ")))
+	}
 
 	return nil
 }
 
 // handleCheckUpdatesCommand checks for launcher updates
 func (l *Launcher) handleCheckUpdatesCommand() error {
+	if l.configManager.IsOffline() {
+		l.ui.ShowInfo("Offline mode is enabled - launcher updates are not checked")
+		return nil
+	}
+
+	return l.checkLauncherUpdates()
+}
+
+// checkLauncherUpdates checks the configured channel for a launcher update
+// and offers to install it. A build newer than the channel's latest
+// release, e.g. a beta on the stable channel, is offered a downgrade.
+func (l *Launcher) checkLauncherUpdates() error {
 	return l.executeWithInterrupt("checking for updates", func(ctx context.Context) error {
-		l.ui.ShowProgress("Checking for launcher updates")
+		l.ui.ShowProgress(fmt.Sprintf("Checking for launcher updates (%s channel)", l.configManager.GetUpdateChannel()))
 
-		// Create updater - use the actual binary version, not the config version
-		currentVersion := config.GetVersion()
-		updaterInstance := updater.NewUpdater(currentVersion)
+		updaterInstance := l.newUpdater()
 
 		// Check for updates
 		updateInfo, err := updaterInstance.CheckForUpdates(ctx)
@@ -508,13 +1371,24 @@ func (l *Launcher) handleCheckUpdatesCommand() error {
 			return fmt.Errorf("failed to check for updates: %w", err)
 		}
 
-		// Record the check time
-		l.configManager.SetLastUpdateCheck(time.Now())
+		// Record the successful check, which also resets the failure count
+		l.configManager.RecordUpdateCheck(false)
 		if err := l.configManager.Save(); err != nil {
 			l.ui.ShowWarning(fmt.Sprintf("Failed to save last update check time: %v", err))
 		}
 
+		if updateInfo.IsAhead() && updateInfo.Channel == updater.ChannelStable {
+			l.ui.ClearUpdateNotice()
+			l.ui.ShowInfo(fmt.Sprintf("Version %s is newer than the latest stable release %s",
+				updateInfo.CurrentVersion, updateInfo.LatestVersion))
+			if updateInfo.DownloadURL == "" || !l.ui.ConfirmOperation(fmt.Sprintf("downgrade to the stable release %s", updateInfo.LatestVersion)) {
+				return nil
+			}
+			return l.performLauncherUpdate(ctx, updaterInstance, updateInfo)
+		}
+
 		if !updateInfo.HasUpdate {
+			l.ui.ClearUpdateNotice()
 			l.ui.ShowSuccess("You're running the latest version!")
 			l.ui.ShowInfo(fmt.Sprintf("Current version: %s", updateInfo.CurrentVersion))
 			l.ui.ShowInfo(fmt.Sprintf("Latest version: %s", updateInfo.LatestVersion))
@@ -524,17 +1398,26 @@ func (l *Launcher) handleCheckUpdatesCommand() error {
 
 		// Show update information
 		l.ui.ShowSuccess("A new version is available!")
+		if updateInfo.Prerelease {
+			l.ui.ShowWarning("This is a beta build and may be unstable")
+		}
 		l.ui.ShowInfo(fmt.Sprintf("Current version: %s", updateInfo.CurrentVersion))
 		l.ui.ShowInfo(fmt.Sprintf("Latest version: %s", updateInfo.LatestVersion))
 		l.ui.ShowInfo(fmt.Sprintf("Released: %s", updateInfo.PublishedAt.Format("January 2, 2006")))
 
-		if updateInfo.Size > 0 {
+		if updateInfo.Patch != nil && updateInfo.Patch.Size > 0 {
+			l.ui.ShowInfo(fmt.Sprintf("Download size: %s (delta update, full download: %s)",
+				updater.FormatSize(updateInfo.Patch.Size), updater.FormatSize(updateInfo.Size)))
+		} else if updateInfo.Size > 0 {
 			l.ui.ShowInfo(fmt.Sprintf("Download size: %s", updater.FormatSize(updateInfo.Size)))
 		}
 
 		if updateInfo.ReleaseNotes != "" {
-			fmt.Println("\n📋 Release Notes:")
-			fmt.Println(updateInfo.ReleaseNotes)
+			l.ui.Println(fmt.Sprintf("\n%s Release Notes:", theme.Logs))
+			l.ui.Println(ui.RenderMarkdown(updateInfo.ReleaseNotes))
+		}
+		if updateInfo.IsMajorUpgrade() {
+			l.ui.ShowWarning("This is a new major version and may contain breaking changes")
 		}
 
 		if updateInfo.DownloadURL == "" {
@@ -558,23 +1441,22 @@ func (l *Launcher) performLauncherUpdate(ctx context.Context, updaterInstance *u
 	l.ui.ShowProgress("Downloading update")
 	l.ui.ShowInfo("This may take a moment...")
 
-	err := updaterInstance.PerformUpdate(ctx, updateInfo.DownloadURL)
+	tracker := progress.NewTracker(updateInfo.Size)
+	updaterInstance.SetTracker(tracker)
+	l.ui.TrackProgress(tracker)
+
+	err := updaterInstance.PerformUpdate(ctx, updateInfo)
 	if err != nil {
 		return fmt.Errorf("failed to apply update: %w", err)
 	}
 
+	l.ui.ShowInfo(fmt.Sprintf("Downloaded %s in %s",
+		progress.FormatBytes(tracker.Current()), progress.FormatDuration(tracker.Elapsed())))
+
+	l.ui.ClearUpdateNotice()
 	l.ui.ShowSuccess("Update completed successfully!")
 	l.ui.ShowInfo(fmt.Sprintf("Updated to version %s", updateInfo.LatestVersion))
-
-	// Platform-specific restart instructions
-	switch runtime.GOOS {
-	case "windows":
-		l.ui.ShowInfo("The launcher will be replaced automatically when you exit.")
-		l.ui.ShowInfo("Please close this window and start the launcher again.")
-	default:
-		l.ui.ShowInfo("The update has been applied to the binary.")
-		l.ui.ShowInfo("Please restart the launcher to use the new version.")
-	}
+	l.ui.ShowWhatChanged(updateInfo.LatestVersion, updateInfo.ReleaseNotes, updateInfo.ChangelogURL(), updateInfo.IsMajorUpgrade())
 
 	// Update the version in config
 	l.configManager.GetConfig().Version = updateInfo.LatestVersion
@@ -582,43 +1464,45 @@ func (l *Launcher) performLauncherUpdate(ctx context.Context, updaterInstance *u
 		l.ui.ShowWarning(fmt.Sprintf("Failed to save version info: %v", err))
 	}
 
+	l.offerRelaunch(updaterInstance, updateInfo.LatestVersion)
 	return nil
 }
 
-// checkForUpdatesOnStartup performs automatic update checks if enabled
-func (l *Launcher) checkForUpdatesOnStartup() {
-	// Skip if auto-update is disabled or not time to check
-	if !l.configManager.ShouldCheckForUpdates() {
+// offerRelaunch asks whether to restart into the installed update. On Unix
+// the launcher then leaves the menu and execs the new binary after its
+// usual cleanup; on Windows it closes so the swap script can replace the
+// binary, and must be reopened by hand.
+func (l *Launcher) offerRelaunch(updaterInstance *updater.Updater, version string) {
+	if runtime.GOOS == "windows" {
+		l.ui.ShowInfo("The launcher will be replaced automatically when you exit.")
+		if l.ui.ConfirmOperation(fmt.Sprintf("close the launcher now to finish the update to %s", version)) {
+			l.relaunch = updaterInstance
+			l.ui.ShowInfo("Start the launcher again once this window has closed.")
+			return
+		}
+		l.ui.ShowInfo("Please close this window and start the launcher again.")
 		return
 	}
 
-	// Show brief message about background check
-	l.ui.ShowInfo("Checking for launcher updates...")
-
-	// Create a context with timeout for background check
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	// Use the actual binary version, not the config version
-	currentVersion := config.GetVersion()
-	updaterInstance := updater.NewUpdater(currentVersion)
-
-	updateInfo, err := updaterInstance.CheckForUpdates(ctx)
-	if err != nil {
-		// Silently fail for background checks - don't disturb user experience
-		l.configManager.SetLastUpdateCheck(time.Now())
-		_ = l.configManager.Save()
+	l.ui.ShowInfo("The update has been applied to the binary.")
+	if l.ui.ConfirmOperation(fmt.Sprintf("restart the launcher now to use %s", version)) {
+		l.relaunch = updaterInstance
+		l.ui.ShowInfo("Restarting the launcher...")
 		return
 	}
+	l.ui.ShowInfo("Please restart the launcher to use the new version.")
+}
 
-	// Record the check time
-	l.configManager.SetLastUpdateCheck(time.Now())
-	_ = l.configManager.Save()
-
-	if updateInfo.HasUpdate {
-		l.ui.ShowInfo(fmt.Sprintf("📦 Update available: %s → %s", updateInfo.CurrentVersion, updateInfo.LatestVersion))
-		l.ui.ShowInfo("Use 'Check for Launcher Updates' from the menu to install")
+// Relaunch restarts into the launcher update installed from the menu if
+// the user chose to, and returns nil without doing anything otherwise.
+// It must only be called after Close. On Unix it only returns on failure;
+// on Windows it does nothing, as the swap script replaces the binary once
+// the launcher has exited.
+func (l *Launcher) Relaunch() error {
+	if l.relaunch == nil || runtime.GOOS == "windows" {
+		return nil
 	}
+	return l.relaunch.Relaunch()
 }
 
 // GetModeManager returns the mode manager (for accessing mode functionality)
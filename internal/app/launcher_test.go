@@ -0,0 +1,56 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Read-only commands close the launcher without changing a setting, which
+// must not rewrite the file and lose its formatting and comments
+func TestCloseKeepsUnchangedConfigFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	original := "# Hand-edited\nfirst_run = false\n\n# Use the beta builds\nupdate_channel = \"beta\"\n"
+	if err := os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	launcher, err := NewLauncherWithConfigPath(configPath)
+	if err != nil {
+		t.Fatalf("NewLauncherWithConfigPath failed: %v", err)
+	}
+	if err := launcher.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != original {
+		t.Errorf("Close rewrote the unchanged config file:\n%s", data)
+	}
+}
+
+func TestCloseSavesChangedSettings(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	configPath := filepath.Join(t.TempDir(), "config.json")
+
+	launcher, err := NewLauncherWithConfigPath(configPath)
+	if err != nil {
+		t.Fatalf("NewLauncherWithConfigPath failed: %v", err)
+	}
+	if _, err := os.Stat(configPath); !os.IsNotExist(err) {
+		t.Fatalf("config file exists before any setting changed: %v", err)
+	}
+
+	launcher.GetConfigManager().SetUpdateChannel("beta")
+	if err := launcher.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if _, err := os.Stat(configPath); err != nil {
+		t.Errorf("Close did not save the changed settings: %v", err)
+	}
+}
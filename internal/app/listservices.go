@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/controller"
+)
+
+// ErrUnhealthy is returned by the services command with --fail-on-unhealthy
+// when a service with a health check is not healthy
+var ErrUnhealthy = errors.New("services unhealthy")
+
+// handleServicesCommand prints one line per service, or a JSON array with
+// --json, for monitoring scripts that need more than the overall status
+func (l *Launcher) handleServicesCommand() error {
+	ctx, cancel := context.WithTimeout(l.ctx, 30*time.Second)
+	defer cancel()
+
+	apiStatus, err := l.controller.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list services: %w", err)
+	}
+
+	services := apiStatus.Services
+	if services == nil {
+		services = []api.Service{} // Print [] rather than null
+	}
+
+	if l.jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(services); err != nil {
+			return err
+		}
+	} else {
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "NAME\tSTATUS\tHEALTH\tUPTIME")
+		for _, service := range services {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", service.Name, service.Status,
+				orDash(service.Health), orDash(service.Uptime))
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if l.failOnUnhealthy {
+		if unhealthy := controller.UnhealthyServices(apiStatus); len(unhealthy) > 0 {
+			return fmt.Errorf("%w: %d of %d", ErrUnhealthy, len(unhealthy), len(services))
+		}
+	}
+	return nil
+}
+
+// orDash returns value, or "-" for an empty table cell
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ddalab/launcher/pkg/config"
+	"github.com/ddalab/launcher/pkg/i18n"
+	"github.com/ddalab/launcher/pkg/theme"
+)
+
+// connectionSettings are the settings that change how the backend is
+// reached, so the mode is detected again when one of them changes
+var connectionSettings = []string{
+	"api_endpoint",
+	"operation_mode",
+	"api_token",
+	"api_health_path",
+	"api_version_path",
+	"connect_timeout_ms",
+}
+
+// restartSettings are the settings only read at launch
+var restartSettings = []string{
+	"api_ca_cert",
+	"ssh_host",
+	"ssh_ports",
+	"telemetry_endpoint",
+}
+
+// handleReloadSettingsCommand reads the config file again, e.g. after it
+// was edited by hand, applies the new settings to the running launcher and
+// reports what changed
+func (l *Launcher) handleReloadSettingsCommand() error {
+	if l.configManager.HasUnsavedChanges() &&
+		!l.ui.ConfirmOperation("discard settings changed in this session and reload them from the config file") {
+		l.ui.ShowInfo("Settings not reloaded")
+		return nil
+	}
+
+	changed, err := l.configManager.Reload()
+	if err != nil {
+		return fmt.Errorf("failed to reload settings: %w", err)
+	}
+	if len(changed) == 0 {
+		l.ui.ShowInfo("No settings changed")
+		return nil
+	}
+
+	l.applySettings(changed)
+	l.ui.ShowSuccess(fmt.Sprintf("Settings reloaded, changed: %s", strings.Join(changed, ", ")))
+
+	var pending []string
+	for _, key := range changed {
+		if slices.Contains(restartSettings, key) {
+			pending = append(pending, key)
+		}
+	}
+	if len(pending) > 0 {
+		l.ui.ShowWarning(fmt.Sprintf("Restart the launcher to apply: %s", strings.Join(pending, ", ")))
+	}
+	return nil
+}
+
+// applySettings passes the changed settings on to the parts of the
+// launcher that copied them at startup
+func (l *Launcher) applySettings(changed []string) {
+	if slices.Contains(changed, "locale") {
+		i18n.SetLocale(i18n.Detect(l.configManager.GetLocale()))
+	}
+	if slices.Contains(changed, "theme") {
+		theme.SetPlain(l.configManager.GetTheme() == config.ThemePlain)
+	}
+	if slices.Contains(changed, "palette") {
+		if err := theme.SetPalette(l.configManager.GetPalette()); err != nil {
+			l.ui.ShowWarning(fmt.Sprintf("Keeping the current palette: %v", err))
+		}
+	}
+
+	if slices.Contains(changed, "ddalab_path") {
+		l.installationChanged()
+	}
+	if slices.Contains(changed, "expected_services") {
+		l.detector.SetExpectedServices(l.configManager.GetExpectedServices())
+	}
+
+	l.statusMonitor.SetTimeout(l.configManager.GetStatusTimeout())
+	l.reachability.SetInterval(l.configManager.GetPingInterval())
+
+	if slices.ContainsFunc(changed, func(key string) bool { return slices.Contains(connectionSettings, key) }) {
+		l.modeManager.ApplyConfig()
+		if err := l.modeManager.Initialize(); err != nil {
+			l.ui.ShowWarning(fmt.Sprintf("Mode initialization warning: %v", err))
+		}
+		l.ui.ShowInfo(l.modeManager.GetModeDescription())
+		l.statusMonitor.CheckNow()
+	} else if slices.Contains(changed, "ddalab_path") {
+		l.statusMonitor.CheckNow()
+	}
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ddalab/launcher/internal/terminal"
+	"github.com/ddalab/launcher/pkg/controller"
+	"github.com/ddalab/launcher/pkg/theme"
+)
+
+const (
+	selftestHealthTimeout  = 3 * time.Minute // How long to wait for healthy services
+	selftestCleanupTimeout = time.Minute     // How long the final stop may take
+)
+
+// errStepSkipped marks self-test steps not run because an earlier step failed
+var errStepSkipped = errors.New("skipped")
+
+// selftestStep is one stage of the self-test
+type selftestStep struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// handleSelftestCommand exercises the whole lifecycle against the
+// configured installation: Docker, validation, start, health, access URL,
+// backup and stop. It reports each step with its duration.
+func (l *Launcher) handleSelftestCommand() error {
+	if l.areServicesRunning() {
+		l.ui.ShowWarning("DDALAB is already running and may be serving users - the self-test stops it at the end")
+		if !terminal.IsTerminal() && !l.ui.AssumesYes() {
+			return fmt.Errorf("refusing to self-test a running installation without confirmation - pass --yes to confirm")
+		}
+		if !l.ui.ConfirmOperation("run the self-test against the running installation") {
+			return nil
+		}
+	}
+
+	started := false
+	steps := []selftestStep{
+		{"Docker available", func(ctx context.Context) error {
+			return l.modeManager.GetBootstrapper().CheckDocker()
+		}},
+		{"Installation valid", func(ctx context.Context) error {
+			return l.detector.ValidateInstallation(ctx, l.configManager.GetDDALABPath())
+		}},
+		{"Start stack", func(ctx context.Context) error {
+			if err := l.controller.Start(ctx); err != nil {
+				return err
+			}
+			started = true
+			l.statusMonitor.MarkStarted()
+			return nil
+		}},
+		{"Services healthy", func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, selftestHealthTimeout)
+			defer cancel()
+			_, err := l.controller.WaitHealthy(ctx)
+			return err
+		}},
+		{"Access URL responds", func(ctx context.Context) error {
+			return controller.ProbeURL(ctx, controller.DefaultAccessURL)
+		}},
+		{"Backup", func(ctx context.Context) error {
+			_, err := l.controller.Backup(ctx)
+			return err
+		}},
+		{"Stop stack", func(ctx context.Context) error {
+			if err := l.controller.Stop(ctx); err != nil {
+				return err
+			}
+			started = false
+			return nil
+		}},
+	}
+
+	var failed int
+	err := l.executeWithInterrupt("running self-test", func(ctx context.Context) error {
+		var stepErr error
+		for _, step := range steps {
+			if stepErr != nil {
+				l.reportStep(step.name, 0, errStepSkipped)
+				continue
+			}
+
+			start := time.Now()
+			stepErr = step.run(ctx)
+			l.reportStep(step.name, time.Since(start), stepErr)
+			if stepErr != nil {
+				failed++
+			}
+		}
+
+		// Leave the installation stopped even when a later step failed
+		if started {
+			l.ui.ShowProgress("Cleaning up: stopping DDALAB")
+			cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), selftestCleanupTimeout)
+			defer cancel()
+			if err := l.controller.Stop(cleanupCtx); err != nil {
+				l.ui.ShowWarning(fmt.Sprintf("Cleanup failed: %v", err))
+			}
+		}
+
+		return stepErr
+	})
+	l.statusMonitor.CheckNow()
+
+	if err != nil {
+		return fmt.Errorf("self-test failed: %w", err)
+	}
+	if failed > 0 {
+		// The run was cancelled part way through
+		return fmt.Errorf("self-test did not complete")
+	}
+
+	l.ui.ShowSuccess(fmt.Sprintf("Self-test passed: all %d steps succeeded", len(steps)))
+	return nil
+}
+
+// reportStep prints the outcome of a self-test step
+func (l *Launcher) reportStep(name string, elapsed time.Duration, err error) {
+	switch {
+	case errors.Is(err, errStepSkipped):
+		l.ui.Println(fmt.Sprintf("  %s %-22s skipped", theme.Skipped, name))
+	case err != nil:
+		l.ui.Println(fmt.Sprintf("  %s %-22s %6s  %v", theme.Error, name, elapsed.Round(time.Millisecond), err))
+	default:
+		l.ui.Println(fmt.Sprintf("  %s %-22s %6s", theme.Success, name, elapsed.Round(time.Millisecond)))
+	}
+}
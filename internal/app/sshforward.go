@@ -0,0 +1,60 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/ddalab/launcher/pkg/sshforward"
+)
+
+// startSSHForward forwards the ports of the configured SSH host to
+// localhost, so the launcher reaches a remote DDALAB at its local endpoint.
+// A forward that drops is reconnected in the background until the launcher
+// closes; handleSSHForwardEvents reports it.
+func (l *Launcher) startSSHForward() {
+	host, ports := l.configManager.GetSSHForward()
+	if host == "" {
+		return
+	}
+
+	l.ui.ShowProgress(fmt.Sprintf("Forwarding ports %v from %s over SSH", ports, host))
+	forward := sshforward.New(host, ports)
+	if err := forward.Start(l.ctx); err != nil {
+		l.ui.ShowWarning(fmt.Sprintf("SSH port forward failed: %v", err))
+		l.ui.ShowInfo("Check that 'ssh " + host + "' logs in without a password prompt, e.g. with a key in ssh-agent")
+		return
+	}
+	l.ui.ShowSuccess(fmt.Sprintf("Forwarding ports %v from %s", ports, host))
+
+	l.sshForward = forward
+	l.sshForwardEvents = make(chan error, 4)
+	go forward.Supervise(l.ctx, func(err error) {
+		select {
+		case l.sshForwardEvents <- err:
+		default: // The menu has not caught up, the next event says enough
+		}
+	})
+}
+
+// handleSSHForwardEvents reports drops and reconnects of the SSH port
+// forward since the last call. It runs on the menu loop.
+func (l *Launcher) handleSSHForwardEvents() {
+	for {
+		select {
+		case err := <-l.sshForwardEvents:
+			if err != nil {
+				l.ui.ShowWarning(fmt.Sprintf("SSH port forward to %s dropped (%v) - reconnecting", l.sshForward.Host(), err))
+			} else {
+				l.ui.ShowSuccess(fmt.Sprintf("SSH port forward to %s restored", l.sshForward.Host()))
+			}
+		default:
+			return
+		}
+	}
+}
+
+// stopSSHForward ends the SSH port forward, if any
+func (l *Launcher) stopSSHForward() {
+	if l.sshForward != nil {
+		l.sshForward.Stop()
+	}
+}
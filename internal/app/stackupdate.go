@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/controller"
+	"github.com/ddalab/launcher/pkg/ui"
+)
+
+// handleCheckDDALABUpdatesCommand checks whether a newer DDALAB release is
+// available for the installed stack and offers to update it. Launcher
+// updates are handled separately by handleCheckUpdatesCommand.
+func (l *Launcher) handleCheckDDALABUpdatesCommand() error {
+	l.ui.ShowInfo("This checks the DDALAB services - use 'Check for Launcher Updates' for the launcher itself")
+
+	var update *api.StackUpdate
+	unsupported := false
+	err := l.executeWithInterrupt("checking for DDALAB updates", func(ctx context.Context) error {
+		result, err := l.controller.CheckForUpdate(ctx)
+		if errors.Is(err, api.ErrUnsupported) {
+			unsupported = true
+			return nil
+		}
+		update = result
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if unsupported {
+		l.showInstalledVersion()
+		l.ui.ShowInfo("The DDALAB backend cannot check for new releases - 'Update DDALAB' pulls the latest images regardless")
+		return nil
+	}
+	if update == nil {
+		return nil // Cancelled
+	}
+
+	l.ui.ShowInfo(fmt.Sprintf("Installed DDALAB version: %s", valueOr(update.CurrentVersion, "unknown")))
+	l.ui.ShowInfo(fmt.Sprintf("Available DDALAB version: %s", valueOr(update.LatestVersion, "unknown")))
+
+	for _, image := range update.Images {
+		if image.UpdateAvailable {
+			l.ui.ShowInfo(fmt.Sprintf("Newer image for %s: %s", image.Service, image.Image))
+		}
+	}
+
+	if !update.UpdateAvailable {
+		l.ui.ShowSuccess("DDALAB is up to date")
+		return nil
+	}
+
+	if update.ReleaseNotes != "" {
+		l.ui.Println("")
+		l.ui.Println(ui.RenderMarkdown(update.ReleaseNotes))
+		l.ui.Println("")
+	}
+
+	if !l.ui.ConfirmOperation(fmt.Sprintf("update DDALAB to %s now", valueOr(update.LatestVersion, "the latest version"))) {
+		return nil
+	}
+	return l.operations.RunConfirmed(controller.OpUpdate)
+}
+
+// showInstalledVersion shows the DDALAB version the backend reports, if any
+func (l *Launcher) showInstalledVersion() {
+	ctx, cancel := context.WithTimeout(l.ctx, l.configManager.GetStatusTimeout())
+	defer cancel()
+
+	status, err := l.controller.Status(ctx)
+	if err == nil && status.Installation.Version != "" {
+		l.ui.ShowInfo(fmt.Sprintf("Installed DDALAB version: %s", status.Installation.Version))
+	}
+}
+
+// valueOr returns value, or fallback if value is empty
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
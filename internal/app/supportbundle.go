@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ddalab/launcher/pkg/controller"
+	"github.com/ddalab/launcher/pkg/diagnostics"
+)
+
+// supportBundleLogLines is how many recent log lines a support bundle holds
+const supportBundleLogLines = 500
+
+// CreateSupportBundle writes a zip to outPath with the diagnostics report
+// and the most recent service logs, both with secrets redacted. Logs that
+// cannot be fetched, e.g. because DDALAB is stopped, are noted in the
+// bundle rather than failing it.
+func (l *Launcher) CreateSupportBundle(ctx context.Context, outPath string) error {
+	report := diagnostics.Collect(l.configManager, l.modeManager, l.detector)
+
+	// Only ask a backend that is already up; a bug report must not start
+	// DDALAB as a side effect
+	logs, logsErr := "", error(controller.ErrAPIUnavailable)
+	if l.modeManager.IsAPIMode() {
+		logs, logsErr = l.controller.Logs(ctx, controller.LogOptions{Tail: supportBundleLogLines})
+	}
+	return report.WriteBundle(outPath, report.RedactLogs(logs), logsErr)
+}
+
+// offerSupportBundle asks whether to create a support bundle after the
+// diagnostics export and where to write it
+func (l *Launcher) offerSupportBundle() {
+	if !l.ui.ConfirmOperation(fmt.Sprintf("create a support bundle (.zip) that also holds the last %d log lines", supportBundleLogLines)) {
+		return
+	}
+
+	defaultPath, err := diagnostics.DefaultBundlePath()
+	if err != nil {
+		l.ui.ShowError(err.Error())
+		return
+	}
+	outPath, err := l.ui.PromptPath("Where should the support bundle be saved?", defaultPath)
+	if err != nil {
+		return // Cancelled
+	}
+
+	err = l.executeWithInterrupt("creating support bundle", func(ctx context.Context) error {
+		l.ui.ShowProgress("Fetching recent logs")
+		return l.CreateSupportBundle(ctx, outPath)
+	})
+	if err != nil {
+		l.ui.ShowError(err.Error())
+		return
+	}
+	l.ui.ShowSuccess(fmt.Sprintf("Support bundle saved to %s - attach it to your bug report", outPath))
+}
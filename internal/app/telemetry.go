@@ -0,0 +1,92 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/ddalab/launcher/pkg/telemetry"
+)
+
+// recordFailure reports a failed operation to telemetry if the user opted in
+func (l *Launcher) recordFailure(operation string, err error) {
+	l.telemetry.Record(operation, err, string(l.modeManager.GetCurrentMode()))
+}
+
+// askTelemetryConsent asks once whether failure reports may be sent. The
+// question is skipped while offline, when no endpoint is configured and
+// under --yes, since consent has to be given explicitly.
+func (l *Launcher) askTelemetryConsent() {
+	endpoint := l.configManager.GetTelemetryEndpoint()
+	if endpoint == "" || l.configManager.IsTelemetryAsked() || l.configManager.IsOffline() || l.ui.AssumesYes() {
+		return
+	}
+
+	l.ui.ShowInfo("The launcher can report failed operations to help fix problems. Each report contains only")
+	l.ui.ShowInfo("the operation name, an error category, your platform, the launcher mode and version -")
+	l.ui.ShowInfo("no paths, hostnames, file contents or error messages. You can change this later in 'Telemetry Settings'.")
+
+	enabled := l.ui.ConfirmOperation(fmt.Sprintf("send anonymous failure reports to %s", endpoint))
+	l.configManager.SetTelemetry(enabled)
+	if err := l.configManager.Save(); err != nil {
+		l.ui.ShowWarning(fmt.Sprintf("Failed to save telemetry choice: %v", err))
+	}
+}
+
+// handleTelemetryCommand shows what telemetry would send and lets the user
+// enable or disable it
+func (l *Launcher) handleTelemetryCommand() error {
+	if err := l.checkAllowed("telemetry"); err != nil {
+		return err
+	}
+
+	endpoint := l.configManager.GetTelemetryEndpoint()
+	if l.configManager.IsTelemetryEnabled() {
+		l.ui.ShowInfo("Telemetry: enabled")
+	} else {
+		l.ui.ShowInfo("Telemetry: disabled")
+	}
+	if endpoint == "" {
+		l.ui.ShowInfo("Endpoint: none configured - nothing is sent")
+	} else {
+		l.ui.ShowInfo(fmt.Sprintf("Endpoint: %s", endpoint))
+	}
+	if l.configManager.IsOffline() {
+		l.ui.ShowInfo("Offline mode is on - nothing is sent until it is turned off")
+	}
+
+	events := l.telemetry.Pending()
+	if len(events) > 0 {
+		l.ui.ShowInfo(fmt.Sprintf("%d report(s) waiting to be sent:", len(events)))
+	} else {
+		l.ui.ShowInfo("No reports waiting. A failed start would be sent as:")
+		events = []telemetry.Event{l.telemetry.NewEvent("Start DDALAB", fmt.Errorf("example"), string(l.modeManager.GetCurrentMode()))}
+	}
+	payload, err := telemetry.Payload(events)
+	if err != nil {
+		return fmt.Errorf("failed to encode telemetry payload: %w", err)
+	}
+	l.ui.Println("")
+	l.ui.Println(string(payload))
+	l.ui.Println("")
+
+	if l.configManager.GetConfig().Telemetry {
+		if !l.ui.ConfirmOperation("disable telemetry") {
+			return nil
+		}
+		l.configManager.SetTelemetry(false)
+	} else {
+		if endpoint == "" {
+			l.ui.ShowInfo("Set telemetry_endpoint in the launcher config to enable telemetry")
+			return nil
+		}
+		if !l.ui.ConfirmOperation(fmt.Sprintf("send anonymous failure reports to %s", endpoint)) {
+			return nil
+		}
+		l.configManager.SetTelemetry(true)
+	}
+
+	if err := l.configManager.Save(); err != nil {
+		return fmt.Errorf("failed to save telemetry setting: %w", err)
+	}
+	l.ui.ShowSuccess("Telemetry setting saved")
+	return nil
+}
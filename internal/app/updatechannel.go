@@ -0,0 +1,42 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/ddalab/launcher/pkg/updater"
+)
+
+// handleUpdateChannelCommand switches the launcher between stable and beta
+// releases and checks the new channel right away, so a beta build can be
+// installed at once, or a beta build downgraded to the latest stable one
+func (l *Launcher) handleUpdateChannelCommand() error {
+	if err := l.checkAllowed("update-channel"); err != nil {
+		return err
+	}
+
+	next := updater.ChannelBeta
+	if current, _ := updater.ParseChannel(l.configManager.GetUpdateChannel()); current == updater.ChannelBeta {
+		next = updater.ChannelStable
+	}
+
+	if next == updater.ChannelBeta {
+		l.ui.ShowWarning("Beta builds are pre-releases and may be unstable")
+		if !l.ui.ConfirmOperation("switch the launcher to beta builds") {
+			l.ui.ShowInfo("Update channel not changed")
+			return nil
+		}
+	}
+
+	l.configManager.SetUpdateChannel(string(next))
+	if err := l.configManager.Save(); err != nil {
+		return fmt.Errorf("failed to save the update channel: %w", err)
+	}
+	l.ui.ClearUpdateNotice() // It was found on the other channel
+	l.ui.ShowSuccess(fmt.Sprintf("Update channel: %s", next))
+
+	if l.configManager.IsOffline() {
+		l.ui.ShowInfo("Offline mode is enabled - the new channel is checked once online")
+		return nil
+	}
+	return l.checkLauncherUpdates()
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/config"
+	"github.com/ddalab/launcher/pkg/updater"
+)
+
+// Retries of the startup update check. A slow proxy or a flaky connection
+// often answers the second attempt.
+const (
+	updateCheckAttempts = 3
+	updateCheckBackoff  = 2 * time.Second // Doubled after each failed attempt
+)
+
+// updateCheckFailureNotice is how many startup checks in a row have to fail
+// before the menu mentions it; a single failure is usually transient
+const updateCheckFailureNotice = 3
+
+// updateCheckResult is the outcome of the background update check
+type updateCheckResult struct {
+	updater *updater.Updater
+	info    *updater.UpdateInfo
+	err     error
+}
+
+// checkForUpdatesOnStartup starts the automatic update check if it is
+// enabled and due. It runs in the background so a slow network never delays
+// the menu; the result is picked up by handleUpdateCheckResult.
+func (l *Launcher) checkForUpdatesOnStartup() {
+	if !l.configManager.ShouldCheckForUpdates() {
+		return
+	}
+
+	timeout := l.configManager.GetUpdateCheckTimeout()
+	l.updateCheck = make(chan updateCheckResult, 1)
+	go func() {
+		updaterInstance := l.newUpdater()
+		info, err := checkWithRetry(l.ctx, updaterInstance, timeout)
+		l.updateCheck <- updateCheckResult{updaterInstance, info, err}
+	}()
+}
+
+// newUpdater returns an updater for the running binary, not the version in
+// the config, on the configured release channel
+func (l *Launcher) newUpdater() *updater.Updater {
+	updaterInstance := updater.NewUpdater(config.GetVersion())
+	channel, err := updater.ParseChannel(l.configManager.GetUpdateChannel())
+	if err != nil {
+		log.Printf("Warning: %v, using the stable channel", err)
+		channel = updater.ChannelStable
+	}
+	updaterInstance.SetChannel(channel)
+	return updaterInstance
+}
+
+// checkWithRetry checks for updates, retrying failed attempts with backoff
+func checkWithRetry(ctx context.Context, updaterInstance *updater.Updater, timeout time.Duration) (*updater.UpdateInfo, error) {
+	backoff := updateCheckBackoff
+	var err error
+	for attempt := 1; attempt <= updateCheckAttempts; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+		var info *updater.UpdateInfo
+		info, err = updaterInstance.CheckForUpdates(attemptCtx)
+		cancel()
+		if err == nil {
+			return info, nil
+		}
+		if attempt == updateCheckAttempts {
+			break
+		}
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return nil, err
+}
+
+// handleUpdateCheckResult applies the result of the background update check
+// once it is available. It runs on the menu loop, so saving the config and
+// prompting never race with the menu.
+func (l *Launcher) handleUpdateCheckResult() {
+	var result updateCheckResult
+	select {
+	case result = <-l.updateCheck:
+		l.updateCheck = nil
+	default:
+		return // Not started or still running
+	}
+
+	if errors.Is(result.err, context.Canceled) {
+		return // The launcher is closing
+	}
+
+	failures := l.configManager.RecordUpdateCheck(result.err != nil)
+	_ = l.configManager.Save()
+
+	if result.err != nil {
+		if failures < updateCheckFailureNotice {
+			return
+		}
+		var dnsErr *net.DNSError
+		if errors.As(result.err, &dnsErr) {
+			l.ui.SetUpdateNotice("Update check failed - this machine appears to be offline, use --offline or \"offline\": true to skip it")
+		} else {
+			l.ui.SetUpdateNotice(fmt.Sprintf("Update check failed %d times - run 'Check for Launcher Updates' manually or check connectivity", failures))
+		}
+		return
+	}
+
+	if !result.info.HasUpdate {
+		return
+	}
+
+	if l.configManager.IsAutoInstallUpdatesEnabled() && result.info.DownloadURL != "" {
+		action := fmt.Sprintf("Installing launcher update %s", result.info.LatestVersion)
+		if l.ui.ConfirmWithCountdown(action, autoUpdateCountdown) {
+			err := l.executeWithInterrupt("installing update", func(ctx context.Context) error {
+				return l.performLauncherUpdate(ctx, result.updater, result.info)
+			})
+			if err == nil {
+				return
+			}
+			l.ui.ShowWarning(fmt.Sprintf("Automatic update failed: %v", err))
+		} else {
+			l.ui.ShowInfo("Automatic update cancelled")
+		}
+	}
+
+	l.ui.SetUpdateNotice(fmt.Sprintf("Launcher update available: %s → %s - select 'Check for Launcher Updates' to install",
+		result.info.CurrentVersion, result.info.LatestVersion))
+}
@@ -0,0 +1,46 @@
+package terminal
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// clipboardTool is an external command that reads clipboard content from stdin
+type clipboardTool struct {
+	name string
+	args []string
+}
+
+// CopyToClipboard copies text to the system clipboard using the platform's tools
+func CopyToClipboard(text string) error {
+	var tools []clipboardTool
+
+	switch runtime.GOOS {
+	case "darwin":
+		tools = []clipboardTool{{"pbcopy", nil}}
+	case "windows":
+		tools = []clipboardTool{{"clip", nil}}
+	default:
+		tools = []clipboardTool{
+			{"wl-copy", nil},
+			{"xclip", []string{"-selection", "clipboard"}},
+			{"xsel", []string{"--clipboard", "--input"}},
+		}
+	}
+
+	for _, tool := range tools {
+		if _, err := exec.LookPath(tool.name); err != nil {
+			continue
+		}
+
+		cmd := exec.Command(tool.name, tool.args...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no clipboard tool available")
+}
@@ -0,0 +1,31 @@
+package terminal
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// Open opens a file, directory or URL with the operating system's default
+// handler (file explorer for folders, browser for URLs)
+func Open(target string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "windows":
+		cmd = exec.Command("explorer", target)
+	default:
+		cmd = exec.Command("xdg-open", target)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s: %w", target, err)
+	}
+
+	// Reap the opener process without blocking the caller
+	go func() { _ = cmd.Wait() }()
+
+	return nil
+}
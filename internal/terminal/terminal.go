@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+	"sync"
 )
 
 // IsTerminal checks if the program is running in a terminal
@@ -12,6 +13,37 @@ func IsTerminal() bool {
 	return isTerminalPlatform()
 }
 
+// titleSet records whether SetTitle changed the title, so ResetTitle only
+// restores it once however many exit paths call it
+var (
+	titleMu  sync.Mutex
+	titleSet bool
+)
+
+// SetTitle sets the terminal window title, remembering the previous one
+// where the platform allows it. ResetTitle restores it.
+func SetTitle(title string) {
+	titleMu.Lock()
+	defer titleMu.Unlock()
+
+	if setTitlePlatform(title) {
+		titleSet = true
+	}
+}
+
+// ResetTitle restores the terminal window title from before SetTitle, or
+// clears it so the shell can set its own where the previous title is
+// unknown. It does nothing if SetTitle was not called.
+func ResetTitle() {
+	titleMu.Lock()
+	defer titleMu.Unlock()
+
+	if titleSet {
+		restoreTitlePlatform()
+		titleSet = false
+	}
+}
+
 // RelaunchInTerminal attempts to relaunch the program in a terminal
 func RelaunchInTerminal() error {
 	executable, err := os.Executable()
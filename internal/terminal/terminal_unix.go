@@ -4,6 +4,7 @@
 package terminal
 
 import (
+	"fmt"
 	"os"
 )
 
@@ -15,3 +16,24 @@ func isTerminalPlatform() bool {
 	}
 	return (fileInfo.Mode() & os.ModeCharDevice) != 0
 }
+
+// setTitlePlatform saves the current title on the terminal's title stack
+// and sets a new one. Output that is not a terminal is left alone, so the
+// escape sequences never end up in a redirected log.
+func setTitlePlatform(title string) bool {
+	fileInfo, err := os.Stdout.Stat()
+	if err != nil || fileInfo.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+
+	fmt.Print("\033[22;0t") // Push the current title (xterm, VTE, kitty, ...)
+	fmt.Printf("\033]0;%s\007", title)
+	return true
+}
+
+// restoreTitlePlatform pops the title saved by setTitlePlatform. Terminals
+// without a title stack ignore the pop and keep the cleared title.
+func restoreTitlePlatform() {
+	fmt.Print("\033]0;\007")
+	fmt.Print("\033[23;0t")
+}
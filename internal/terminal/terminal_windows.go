@@ -12,8 +12,13 @@ var (
 	kernel32             = syscall.NewLazyDLL("kernel32.dll")
 	procGetConsoleWindow = kernel32.NewProc("GetConsoleWindow")
 	procGetConsoleMode   = kernel32.NewProc("GetConsoleMode")
+	procGetConsoleTitle  = kernel32.NewProc("GetConsoleTitleW")
+	procSetConsoleTitle  = kernel32.NewProc("SetConsoleTitleW")
 )
 
+// previousTitle is the console title from before setTitlePlatform
+var previousTitle string
+
 // isTerminalPlatform checks if running in a terminal on Windows
 func isTerminalPlatform() bool {
 	// Check if we have a console window
@@ -32,3 +37,27 @@ func isTerminalPlatform() bool {
 	ret, _, _ = procGetConsoleMode.Call(uintptr(handle), uintptr(unsafe.Pointer(&mode)))
 	return ret != 0
 }
+
+// setTitlePlatform remembers the console title and sets a new one
+func setTitlePlatform(title string) bool {
+	buffer := make([]uint16, 1024)
+	n, _, _ := procGetConsoleTitle.Call(uintptr(unsafe.Pointer(&buffer[0])), uintptr(len(buffer)))
+	previousTitle = syscall.UTF16ToString(buffer[:n])
+
+	return setConsoleTitle(title)
+}
+
+// restoreTitlePlatform sets the console title saved by setTitlePlatform
+func restoreTitlePlatform() {
+	setConsoleTitle(previousTitle)
+}
+
+// setConsoleTitle sets the console window title and reports whether it did
+func setConsoleTitle(title string) bool {
+	titlePtr, err := syscall.UTF16PtrFromString(title)
+	if err != nil {
+		return false
+	}
+	ret, _, _ := procSetConsoleTitle.Call(uintptr(unsafe.Pointer(titlePtr)))
+	return ret != 0
+}
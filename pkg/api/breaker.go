@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the backend while the
+// circuit breaker is open after repeated failures
+var ErrCircuitOpen = errors.New("backend is failing repeatedly - backing off")
+
+// Circuit breaker defaults
+const (
+	defaultBreakerThreshold = 5                // Consecutive failures that open the circuit
+	defaultBreakerCooldown  = 15 * time.Second // How long the circuit stays open
+)
+
+// pollKey marks a context of a health or readiness poll
+type pollKey struct{}
+
+// WithoutBreaker returns a context whose requests bypass the circuit
+// breaker, for polls that wait for the backend to come up, e.g. after a
+// start or update. Their failures do not open the circuit and an open
+// circuit does not stop them; a success closes it. Health checks and pings
+// always bypass it.
+func WithoutBreaker(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pollKey{}, true)
+}
+
+// bypassesBreaker reports whether ctx was made by WithoutBreaker
+func bypassesBreaker(ctx context.Context) bool {
+	return ctx.Value(pollKey{}) != nil
+}
+
+// CircuitState is the state of the client's circuit breaker
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // Requests pass through
+	CircuitOpen                         // Requests fail fast with ErrCircuitOpen
+	CircuitHalfOpen                     // A single probe request tests recovery
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitInfo describes the circuit breaker for diagnostics
+type CircuitInfo struct {
+	State    CircuitState
+	Failures int       // Consecutive failures
+	RetryAt  time.Time // When an open circuit lets a probe through
+}
+
+// breaker is a circuit breaker shared by all requests of a client, so the
+// status monitor and commands back off together while the backend is down
+type breaker struct {
+	mu        sync.Mutex
+	state     CircuitState
+	failures  int
+	openedAt  time.Time
+	probing   bool // A half-open probe is in flight
+	threshold int
+	cooldown  time.Duration
+}
+
+// newBreaker creates a closed circuit breaker
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{threshold: threshold, cooldown: cooldown}
+}
+
+// allow returns ErrCircuitOpen if a request must not be sent. Once the
+// cooldown has passed, one probe request is let through.
+func (b *breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		remaining := b.cooldown - time.Since(b.openedAt)
+		if remaining > 0 {
+			return fmt.Errorf("%w (retrying in %s)", ErrCircuitOpen, max(remaining.Round(time.Second), time.Second))
+		}
+		b.state = CircuitHalfOpen
+		b.probing = true
+		return nil
+	case CircuitHalfOpen:
+		if b.probing {
+			return fmt.Errorf("%w (testing recovery)", ErrCircuitOpen)
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of a request. Only transient
+// failures count; a request cancelled by the caller says nothing about the
+// backend and releases a probe without a verdict.
+func (b *breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err != nil && errors.Is(err, context.Canceled) {
+		b.probing = false
+		return
+	}
+
+	if err == nil || !IsTransientError(err) {
+		b.state = CircuitClosed
+		b.failures = 0
+		b.probing = false
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+		b.probing = false
+	}
+}
+
+// info returns the breaker's state
+func (b *breaker) info() CircuitInfo {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	info := CircuitInfo{State: b.state, Failures: b.failures}
+	if b.state == CircuitOpen {
+		info.RetryAt = b.openedAt.Add(b.cooldown)
+	}
+	return info
+}
+
+// Circuit returns the state of the client's circuit breaker
+func (c *Client) Circuit() CircuitInfo {
+	return c.breaker.info()
+}
@@ -1,33 +1,246 @@
 package api
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
 	"time"
+
+	"github.com/ddalab/launcher/pkg/httpx"
 )
 
 // Client represents the API client for Docker extension communication
 type Client struct {
 	baseURL        string
 	httpClient     *http.Client
-	apiVersion     string          // Preferred API version
 	serverFeatures map[string]bool // Server features from version endpoint
+	featuresKnown  bool            // The version endpoint answered
+
+	mu               sync.RWMutex
+	apiVersion       string    // API version in use, switched by the version check
+	serverVersion    string    // Server version reported by the backend
+	serverAPIVersion string    // API version reported in response metadata
+	mismatchOnce     sync.Once // Ensures the version mismatch warning is logged once
+
+	authToken  string        // Optional bearer token sent with every request
+	maxRetries int           // Extra attempts for idempotent requests
+	retryDelay time.Duration // Base delay between retries
+	debug      bool          // Log requests and responses with secrets redacted
+	breaker    *breaker      // Fails requests fast while the backend keeps failing
+
+	healthPath  string // Route answering health checks and pings
+	versionPath string // Route reporting the version info
+
+	probeConnectTimeout time.Duration // Connect timeout of status checks and pings
 }
 
+// FeatureJobs is the server feature flag for the active jobs endpoint
+const FeatureJobs = "jobs"
+
+// FeatureUpdates is the server feature flag for the DDALAB update check
+const FeatureUpdates = "updates"
+
+// FeatureEnvValidate is the server feature flag for validating .env
+// variables without saving them
+const FeatureEnvValidate = "env_validate"
+
+// FeatureLogFilter is the server feature flag for per-service logs filtered
+// by tail length and level
+const FeatureLogFilter = "log_filter"
+
+// FeatureEvents is the server feature flag for the stream of application
+// events, e.g. logins and finished analyses
+const FeatureEvents = "events"
+
+// FeatureRemoveOrphans is the server feature flag for bringing the stack
+// down with its containers removed, including orphans of renamed services
+const FeatureRemoveOrphans = "remove_orphans"
+
+// ErrUnsupported is returned for requests the backend does not support
+var ErrUnsupported = errors.New("not supported by the backend")
+
+// AuthTokenEnvVar names the environment variable holding an optional API token
+const AuthTokenEnvVar = "DDALAB_API_TOKEN"
+
+// DebugEnvVar names the environment variable that enables request logging
+const DebugEnvVar = "DDALAB_API_DEBUG"
+
+// Default routes of the health and version endpoints, relative to the base URL
+const (
+	DefaultHealthPath  = "/api/test"
+	DefaultVersionPath = "/api/version"
+)
+
+// DefaultTimeout bounds requests made without a context deadline
+const DefaultTimeout = 30 * time.Second
+
 // NewClient creates a new API client
 func NewClient(baseURL string) *Client {
+	return NewClientWithHTTPClient(baseURL, &http.Client{
+		Timeout: DefaultTimeout,
+	})
+}
+
+// NewClientWithHTTPClient creates an API client that sends requests through
+// the given HTTP client, e.g. one with a custom transport
+func NewClientWithHTTPClient(baseURL string, httpClient *http.Client) *Client {
 	return &Client{
 		baseURL:        baseURL,
 		apiVersion:     "v1", // Default to v1
 		serverFeatures: make(map[string]bool),
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		httpClient:     httpClient,
+		authToken:      os.Getenv(AuthTokenEnvVar),
+		maxRetries:     defaultMaxRetries,
+		retryDelay:     defaultRetryDelay,
+		debug:          os.Getenv(DebugEnvVar) != "",
+		breaker:        newBreaker(defaultBreakerThreshold, defaultBreakerCooldown),
+		healthPath:     DefaultHealthPath,
+		versionPath:    DefaultVersionPath,
+	}
+}
+
+// SetProbeConnectTimeout sets how long status checks, health checks and
+// pings wait for a connection, so a backend that is down is reported
+// quickly. Other requests keep the transport's connect timeout. Zero
+// leaves probes at that timeout too.
+func (c *Client) SetProbeConnectTimeout(timeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.probeConnectTimeout = timeout
+}
+
+// probe returns ctx with the probe connect timeout, if one is set
+func (c *Client) probe(ctx context.Context) context.Context {
+	c.mu.RLock()
+	timeout := c.probeConnectTimeout
+	c.mu.RUnlock()
+
+	if timeout <= 0 {
+		return ctx
 	}
+	return httpx.WithConnectTimeout(ctx, timeout)
+}
+
+// SetAuthToken sets the bearer token sent with every request
+func (c *Client) SetAuthToken(token string) {
+	c.authToken = token
+}
+
+// SetHealthPaths sets the routes of the health and version endpoints for
+// backends that expose them elsewhere, e.g. "/healthz". An empty path keeps
+// the current route.
+func (c *Client) SetHealthPaths(healthPath, versionPath string) {
+	if healthPath != "" {
+		c.healthPath = healthPath
+	}
+	if versionPath != "" {
+		c.versionPath = versionPath
+	}
+}
+
+// SetDebug enables or disables logging of requests and responses. Secret
+// values and credential headers are redacted before anything is logged.
+func (c *Client) SetDebug(enabled bool) {
+	c.debug = enabled
+}
+
+// endpointURL joins an API path onto the base URL. Using url.JoinPath keeps
+// IPv6 hosts, custom ports and base path prefixes intact and never yields
+// doubled or missing slashes.
+func (c *Client) endpointURL(endpoint string) (string, error) {
+	baseURL := c.BaseURL()
+	joined, err := url.JoinPath(baseURL, endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL '%s': %w", baseURL, err)
+	}
+	return joined, nil
+}
+
+// BaseURL returns the API base URL the client talks to
+func (c *Client) BaseURL() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.baseURL
+}
+
+// SetBaseURL points the client at a different API base URL
+func (c *Client) SetBaseURL(baseURL string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.baseURL = baseURL
+}
+
+// newRequest creates an HTTP request for an API path relative to the base
+// URL. The query parameters are set on the URL rather than joined onto the
+// path, which would escape the "?".
+func (c *Client) newRequest(ctx context.Context, method, endpoint string, query url.Values, body io.Reader) (*http.Request, error) {
+	reqURL, err := c.endpointURL(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return nil, err
+	}
+	if len(query) > 0 {
+		req.URL.RawQuery = query.Encode()
+	}
+	return req, nil
+}
+
+// recordMetadata stores the server version from response metadata and warns
+// once if the server reports a different API version than the one in use
+func (c *Client) recordMetadata(metadata *Metadata) {
+	if metadata == nil {
+		return
+	}
+
+	c.mu.Lock()
+	if metadata.ServerVersion != "" {
+		c.serverVersion = metadata.ServerVersion
+	}
+	if metadata.APIVersion != "" {
+		c.serverAPIVersion = metadata.APIVersion
+	}
+	apiVersion := c.apiVersion
+	c.mu.Unlock()
+
+	if metadata.APIVersion != "" && metadata.APIVersion != apiVersion {
+		c.mismatchOnce.Do(func() {
+			log.Printf("Warning: server API version %s differs from client API version %s; some operations may misbehave",
+				metadata.APIVersion, apiVersion)
+		})
+	}
+}
+
+// ServerVersion returns the backend server version, if known
+func (c *Client) ServerVersion() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.serverVersion
+}
+
+// ServerAPIVersion returns the API version reported by the backend, if known
+func (c *Client) ServerAPIVersion() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.serverAPIVersion
+}
+
+// APIVersion returns the API version the client is currently using
+func (c *Client) APIVersion() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.apiVersion
 }
 
 // StandardResponse wraps all API responses from the backend
@@ -62,10 +275,11 @@ type Status struct {
 
 // Service represents a single service status
 type Service struct {
-	Name   string `json:"name"`
-	Status string `json:"status"`
-	Health string `json:"health"`
-	Uptime string `json:"uptime,omitempty"`
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Health   string `json:"health"`
+	Uptime   string `json:"uptime,omitempty"`
+	Restarts int    `json:"restarts,omitempty"`
 }
 
 // InstallationInfo represents installation details
@@ -104,8 +318,11 @@ type VersionInfo struct {
 	Features           map[string]bool `json:"features"`
 }
 
-// HealthCheck function to verify API availability
+// HealthCheck function to verify API availability. It bypasses the circuit
+// breaker, so waiting for a starting backend is not held up by it.
 func (c *Client) HealthCheck(ctx context.Context) error {
+	ctx = WithoutBreaker(c.probe(ctx))
+
 	// First try to get version info to validate compatibility
 	if err := c.checkVersion(ctx); err != nil {
 		// If version check fails, fall back to basic health check
@@ -116,25 +333,13 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 
 // checkVersion retrieves and validates API version compatibility
 func (c *Client) checkVersion(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/version", nil)
-	if err != nil {
-		return fmt.Errorf("failed to create version request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
+	var versionInfo VersionInfo
+	if err := c.call(ctx, http.MethodGet, c.versionPath, nil, &versionInfo); err != nil {
 		return fmt.Errorf("version check failed: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("version check failed with status: %d", resp.StatusCode)
-	}
-
-	var versionInfo VersionInfo
-	if err := json.NewDecoder(resp.Body).Decode(&versionInfo); err != nil {
-		return fmt.Errorf("failed to decode version response: %w", err)
-	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	// Check if our preferred version is supported
 	supported := false
@@ -156,72 +361,114 @@ func (c *Client) checkVersion(ctx context.Context) error {
 
 	// Store server features for capability checks
 	c.serverFeatures = versionInfo.Features
+	c.serverVersion = versionInfo.Version
+	c.featuresKnown = true
 
 	return nil
 }
 
-// basicHealthCheck performs a simple health check without version validation
-func (c *Client) basicHealthCheck(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/test", nil)
-	if err != nil {
-		return fmt.Errorf("failed to create health check request: %w", err)
+// HasFeature reports whether the backend announced the feature in its
+// version info. It is false until a health check fetched the version.
+func (c *Client) HasFeature(name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.serverFeatures[name]
+}
+
+// Features returns the names of the features the backend announced, sorted.
+// ok is false until a health check fetched the version info.
+func (c *Client) Features() (features []string, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for name, enabled := range c.serverFeatures {
+		if enabled {
+			features = append(features, name)
+		}
 	}
+	sort.Strings(features)
+	return features, c.featuresKnown
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
+// basicHealthCheck performs a simple health check without version validation
+func (c *Client) basicHealthCheck(ctx context.Context) error {
+	if err := c.call(ctx, http.MethodGet, c.healthPath, nil, nil); err != nil {
 		return fmt.Errorf("health check failed: %w", err)
 	}
-	defer resp.Body.Close()
+	return nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("health check failed with status: %d", resp.StatusCode)
+// Ping checks that the API answers and returns the round-trip time. Like
+// HealthCheck it bypasses the circuit breaker.
+func (c *Client) Ping(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	if err := c.call(WithoutBreaker(c.probe(ctx)), http.MethodGet, c.healthPath, nil, nil); err != nil {
+		return 0, fmt.Errorf("ping failed: %w", err)
 	}
-
-	return nil
+	return time.Since(start), nil
 }
 
 // GetStatus retrieves the current DDALAB status using the new v1 API
 func (c *Client) GetStatus(ctx context.Context) (*Status, error) {
-	endpoint := fmt.Sprintf("/api/%s/status", c.apiVersion)
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+endpoint, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create status request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
+	var status Status
+	endpoint := fmt.Sprintf("/api/%s/status", c.APIVersion())
+	if err := c.call(c.probe(ctx), http.MethodGet, endpoint, nil, &status); err != nil {
 		return nil, fmt.Errorf("status request failed: %w", err)
 	}
-	defer resp.Body.Close()
+	return &status, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("status request failed with status: %d", resp.StatusCode)
-	}
+// StatusValidator identifies a status response, so the next request can
+// ask the backend whether anything changed since. It is empty when the
+// backend sent neither an ETag nor a Last-Modified header.
+type StatusValidator struct {
+	ETag         string
+	LastModified string
+}
 
-	var response StandardResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode status response: %w", err)
-	}
+// IsZero reports whether the backend sent no validator
+func (v StatusValidator) IsZero() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
 
-	if !response.Success {
-		if response.Error != nil {
-			return nil, fmt.Errorf("API error: %s - %s", response.Error.Code, response.Error.Message)
-		}
-		return nil, fmt.Errorf("API request failed")
+// ErrNotModified is returned by GetStatusIfChanged when the status is
+// unchanged since the validator's response
+var ErrNotModified = errors.New("status not modified")
+
+// GetStatusIfChanged fetches the status unless it is unchanged since the
+// response identified by since, in which case it returns ErrNotModified
+// without reading or parsing a body. The returned validator identifies
+// the new response. Backends without conditional requests send no
+// validator and always answer with the full status.
+func (c *Client) GetStatusIfChanged(ctx context.Context, since StatusValidator) (*Status, StatusValidator, error) {
+	header := http.Header{}
+	if since.ETag != "" {
+		header.Set("If-None-Match", since.ETag)
 	}
+	if since.LastModified != "" {
+		header.Set("If-Modified-Since", since.LastModified)
+	}
+
+	endpoint := fmt.Sprintf("/api/%s/status", c.APIVersion())
+	data, respHeader, err := c.do(c.probe(ctx), http.MethodGet, endpoint, nil, nil, header)
 
-	// Convert the data to Status struct
-	dataBytes, err := json.Marshal(response.Data)
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotModified && !since.IsZero() {
+		return nil, since, ErrNotModified
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal status data: %w", err)
+		return nil, StatusValidator{}, fmt.Errorf("status request failed: %w", err)
 	}
 
 	var status Status
-	if err := json.Unmarshal(dataBytes, &status); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal status data: %w", err)
+	if err := c.decode(data, respHeader.Get("Content-Type"), &status); err != nil {
+		return nil, StatusValidator{}, fmt.Errorf("status request failed: %w", err)
 	}
-
-	return &status, nil
+	validator := StatusValidator{
+		ETag:         respHeader.Get("ETag"),
+		LastModified: respHeader.Get("Last-Modified"),
+	}
+	return &status, validator, nil
 }
 
 // StartStack starts all DDALAB services using the new lifecycle API
@@ -234,6 +481,16 @@ func (c *Client) StopStack(ctx context.Context) error {
 	return c.lifecycleAction(ctx, "stop")
 }
 
+// DeepStopStack stops all DDALAB services and removes their containers,
+// including orphans left by services that were renamed or removed. It
+// returns ErrUnsupported if the backend does not announce the feature.
+func (c *Client) DeepStopStack(ctx context.Context) error {
+	if !c.HasFeature(FeatureRemoveOrphans) {
+		return ErrUnsupported
+	}
+	return c.lifecycleAction(ctx, "down")
+}
+
 // RestartStack restarts all DDALAB services using the new lifecycle API
 func (c *Client) RestartStack(ctx context.Context) error {
 	return c.lifecycleAction(ctx, "restart")
@@ -246,104 +503,140 @@ func (c *Client) UpdateStack(ctx context.Context) error {
 
 // lifecycleAction performs a lifecycle action using the new v1 API
 func (c *Client) lifecycleAction(ctx context.Context, action string) error {
-	endpoint := fmt.Sprintf("/api/%s/lifecycle/%s", c.apiVersion, action)
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+endpoint, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create %s request: %w", action, err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
+	endpoint := fmt.Sprintf("/api/%s/lifecycle/%s", c.APIVersion(), action)
+	if err := c.call(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
 		return fmt.Errorf("%s request failed: %w", action, err)
 	}
-	defer resp.Body.Close()
+	return nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("%s failed with status %d: %s", action, resp.StatusCode, string(body))
+// RestartService restarts a single DDALAB service using the v1 API
+func (c *Client) RestartService(ctx context.Context, name string) error {
+	endpoint := fmt.Sprintf("/api/%s/services/%s/restart", c.APIVersion(), url.PathEscape(name))
+	if err := c.call(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("restart of service %s failed: %w", name, err)
 	}
+	return nil
+}
+
+// Job is a long-running backend task, e.g. an analysis
+type Job struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	StartedAt time.Time `json:"started_at"`
+}
 
-	// Parse the standardized response
-	var response StandardResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return fmt.Errorf("failed to decode %s response: %w", action, err)
+// GetActiveJobs returns the jobs currently running in DDALAB. It returns
+// ErrUnsupported if the backend does not announce the jobs feature.
+func (c *Client) GetActiveJobs(ctx context.Context) ([]Job, error) {
+	if !c.HasFeature(FeatureJobs) {
+		return nil, ErrUnsupported
 	}
 
-	if !response.Success {
-		if response.Error != nil {
-			return fmt.Errorf("API error: %s - %s", response.Error.Code, response.Error.Message)
-		}
-		return fmt.Errorf("%s operation failed", action)
+	var data struct {
+		Jobs []Job `json:"jobs"`
 	}
+	endpoint := fmt.Sprintf("/api/%s/jobs/active", c.APIVersion())
+	if err := c.call(ctx, http.MethodGet, endpoint, nil, &data); err != nil {
+		return nil, fmt.Errorf("active jobs request failed: %w", err)
+	}
+	return data.Jobs, nil
+}
 
-	return nil
+// StackUpdate describes whether a newer DDALAB release is available for
+// the installed stack
+type StackUpdate struct {
+	CurrentVersion  string        `json:"current_version"`
+	LatestVersion   string        `json:"latest_version"`
+	UpdateAvailable bool          `json:"update_available"`
+	ReleaseNotes    string        `json:"release_notes,omitempty"`
+	Images          []ImageUpdate `json:"images,omitempty"`
 }
 
-// GetLogs retrieves service logs using the new v1 API
-func (c *Client) GetLogs(ctx context.Context) (string, error) {
-	endpoint := fmt.Sprintf("/api/%s/logs", c.apiVersion)
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+endpoint, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create logs request: %w", err)
-	}
+// ImageUpdate compares the image a service runs with the newest one
+type ImageUpdate struct {
+	Service         string `json:"service"`
+	Image           string `json:"image"`
+	CurrentDigest   string `json:"current_digest,omitempty"`
+	LatestDigest    string `json:"latest_digest,omitempty"`
+	UpdateAvailable bool   `json:"update_available"`
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("logs request failed: %w", err)
+// CheckStackUpdate asks the backend whether a newer DDALAB release or newer
+// images are available. It returns ErrUnsupported if the backend does not
+// announce the updates feature.
+func (c *Client) CheckStackUpdate(ctx context.Context) (*StackUpdate, error) {
+	if !c.HasFeature(FeatureUpdates) {
+		return nil, ErrUnsupported
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("logs request failed with status: %d", resp.StatusCode)
+	var update StackUpdate
+	endpoint := fmt.Sprintf("/api/%s/updates/check", c.APIVersion())
+	if err := c.call(ctx, http.MethodGet, endpoint, nil, &update); err != nil {
+		return nil, fmt.Errorf("update check failed: %w", err)
 	}
+	return &update, nil
+}
 
-	var response StandardResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return "", fmt.Errorf("failed to decode logs response: %w", err)
+// GetLogs retrieves service logs using the new v1 API
+func (c *Client) GetLogs(ctx context.Context) (string, error) {
+	var data struct {
+		Logs *string `json:"logs"`
 	}
-
-	if !response.Success {
-		if response.Error != nil {
-			return "", fmt.Errorf("API error: %s - %s", response.Error.Code, response.Error.Message)
-		}
-		return "", fmt.Errorf("logs request failed")
+	endpoint := fmt.Sprintf("/api/%s/logs", c.APIVersion())
+	if err := c.call(ctx, http.MethodGet, endpoint, nil, &data); err != nil {
+		return "", fmt.Errorf("logs request failed: %w", err)
 	}
 
-	// Extract logs from the response data
-	if data, ok := response.Data.(map[string]interface{}); ok {
-		if logs, exists := data["logs"]; exists {
-			if logStr, ok := logs.(string); ok {
-				return logStr, nil
-			}
-		}
+	if data.Logs == nil {
+		return "", fmt.Errorf("unexpected logs response format")
 	}
 
-	return "", fmt.Errorf("unexpected logs response format")
+	return *data.Logs, nil
 }
 
-// CreateBackup creates a database backup using legacy endpoint
-func (c *Client) CreateBackup(ctx context.Context) (string, error) {
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/api/backup", nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create backup request: %w", err)
+// LogQuery narrows the logs returned by GetServiceLogs
+type LogQuery struct {
+	Tail  int    // Only the last Tail lines (0 for the server default)
+	Level string // Minimum level: debug, info, warn or error (empty for all)
+}
+
+// GetServiceLogs retrieves the logs of one service, filtered by the server.
+// It returns ErrUnsupported if the backend does not announce the log filter
+// feature.
+func (c *Client) GetServiceLogs(ctx context.Context, service string, query LogQuery) (string, error) {
+	if !c.HasFeature(FeatureLogFilter) {
+		return "", ErrUnsupported
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("backup request failed: %w", err)
+	params := url.Values{}
+	if query.Tail > 0 {
+		params.Set("tail", strconv.Itoa(query.Tail))
 	}
-	defer resp.Body.Close()
+	if query.Level != "" {
+		params.Set("level", query.Level)
+	}
+	endpoint := fmt.Sprintf("/api/%s/services/%s/logs", c.APIVersion(), url.PathEscape(service))
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("backup failed with status %d: %s", resp.StatusCode, string(body))
+	var data struct {
+		Logs *string `json:"logs"`
+	}
+	if err := c.callQuery(ctx, http.MethodGet, endpoint, params, nil, &data); err != nil {
+		return "", fmt.Errorf("service logs request failed: %w", err)
 	}
+	if data.Logs == nil {
+		return "", fmt.Errorf("unexpected logs response format")
+	}
+	return *data.Logs, nil
+}
 
+// CreateBackup creates a database backup using legacy endpoint
+func (c *Client) CreateBackup(ctx context.Context) (string, error) {
 	var result map[string]string
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", fmt.Errorf("failed to decode backup response: %w", err)
+	if err := c.call(ctx, http.MethodPost, "/api/backup", nil, &result); err != nil {
+		return "", fmt.Errorf("backup request failed: %w", err)
 	}
-
 	return result["filename"], nil
 }
 
@@ -352,110 +645,49 @@ func (c *Client) UpdateDDALAB(ctx context.Context) error {
 	return c.UpdateStack(ctx)
 }
 
-// GetEnvConfig retrieves environment configuration
+// GetEnvConfig retrieves environment configuration from the legacy /env endpoint
 func (c *Client) GetEnvConfig(ctx context.Context) (*EnvConfig, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/env", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create env config request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("env config request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("env config request failed with status: %d", resp.StatusCode)
-	}
-
 	var config EnvConfig
-	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil {
-		return nil, fmt.Errorf("failed to decode env config response: %w", err)
+	if err := c.call(ctx, http.MethodGet, "/env", nil, &config); err != nil {
+		return nil, fmt.Errorf("env config request failed: %w", err)
 	}
-
 	return &config, nil
 }
 
 // ValidatePath validates a DDALAB installation path using v1 API
 func (c *Client) ValidatePath(ctx context.Context, path string) (*PathValidationResult, error) {
-	payload := map[string]string{"path": path}
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal path validation request: %w", err)
-	}
+	endpoint := fmt.Sprintf("/api/%s/paths/validate", c.APIVersion())
 
-	endpoint := fmt.Sprintf("/api/%s/paths/validate", c.apiVersion)
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+endpoint, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create path validation request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
+	var result PathValidationResult
+	err := c.call(ctx, http.MethodPost, endpoint, map[string]string{"path": path}, &result)
 
-	resp, err := c.httpClient.Do(req)
+	// Invalid paths may be reported with a non-200 status and a result body
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && json.Unmarshal(statusErr.Body, &result) == nil {
+		return &result, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("path validation request failed: %w", err)
 	}
-	defer resp.Body.Close()
-
-	var result PathValidationResult
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode path validation response: %w", err)
-	}
 
 	return &result, nil
 }
 
 // SelectPath selects a DDALAB installation path using v1 API
 func (c *Client) SelectPath(ctx context.Context, path string) error {
-	payload := map[string]string{"path": path}
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("failed to marshal path selection request: %w", err)
+	endpoint := fmt.Sprintf("/api/%s/paths/select", c.APIVersion())
+	if err := c.call(ctx, http.MethodPost, endpoint, map[string]string{"path": path}, nil); err != nil {
+		return fmt.Errorf("path selection failed: %w", err)
 	}
-
-	endpoint := fmt.Sprintf("/api/%s/paths/select", c.apiVersion)
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+endpoint, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create path selection request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("path selection request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("path selection failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
 	return nil
 }
 
 // DiscoverPaths discovers DDALAB installation paths
 func (c *Client) DiscoverPaths(ctx context.Context) ([]string, error) {
-	endpoint := fmt.Sprintf("/api/%s/paths/discover", c.apiVersion)
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+endpoint, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create path discovery request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("path discovery request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("path discovery request failed with status: %d", resp.StatusCode)
-	}
-
 	var result map[string][]string
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode path discovery response: %w", err)
+	endpoint := fmt.Sprintf("/api/%s/paths/discover", c.APIVersion())
+	if err := c.call(ctx, http.MethodGet, endpoint, nil, &result); err != nil {
+		return nil, fmt.Errorf("path discovery request failed: %w", err)
 	}
 
 	if paths, exists := result["discovered_paths"]; exists {
@@ -505,46 +737,45 @@ type ConfigSummary struct {
 
 // GetEnvConfigNew retrieves environment configuration using the new v1 API
 func (c *Client) GetEnvConfigNew(ctx context.Context) (*EnvConfigResponse, error) {
-	endpoint := fmt.Sprintf("/api/%s/config/env", c.apiVersion)
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+endpoint, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create env config request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
+	var envConfig EnvConfigResponse
+	endpoint := fmt.Sprintf("/api/%s/config/env", c.APIVersion())
+	if err := c.call(ctx, http.MethodGet, endpoint, nil, &envConfig); err != nil {
 		return nil, fmt.Errorf("env config request failed: %w", err)
 	}
-	defer resp.Body.Close()
+	return &envConfig, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("env config request failed with status: %d", resp.StatusCode)
-	}
+// ValidationIssue is a problem the backend found with one variable
+type ValidationIssue struct {
+	Key      string `json:"key"`
+	Severity string `json:"severity"` // "error" or "warning"
+	Message  string `json:"message"`
+}
 
-	var response StandardResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode env config response: %w", err)
-	}
+// ValidationReport is the backend's verdict on a set of variables
+type ValidationReport struct {
+	Valid  bool              `json:"valid"`
+	Issues []ValidationIssue `json:"issues"`
+}
 
-	if !response.Success {
-		if response.Error != nil {
-			return nil, fmt.Errorf("API error: %s - %s", response.Error.Code, response.Error.Message)
-		}
-		return nil, fmt.Errorf("env config request failed")
+// ValidateEnvConfig asks the backend whether it would accept the variables,
+// without saving them. It returns ErrUnsupported if the backend does not
+// announce the env_validate feature.
+func (c *Client) ValidateEnvConfig(ctx context.Context, variables []EnvVariable) (*ValidationReport, error) {
+	if !c.HasFeature(FeatureEnvValidate) {
+		return nil, ErrUnsupported
 	}
 
-	// Convert the data to EnvConfigResponse struct
-	dataBytes, err := json.Marshal(response.Data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal env config data: %w", err)
+	payload := map[string]interface{}{
+		"variables": variables,
 	}
 
-	var envConfig EnvConfigResponse
-	if err := json.Unmarshal(dataBytes, &envConfig); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal env config data: %w", err)
+	var report ValidationReport
+	endpoint := fmt.Sprintf("/api/%s/config/env/validate", c.APIVersion())
+	if err := c.call(ctx, http.MethodPost, endpoint, payload, &report); err != nil {
+		return nil, fmt.Errorf("env config validation failed: %w", err)
 	}
-
-	return &envConfig, nil
+	return &report, nil
 }
 
 // UpdateEnvConfig updates environment configuration using the new v1 API
@@ -553,28 +784,10 @@ func (c *Client) UpdateEnvConfig(ctx context.Context, variables []EnvVariable) e
 		"variables":     variables,
 		"create_backup": true,
 	}
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("failed to marshal env config update request: %w", err)
-	}
 
-	endpoint := fmt.Sprintf("/api/%s/config/env", c.apiVersion)
-	req, err := http.NewRequestWithContext(ctx, "PUT", c.baseURL+endpoint, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create env config update request: %w", err)
+	endpoint := fmt.Sprintf("/api/%s/config/env", c.APIVersion())
+	if err := c.call(ctx, http.MethodPut, endpoint, payload, nil); err != nil {
+		return fmt.Errorf("env config update failed: %w", err)
 	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("env config update request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("env config update failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
 	return nil
 }
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetServiceLogsSendsQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/services/web/logs" {
+			t.Errorf("path = %q, want /api/v1/services/web/logs", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("tail"); got != "100" {
+			t.Errorf("tail = %q, want 100", got)
+		}
+		if got := r.URL.Query().Get("level"); got != "warn" {
+			t.Errorf("level = %q, want warn", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"logs": "line"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.serverFeatures[FeatureLogFilter] = true
+
+	logs, err := client.GetServiceLogs(context.Background(), "web", LogQuery{Tail: 100, Level: "warn"})
+	if err != nil {
+		t.Fatalf("GetServiceLogs failed: %v", err)
+	}
+	if logs != "line" {
+		t.Errorf("logs = %q, want %q", logs, "line")
+	}
+}
+
+func TestEndpointURL(t *testing.T) {
+	tests := []struct {
+		base     string
+		endpoint string
+		want     string
+	}{
+		{"http://localhost:8080", "/api/v1/status", "http://localhost:8080/api/v1/status"},
+		{"http://localhost:8080/", "/api/v1/status", "http://localhost:8080/api/v1/status"},
+		{"http://localhost:8080", "api/v1/status", "http://localhost:8080/api/v1/status"},
+		{"https://host:8443", "/api/test", "https://host:8443/api/test"},
+		{"http://[::1]:8080", "/api/v1/status", "http://[::1]:8080/api/v1/status"},
+		{"http://[fe80::1%25eth0]:8080", "/api/test", "http://[fe80::1%25eth0]:8080/api/test"},
+		{"https://host/ddalab", "/api/v1/status", "https://host/ddalab/api/v1/status"},
+		{"https://host/ddalab/", "/api/v1/status", "https://host/ddalab/api/v1/status"},
+		{"http://host", "/api/v1/services/a%2Fb/logs", "http://host/api/v1/services/a%2Fb/logs"},
+	}
+	for _, tt := range tests {
+		client := NewClient(tt.base)
+		got, err := client.endpointURL(tt.endpoint)
+		if err != nil {
+			t.Errorf("endpointURL(%q) with base %q failed: %v", tt.endpoint, tt.base, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("endpointURL(%q) with base %q = %q, want %q", tt.endpoint, tt.base, got, tt.want)
+		}
+	}
+}
+
+// A query string joined onto the path is escaped as part of it, so query
+// parameters must be passed to newRequest instead
+func TestNewRequestQuery(t *testing.T) {
+	client := NewClient("http://[::1]:8080/ddalab")
+
+	joined, err := client.endpointURL("/api/v1/services/web/logs?tail=100")
+	if err != nil {
+		t.Fatalf("endpointURL failed: %v", err)
+	}
+	if want := "http://[::1]:8080/ddalab/api/v1/services/web/logs%3Ftail=100"; joined != want {
+		t.Errorf("endpointURL with a query = %q, want %q", joined, want)
+	}
+
+	query := url.Values{"tail": {"100"}, "level": {"warn"}}
+	req, err := client.newRequest(context.Background(), http.MethodGet, "/api/v1/services/web/logs", query, nil)
+	if err != nil {
+		t.Fatalf("newRequest failed: %v", err)
+	}
+	if want := "http://[::1]:8080/ddalab/api/v1/services/web/logs?level=warn&tail=100"; req.URL.String() != want {
+		t.Errorf("request URL = %q, want %q", req.URL.String(), want)
+	}
+}
+
+// The version check may switch the API version while other requests build
+// their endpoints, which go test -race reports unless it is locked
+func TestVersionCheckConcurrentWithRequests(t *testing.T) {
+	var checks atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == DefaultVersionPath {
+			// Every check switches the version
+			version := []string{"v2", "v3"}[checks.Add(1)%2]
+			_ = json.NewEncoder(w).Encode(VersionInfo{Version: "2.0.0", SupportedVersions: []string{version}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"success":  true,
+			"data":     map[string]any{},
+			"metadata": map[string]string{"api_version": "v2"},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				_ = client.APIVersion()
+			}
+		}
+	}()
+	for i := 0; i < 5; i++ {
+		if err := client.HealthCheck(context.Background()); err != nil {
+			t.Errorf("HealthCheck failed: %v", err)
+		}
+		if _, err := client.GetStatus(context.Background()); err != nil {
+			t.Errorf("GetStatus failed: %v", err)
+		}
+	}
+	close(stop)
+	<-done
+
+	if got, want := client.APIVersion(), []string{"v2", "v3"}[checks.Load()%2]; got != want {
+		t.Errorf("APIVersion() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,573 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// The contract test checks the client against the backend's OpenAPI spec in
+// testdata/openapi.json. Every client method is called against a mock
+// server that only answers the operations in the spec, checks the requests
+// against it and stubs the responses from its schemas. The field names of
+// the types the client decodes must be in the response schemas, so a
+// renamed endpoint or field in the spec fails the test. The spec is
+// exported from a running backend with scripts/refresh-openapi.sh.
+
+// openAPISpec is the part of an OpenAPI 3 document the contract test reads
+type openAPISpec struct {
+	Paths      map[string]map[string]*apiOperation `json:"paths"`
+	Components struct {
+		Parameters map[string]*apiParameter `json:"parameters"`
+		Schemas    map[string]*apiSchema    `json:"schemas"`
+	} `json:"components"`
+}
+
+type apiOperation struct {
+	OperationID string              `json:"operationId"`
+	Parameters  []*apiParameter     `json:"parameters"`
+	RequestBody *apiBody            `json:"requestBody"`
+	Responses   map[string]*apiBody `json:"responses"`
+}
+
+type apiParameter struct {
+	Ref      string     `json:"$ref"`
+	Name     string     `json:"name"`
+	In       string     `json:"in"`
+	Required bool       `json:"required"`
+	Schema   *apiSchema `json:"schema"`
+}
+
+type apiBody struct {
+	Required bool `json:"required"`
+	Content  map[string]struct {
+		Schema *apiSchema `json:"schema"`
+	} `json:"content"`
+}
+
+type apiSchema struct {
+	Ref                  string                `json:"$ref"`
+	Type                 string                `json:"type"`
+	Format               string                `json:"format"`
+	Properties           map[string]*apiSchema `json:"properties"`
+	Items                *apiSchema            `json:"items"`
+	AdditionalProperties *apiSchema            `json:"additionalProperties"`
+	Required             []string              `json:"required"`
+	Enum                 []any                 `json:"enum"`
+	Example              any                   `json:"example"`
+}
+
+func loadSpec(t *testing.T) *openAPISpec {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "openapi.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spec openAPISpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatalf("invalid spec: %v", err)
+	}
+	return &spec
+}
+
+// schema resolves a reference to a schema in the components
+func (s *openAPISpec) schema(schema *apiSchema) *apiSchema {
+	for schema != nil && schema.Ref != "" {
+		schema = s.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+	return schema
+}
+
+// parameter resolves a reference to a parameter in the components
+func (s *openAPISpec) parameter(param *apiParameter) *apiParameter {
+	if param.Ref != "" {
+		return s.Components.Parameters[strings.TrimPrefix(param.Ref, "#/components/parameters/")]
+	}
+	return param
+}
+
+// operations returns the keys of all operations, e.g. "GET /api/test"
+func (s *openAPISpec) operations() []string {
+	var keys []string
+	for path, item := range s.Paths {
+		for method := range item {
+			keys = append(keys, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// match finds the operation for a request and the values of its path
+// parameters
+func (s *openAPISpec) match(method, path string) (key string, op *apiOperation, params map[string]string) {
+	segments := strings.Split(path, "/")
+	for template, item := range s.Paths {
+		op := item[strings.ToLower(method)]
+		if op == nil {
+			continue
+		}
+		parts := strings.Split(template, "/")
+		if len(parts) != len(segments) {
+			continue
+		}
+
+		params := make(map[string]string)
+		for i, part := range parts {
+			if name, ok := strings.CutPrefix(part, "{"); ok {
+				value, err := url.PathUnescape(segments[i])
+				if err != nil || value == "" {
+					params = nil
+					break
+				}
+				params[strings.TrimSuffix(name, "}")] = value
+			} else if part != segments[i] {
+				params = nil
+				break
+			}
+		}
+		if params != nil {
+			return method + " " + template, op, params
+		}
+	}
+	return "", nil, nil
+}
+
+// stub returns a value that fits schema, preferring its example
+func (s *openAPISpec) stub(schema *apiSchema) any {
+	schema = s.schema(schema)
+	if schema.Example != nil {
+		return schema.Example
+	}
+	if len(schema.Enum) > 0 {
+		return schema.Enum[0]
+	}
+
+	switch schema.Type {
+	case "object":
+		object := make(map[string]any)
+		for name, property := range schema.Properties {
+			object[name] = s.stub(property)
+		}
+		if schema.AdditionalProperties != nil {
+			object["example"] = s.stub(schema.AdditionalProperties)
+		}
+		return object
+	case "array":
+		return []any{s.stub(schema.Items)}
+	case "boolean":
+		return true
+	case "integer":
+		return 1
+	case "number":
+		return 1.5
+	case "string":
+		if schema.Format == "date-time" {
+			return "2024-06-01T10:30:00Z"
+		}
+		return "example"
+	}
+	return nil
+}
+
+// checkValue returns where a value decoded from JSON does not fit schema
+func (s *openAPISpec) checkValue(schema *apiSchema, value any, path string) []string {
+	schema = s.schema(schema)
+	var problems []string
+
+	switch value := value.(type) {
+	case map[string]any:
+		if schema.Type != "object" {
+			return []string{fmt.Sprintf("%s is an object, the spec has %s", path, schema.Type)}
+		}
+		for _, name := range schema.Required {
+			if _, ok := value[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s is required by the spec", path, name))
+			}
+		}
+		for name, field := range value {
+			property := schema.Properties[name]
+			if property == nil {
+				property = schema.AdditionalProperties
+			}
+			if property == nil {
+				problems = append(problems, fmt.Sprintf("%s.%s is not in the spec", path, name))
+				continue
+			}
+			problems = append(problems, s.checkValue(property, field, path+"."+name)...)
+		}
+	case []any:
+		if schema.Type != "array" {
+			return []string{fmt.Sprintf("%s is an array, the spec has %s", path, schema.Type)}
+		}
+		for i, item := range value {
+			problems = append(problems, s.checkValue(schema.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case string:
+		if schema.Type != "string" {
+			problems = append(problems, fmt.Sprintf("%s is a string, the spec has %s", path, schema.Type))
+		} else if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, any(value)) {
+			problems = append(problems, fmt.Sprintf("%s is %q, the spec allows %v", path, value, schema.Enum))
+		}
+	case bool:
+		if schema.Type != "boolean" {
+			problems = append(problems, fmt.Sprintf("%s is a boolean, the spec has %s", path, schema.Type))
+		}
+	case float64:
+		if schema.Type != "number" && schema.Type != "integer" {
+			problems = append(problems, fmt.Sprintf("%s is a number, the spec has %s", path, schema.Type))
+		}
+	}
+	return problems
+}
+
+// checkType returns the fields of a type the client decodes a response into
+// that are not in schema, or whose kind does not match
+func (s *openAPISpec) checkType(schema *apiSchema, typ reflect.Type, path string) []string {
+	schema = s.schema(schema)
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	mismatch := func(want string) []string {
+		if schema.Type == want || (want == "number" && schema.Type == "integer") {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s is a %s in the client, the spec has %s", path, want, schema.Type)}
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		if typ == reflect.TypeOf(time.Time{}) {
+			return mismatch("string")
+		}
+		if problems := mismatch("object"); problems != nil {
+			return problems
+		}
+		var problems []string
+		for i := range typ.NumField() {
+			field := typ.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "-" || name == "" {
+				continue
+			}
+			property := schema.Properties[name]
+			if property == nil {
+				problems = append(problems, fmt.Sprintf("%s.%s is not in the spec", path, name))
+				continue
+			}
+			problems = append(problems, s.checkType(property, field.Type, path+"."+name)...)
+		}
+		return problems
+	case reflect.Slice:
+		if problems := mismatch("array"); problems != nil {
+			return problems
+		}
+		return s.checkType(schema.Items, typ.Elem(), path+"[]")
+	case reflect.Map:
+		if problems := mismatch("object"); problems != nil || schema.AdditionalProperties == nil {
+			return problems
+		}
+		return s.checkType(schema.AdditionalProperties, typ.Elem(), path+"[]")
+	case reflect.String:
+		return mismatch("string")
+	case reflect.Bool:
+		return mismatch("boolean")
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return mismatch("integer")
+	case reflect.Float32, reflect.Float64:
+		return mismatch("number")
+	}
+	return nil
+}
+
+// contractServer is a mock backend answering the operations of a spec
+type contractServer struct {
+	t    *testing.T
+	spec *openAPISpec
+
+	mu   sync.Mutex
+	hits map[string]int // Requests per operation key
+}
+
+func (c *contractServer) hitCount(key string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits[key]
+}
+
+func (c *contractServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key, op, pathParams := c.spec.match(r.Method, r.URL.EscapedPath())
+	if op == nil {
+		c.t.Errorf("%s %s is not in the spec", r.Method, r.URL.Path)
+		http.NotFound(w, r)
+		return
+	}
+	c.mu.Lock()
+	c.hits[key]++
+	c.mu.Unlock()
+
+	// Parameters
+	declared := make(map[string]bool)
+	for _, param := range op.Parameters {
+		param = c.spec.parameter(param)
+		declared[param.In+" "+param.Name] = true
+
+		var values []string
+		switch param.In {
+		case "path":
+			values = []string{pathParams[param.Name]}
+		case "query":
+			values = r.URL.Query()[param.Name]
+		}
+		if param.Required && len(values) == 0 {
+			c.t.Errorf("%s: %s parameter %s is required by the spec", key, param.In, param.Name)
+		}
+		for _, value := range values {
+			c.checkParameter(key, param, value)
+		}
+	}
+	for name := range pathParams {
+		if !declared["path "+name] {
+			c.t.Errorf("%s: path parameter %s is not in the spec", key, name)
+		}
+	}
+	for name := range r.URL.Query() {
+		if !declared["query "+name] {
+			c.t.Errorf("%s: query parameter %s is not in the spec", key, name)
+		}
+	}
+
+	// Request body
+	var body any
+	if err := json.NewDecoder(r.Body).Decode(&body); err == nil {
+		if op.RequestBody == nil {
+			c.t.Errorf("%s: the spec has no request body", key)
+		} else {
+			for _, problem := range c.spec.checkValue(op.RequestBody.Content["application/json"].Schema, body, "request") {
+				c.t.Errorf("%s: %s", key, problem)
+			}
+		}
+	} else if op.RequestBody != nil && op.RequestBody.Required {
+		c.t.Errorf("%s: the spec requires a request body: %v", key, err)
+	}
+
+	// Response
+	response := op.Responses["200"]
+	if response == nil {
+		c.t.Errorf("%s: the spec has no 200 response", key)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	for contentType, media := range response.Content {
+		data, err := json.Marshal(c.spec.stub(media.Schema))
+		if err != nil {
+			c.t.Errorf("%s: %v", key, err)
+		}
+		w.Header().Set("Content-Type", contentType)
+		if contentType == "text/event-stream" {
+			fmt.Fprintf(w, "data: %s\n\n", data)
+		} else {
+			w.Write(data)
+		}
+		return
+	}
+}
+
+// checkParameter reports a parameter value the spec does not allow
+func (c *contractServer) checkParameter(key string, param *apiParameter, value string) {
+	schema := c.spec.schema(param.Schema)
+	if schema == nil {
+		return
+	}
+	if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, any(value)) {
+		c.t.Errorf("%s: %s parameter %s is %q, the spec allows %v", key, param.In, param.Name, value, schema.Enum)
+	}
+	if _, err := strconv.Atoi(value); schema.Type == "integer" && err != nil {
+		c.t.Errorf("%s: %s parameter %s is %q, the spec wants an integer", key, param.In, param.Name, value)
+	}
+}
+
+// contractCall is a client method and the operation it must use
+type contractCall struct {
+	name string
+	op   string // Operation key, e.g. "GET /api/{version}/status"
+	// response is a value of the type the client decodes the response into,
+	// nil if it decodes none
+	response any
+	// call calls the method and returns its result, which must not be empty
+	// when it is not nil
+	call func(ctx context.Context, c *Client) (any, error)
+}
+
+var envVariables = []EnvVariable{
+	{Key: "DB_HOST", Value: "postgres", Section: "Database", IsRequired: true},
+	{Key: "DB_PASSWORD", Value: "secret", Comment: "Database password", IsSecret: true},
+}
+
+var contractCalls = []contractCall{
+	{"checkVersion", "GET /api/version", VersionInfo{}, func(ctx context.Context, c *Client) (any, error) {
+		return nil, c.checkVersion(ctx)
+	}},
+	{"Ping", "GET /api/test", nil, func(ctx context.Context, c *Client) (any, error) {
+		_, err := c.Ping(ctx)
+		return nil, err
+	}},
+	{"GetStatus", "GET /api/{version}/status", Status{}, func(ctx context.Context, c *Client) (any, error) {
+		return c.GetStatus(ctx)
+	}},
+	{"GetStatusIfChanged", "GET /api/{version}/status", Status{}, func(ctx context.Context, c *Client) (any, error) {
+		status, _, err := c.GetStatusIfChanged(ctx, StatusValidator{})
+		return status, err
+	}},
+	{"StartStack", "POST /api/{version}/lifecycle/{action}", nil, func(ctx context.Context, c *Client) (any, error) {
+		return nil, c.StartStack(ctx)
+	}},
+	{"StopStack", "POST /api/{version}/lifecycle/{action}", nil, func(ctx context.Context, c *Client) (any, error) {
+		return nil, c.StopStack(ctx)
+	}},
+	{"DeepStopStack", "POST /api/{version}/lifecycle/{action}", nil, func(ctx context.Context, c *Client) (any, error) {
+		return nil, c.DeepStopStack(ctx)
+	}},
+	{"RestartStack", "POST /api/{version}/lifecycle/{action}", nil, func(ctx context.Context, c *Client) (any, error) {
+		return nil, c.RestartStack(ctx)
+	}},
+	{"UpdateStack", "POST /api/{version}/lifecycle/{action}", nil, func(ctx context.Context, c *Client) (any, error) {
+		return nil, c.UpdateStack(ctx)
+	}},
+	{"RestartService", "POST /api/{version}/services/{name}/restart", nil, func(ctx context.Context, c *Client) (any, error) {
+		return nil, c.RestartService(ctx, "ddalab")
+	}},
+	{"GetServiceLogs", "GET /api/{version}/services/{name}/logs", nil, func(ctx context.Context, c *Client) (any, error) {
+		return c.GetServiceLogs(ctx, "ddalab", LogQuery{Tail: 100, Level: "warn"})
+	}},
+	{"GetLogs", "GET /api/{version}/logs", nil, func(ctx context.Context, c *Client) (any, error) {
+		return c.GetLogs(ctx)
+	}},
+	{"GetActiveJobs", "GET /api/{version}/jobs/active", struct {
+		Jobs []Job `json:"jobs"`
+	}{}, func(ctx context.Context, c *Client) (any, error) {
+		return c.GetActiveJobs(ctx)
+	}},
+	{"CheckStackUpdate", "GET /api/{version}/updates/check", StackUpdate{}, func(ctx context.Context, c *Client) (any, error) {
+		return c.CheckStackUpdate(ctx)
+	}},
+	{"StreamEvents", "GET /api/{version}/events", Event{}, func(ctx context.Context, c *Client) (any, error) {
+		events := make(chan Event, 1)
+		err := c.StreamEvents(ctx, events)
+		select {
+		case event := <-events:
+			return event, nil // The mock closes the stream after one event
+		default:
+			return nil, err
+		}
+	}},
+	{"ValidatePath", "POST /api/{version}/paths/validate", PathValidationResult{}, func(ctx context.Context, c *Client) (any, error) {
+		return c.ValidatePath(ctx, "/home/user/DDALAB")
+	}},
+	{"SelectPath", "POST /api/{version}/paths/select", nil, func(ctx context.Context, c *Client) (any, error) {
+		return nil, c.SelectPath(ctx, "/home/user/DDALAB")
+	}},
+	{"DiscoverPaths", "GET /api/{version}/paths/discover", nil, func(ctx context.Context, c *Client) (any, error) {
+		return c.DiscoverPaths(ctx)
+	}},
+	{"GetEnvConfigNew", "GET /api/{version}/config/env", EnvConfigResponse{}, func(ctx context.Context, c *Client) (any, error) {
+		return c.GetEnvConfigNew(ctx)
+	}},
+	{"UpdateEnvConfig", "PUT /api/{version}/config/env", nil, func(ctx context.Context, c *Client) (any, error) {
+		return nil, c.UpdateEnvConfig(ctx, envVariables)
+	}},
+	{"ValidateEnvConfig", "POST /api/{version}/config/env/validate", ValidationReport{}, func(ctx context.Context, c *Client) (any, error) {
+		return c.ValidateEnvConfig(ctx, envVariables)
+	}},
+	{"CreateBackup", "POST /api/backup", nil, func(ctx context.Context, c *Client) (any, error) {
+		return c.CreateBackup(ctx)
+	}},
+	{"GetEnvConfig", "GET /env", EnvConfig{}, func(ctx context.Context, c *Client) (any, error) {
+		return c.GetEnvConfig(ctx)
+	}},
+}
+
+func TestClientMatchesSpec(t *testing.T) {
+	spec := loadSpec(t)
+	backend := &contractServer{t: t, spec: spec, hits: make(map[string]int)}
+	server := httptest.NewServer(backend)
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	client.authToken = ""
+	client.debug = false
+	client.maxRetries = 0
+
+	covered := make(map[string]bool)
+	for _, tt := range contractCalls {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			hits := backend.hitCount(tt.op)
+			result, err := tt.call(ctx, client)
+			if err != nil {
+				t.Fatalf("%s failed: %v", tt.name, err)
+			}
+			if backend.hitCount(tt.op) == hits {
+				t.Errorf("%s did not request %s", tt.name, tt.op)
+			}
+			if result != nil && reflect.ValueOf(result).IsZero() {
+				t.Errorf("%s returned an empty result - a field it reads may be missing from the spec", tt.name)
+			}
+
+			if tt.response != nil {
+				method, path, _ := strings.Cut(tt.op, " ")
+				op := spec.Paths[path][strings.ToLower(method)]
+				for _, media := range op.Responses["200"].Content {
+					for _, problem := range spec.checkType(media.Schema, reflect.TypeOf(tt.response), "response") {
+						t.Error(problem)
+					}
+				}
+			}
+		})
+		covered[tt.op] = true
+	}
+
+	for _, op := range spec.operations() {
+		if !covered[op] {
+			t.Errorf("%s is in the spec but not called by the contract test", op)
+		}
+	}
+}
+
+func TestCheckTypeFindsRenamedField(t *testing.T) {
+	spec := loadSpec(t)
+	status := spec.Components.Schemas["Status"]
+	status.Properties["is_running"] = status.Properties["running"]
+	delete(status.Properties, "running")
+
+	problems := spec.checkType(status, reflect.TypeOf(Status{}), "response")
+	if len(problems) != 1 || !strings.Contains(problems[0], "response.running is not in the spec") {
+		t.Errorf("problems = %q, want the renamed running field", problems)
+	}
+}
+
+func TestCheckValueFindsUnknownRequestField(t *testing.T) {
+	spec := loadSpec(t)
+	request := map[string]any{"variables": []any{map[string]any{"key": "A", "value": "1", "secret": true}}}
+
+	problems := spec.checkValue(spec.Components.Schemas["EnvValidateRequest"], request, "request")
+	if len(problems) != 1 || !strings.Contains(problems[0], "request.variables[0].secret is not in the spec") {
+		t.Errorf("problems = %q, want the unknown secret field", problems)
+	}
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// maxEventLine bounds a single line of the event stream
+const maxEventLine = 1024 * 1024
+
+// Event is an application-level event of the backend, e.g. a user login
+// or a finished analysis, as opposed to a container log line
+type Event struct {
+	ID      string    `json:"id,omitempty"`
+	Type    string    `json:"type"` // e.g. "user.login" or "analysis.completed"
+	Time    time.Time `json:"time"`
+	Message string    `json:"message,omitempty"`
+	Actor   string    `json:"actor,omitempty"` // User or service that caused the event
+}
+
+// StreamEvents sends the backend's events to out as they happen until ctx
+// is done, which returns nil, or the stream fails. The backend may send
+// server-sent events ("data: {...}") or one JSON object per line. out is
+// not closed. It returns ErrUnsupported if the backend does not announce
+// the events feature.
+func (c *Client) StreamEvents(ctx context.Context, out chan<- Event) error {
+	if !c.HasFeature(FeatureEvents) {
+		return ErrUnsupported
+	}
+	if err := c.breaker.allow(); err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("/api/%s/events", c.APIVersion())
+	req, err := c.newRequest(ctx, http.MethodGet, endpoint, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream, application/x-ndjson")
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+
+	// The client's overall timeout would cut the stream off; the connect
+	// timeout of the shared transport still applies
+	streamClient := *c.httpClient
+	streamClient.Timeout = 0
+
+	resp, err := streamClient.Do(req)
+	c.breaker.record(err)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("events request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("events request failed: %w", newStatusError(resp))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventLine)
+	for scanner.Scan() {
+		data, ok := eventData(scanner.Bytes())
+		if !ok {
+			continue
+		}
+
+		var event Event
+		if err := json.Unmarshal(data, &event); err != nil {
+			if c.debug {
+				log.Printf("API events: skipping malformed event %s", RedactSecrets(data))
+			}
+			continue
+		}
+		if event.Time.IsZero() {
+			event.Time = time.Now()
+		}
+
+		select {
+		case out <- event:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("events stream failed: %w", err)
+	}
+	return fmt.Errorf("events stream closed by the backend")
+}
+
+// eventData returns the JSON payload of a stream line. Blank lines,
+// comments and the other fields of server-sent events carry none.
+func eventData(line []byte) ([]byte, bool) {
+	line = bytes.TrimSpace(line)
+	if data, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+		line = bytes.TrimSpace(data)
+	}
+	if len(line) == 0 || line[0] != '{' {
+		return nil, false
+	}
+	return line, true
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/ddalab/launcher/pkg/config"
+)
+
+// redactedValue replaces secret values in debug output
+const redactedValue = "***"
+
+// envLinePattern matches KEY=VALUE lines in plain-text bodies
+var envLinePattern = regexp.MustCompile(`(?m)^(\s*(?:export\s+)?([A-Za-z_][A-Za-z0-9_]*)\s*=\s*)(.*)$`)
+
+// RedactSecrets masks secret values in a request or response body so it can
+// be logged safely. JSON bodies have the values of secret keys masked, as
+// well as the value of {"key": ..., "value": ...} pairs naming a secret or
+// flagged with "is_secret". Other bodies have secret KEY=VALUE lines masked.
+// Secret keys are detected with the same heuristics as config.IsSecretVar.
+func RedactSecrets(body []byte) []byte {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return body
+	}
+
+	if trimmed[0] == '{' || trimmed[0] == '[' {
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.UseNumber()
+
+		var value any
+		if err := decoder.Decode(&value); err == nil {
+			if redacted, err := json.Marshal(redactValue(value)); err == nil {
+				return redacted
+			}
+		}
+	}
+
+	return envLinePattern.ReplaceAllFunc(body, func(line []byte) []byte {
+		match := envLinePattern.FindSubmatch(line)
+		if !config.IsSecretVar(string(match[2])) || len(bytes.TrimSpace(match[3])) == 0 {
+			return line
+		}
+		return append(append([]byte{}, match[1]...), redactedValue...)
+	})
+}
+
+// redactValue returns a copy of a decoded JSON value with secrets masked
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, field := range v {
+			if isSecretField(key) && !isEmptyValue(field) {
+				redacted[key] = redactedValue
+				continue
+			}
+			redacted[key] = redactValue(field)
+		}
+
+		if _, ok := redacted["value"]; ok && namesSecret(v) && !isEmptyValue(v["value"]) {
+			redacted["value"] = redactedValue
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = redactValue(item)
+		}
+		return redacted
+	}
+	return value
+}
+
+// isSecretField reports whether a JSON field holds a secret. The "key" and
+// "is_secret" fields of variable descriptions only name or flag one.
+func isSecretField(name string) bool {
+	if name == "key" || name == "is_secret" {
+		return false
+	}
+	return config.IsSecretVar(name)
+}
+
+// namesSecret reports whether an object describes a secret variable, i.e.
+// its "key" names a secret or it is flagged with "is_secret"
+func namesSecret(object map[string]any) bool {
+	if flag, ok := object["is_secret"].(bool); ok && flag {
+		return true
+	}
+	key, ok := object["key"].(string)
+	return ok && config.IsSecretVar(key)
+}
+
+// isEmptyValue reports whether a JSON value carries nothing worth masking:
+// null, an empty string or a flag
+func isEmptyValue(value any) bool {
+	switch v := value.(type) {
+	case nil, bool:
+		return true
+	case string:
+		return v == ""
+	}
+	return false
+}
+
+// redactHeaders returns a copy of headers with credentials masked
+func redactHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+	for name := range redacted {
+		if isSecretHeader(name) {
+			redacted[name] = []string{redactedValue}
+		}
+	}
+	return redacted
+}
+
+// isSecretHeader reports whether a header carries credentials
+func isSecretHeader(name string) bool {
+	switch http.CanonicalHeaderKey(name) {
+	case "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie":
+		return true
+	}
+	return config.IsSecretVar(strings.ReplaceAll(name, "-", "_"))
+}
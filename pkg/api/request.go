@@ -0,0 +1,319 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxErrorBody limits how much of an error response is kept for messages
+const maxErrorBody = 64 * 1024
+
+// Retry behaviour for idempotent requests
+const (
+	defaultMaxRetries = 2
+	defaultRetryDelay = 500 * time.Millisecond
+)
+
+// StatusError is returned when the backend answers with a non-200 status
+type StatusError struct {
+	StatusCode int
+	Body       []byte
+	Info       *ErrorInfo // Structured error, if the body was a StandardResponse
+}
+
+func (e *StatusError) Error() string {
+	if e.Info != nil {
+		return fmt.Sprintf("status %d: %s - %s", e.StatusCode, e.Info.Code, e.Info.Message)
+	}
+	if text := strings.TrimSpace(string(e.Body)); text != "" {
+		return fmt.Sprintf("status %d: %s", e.StatusCode, text)
+	}
+	return fmt.Sprintf("status %d", e.StatusCode)
+}
+
+// call sends a request to path and decodes the response into out (skipped
+// when out is nil). It sets the JSON and auth headers, retries idempotent
+// requests on transient failures, unwraps StandardResponse envelopes and
+// stores plain-text responses when out is a *string. While the circuit
+// breaker is open, it fails with ErrCircuitOpen without sending anything.
+func (c *Client) call(ctx context.Context, method, path string, body, out any) error {
+	return c.callQuery(ctx, method, path, nil, body, out)
+}
+
+// callQuery is call with the query parameters query added to the URL
+func (c *Client) callQuery(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var payload []byte
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		payload = data
+	}
+
+	data, header, err := c.do(ctx, method, path, query, payload, nil)
+	if err != nil {
+		return err
+	}
+	return c.decode(data, header.Get("Content-Type"), out)
+}
+
+// do sends a request through the circuit breaker, unless ctx bypasses it,
+// retrying idempotent requests on transient failures, and returns the
+// response body and headers of the first 200 response
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, payload []byte, extra http.Header) ([]byte, http.Header, error) {
+	attempts := 1
+	if isIdempotent(method) {
+		attempts += c.maxRetries
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			case <-time.After(c.retryDelay * time.Duration(attempt)):
+			}
+		}
+
+		poll := bypassesBreaker(ctx)
+		if !poll {
+			if err := c.breaker.allow(); err != nil {
+				if lastErr != nil {
+					return nil, nil, lastErr
+				}
+				return nil, nil, err
+			}
+		}
+
+		data, header, err := c.send(ctx, method, path, query, payload, extra)
+		if !poll || err == nil {
+			c.breaker.record(err)
+		}
+		if err == nil {
+			return data, header, nil
+		}
+
+		lastErr = err
+		if ctx.Err() != nil || !isRetryable(err) {
+			break
+		}
+	}
+
+	return nil, nil, lastErr
+}
+
+// send performs a single request with the extra headers and returns the
+// response body and headers for 200 responses, or a *StatusError otherwise
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, payload []byte, extra http.Header) ([]byte, http.Header, error) {
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := c.newRequest(ctx, method, path, query, reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range extra {
+		req.Header[key] = values
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+
+	if c.debug {
+		log.Printf("API request: %s %s headers=%v body=%s",
+			method, req.URL, redactHeaders(req.Header), RedactSecrets(payload))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		statusErr := newStatusError(resp)
+		c.logResponse(resp, statusErr.Body)
+		return nil, nil, statusErr
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	c.logResponse(resp, data)
+
+	return data, resp.Header, nil
+}
+
+// logResponse logs a response with secrets redacted when debugging is on
+func (c *Client) logResponse(resp *http.Response, body []byte) {
+	if !c.debug {
+		return
+	}
+	log.Printf("API response: %s %s status=%d headers=%v body=%s",
+		resp.Request.Method, resp.Request.URL, resp.StatusCode, redactHeaders(resp.Header), RedactSecrets(body))
+}
+
+// decode stores a successful response body in out. An unsuccessful
+// StandardResponse is an error even if out is nil.
+func (c *Client) decode(data []byte, contentType string, out any) error {
+	if !isJSONContent(contentType, data) {
+		if out == nil {
+			return nil
+		}
+		if text, ok := out.(*string); ok {
+			*text = string(data)
+			return nil
+		}
+		return fmt.Errorf("unexpected response content type %q", contentType)
+	}
+
+	if response, ok := asStandardResponse(data); ok {
+		return c.unwrapStandardResponse(response, out)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// unwrapStandardResponse records response metadata, converts an
+// unsuccessful response into an error and decodes the data into out
+func (c *Client) unwrapStandardResponse(response *StandardResponse, out any) error {
+	c.recordMetadata(response.Metadata)
+
+	if !response.Success {
+		if response.Error != nil {
+			return fmt.Errorf("API error: %s - %s", response.Error.Code, response.Error.Message)
+		}
+		return fmt.Errorf("API request failed")
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	dataBytes, err := json.Marshal(response.Data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response data: %w", err)
+	}
+
+	if err := json.Unmarshal(dataBytes, out); err != nil {
+		return fmt.Errorf("failed to unmarshal response data: %w", err)
+	}
+
+	return nil
+}
+
+// asStandardResponse reports whether data is a StandardResponse envelope,
+// i.e. an object with a boolean "success" field and data, error or metadata
+func asStandardResponse(data []byte) (*StandardResponse, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, false
+	}
+
+	success, ok := fields["success"]
+	if !ok {
+		return nil, false
+	}
+	if s := string(bytes.TrimSpace(success)); s != "true" && s != "false" {
+		return nil, false
+	}
+
+	_, hasData := fields["data"]
+	_, hasError := fields["error"]
+	_, hasMetadata := fields["metadata"]
+	if !hasData && !hasError && !hasMetadata {
+		return nil, false
+	}
+
+	var response StandardResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, false
+	}
+	return &response, true
+}
+
+// isJSONContent reports whether a response should be decoded as JSON. A
+// missing content type falls back to sniffing the body.
+func isJSONContent(contentType string, data []byte) bool {
+	if contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err == nil {
+			return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+		}
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+}
+
+// newStatusError builds a *StatusError from a non-200 response
+func newStatusError(resp *http.Response) *StatusError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+	statusErr := &StatusError{StatusCode: resp.StatusCode, Body: body}
+
+	var response StandardResponse
+	if json.Unmarshal(body, &response) == nil && response.Error != nil {
+		statusErr.Info = response.Error
+	}
+
+	return statusErr
+}
+
+// isIdempotent reports whether a request can safely be sent more than once
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// isRetryable reports whether a failed request is worth retrying
+func isRetryable(err error) bool {
+	return IsTransientError(err)
+}
+
+// IsTransientError reports whether err looks like a temporary backend
+// outage: transport errors (refused, reset, timeouts) and gateway or
+// service-unavailable responses usually clear up quickly, e.g. while the
+// backend reloads right after a start
+func IsTransientError(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
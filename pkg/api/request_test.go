@@ -0,0 +1,273 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/httpx"
+)
+
+// fakeTransport answers requests with the queued responses in order,
+// repeating the last one, and records the requests it saw
+type fakeTransport struct {
+	responses []fakeResponse
+	requests  []*http.Request
+}
+
+// fakeResponse is a canned response, or a transport error if err is set
+type fakeResponse struct {
+	status      int
+	contentType string
+	body        string
+	err         error
+}
+
+func (f *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.requests = append(f.requests, req)
+	response := f.responses[min(len(f.requests), len(f.responses))-1]
+	if response.err != nil {
+		return nil, response.err
+	}
+
+	header := http.Header{}
+	if response.contentType != "" {
+		header.Set("Content-Type", response.contentType)
+	}
+	return &http.Response{
+		StatusCode: response.status,
+		Status:     http.StatusText(response.status),
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(response.body)),
+		Request:    req,
+	}, nil
+}
+
+// newFakeClient returns a client that sends its requests to transport and
+// retries without delay
+func newFakeClient(transport *fakeTransport) *Client {
+	client := NewClientWithHTTPClient("http://backend:8080", &http.Client{Transport: transport})
+	client.authToken = ""
+	client.debug = false
+	client.retryDelay = time.Millisecond
+	return client
+}
+
+func jsonResponse(status int, body string) fakeResponse {
+	return fakeResponse{status: status, contentType: "application/json", body: body}
+}
+
+func TestCallUnwrapsStandardResponse(t *testing.T) {
+	transport := &fakeTransport{responses: []fakeResponse{
+		jsonResponse(http.StatusOK, `{"success": true, "data": {"name": "web"}, "metadata": {"server_version": "2.1.0"}}`),
+	}}
+	client := newFakeClient(transport)
+
+	var out struct {
+		Name string `json:"name"`
+	}
+	if err := client.call(context.Background(), http.MethodGet, "/api/v1/thing", nil, &out); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if out.Name != "web" {
+		t.Errorf("name = %q, want web", out.Name)
+	}
+	if got := client.ServerVersion(); got != "2.1.0" {
+		t.Errorf("ServerVersion() = %q, want 2.1.0 from the metadata", got)
+	}
+}
+
+func TestCallReportsUnsuccessfulEnvelope(t *testing.T) {
+	transport := &fakeTransport{responses: []fakeResponse{
+		jsonResponse(http.StatusOK, `{"success": false, "error": {"code": "E_BUSY", "message": "busy"}}`),
+	}}
+	client := newFakeClient(transport)
+
+	err := client.call(context.Background(), http.MethodGet, "/api/v1/thing", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "E_BUSY") {
+		t.Errorf("call error = %v, want the envelope's error code", err)
+	}
+}
+
+func TestCallStoresPlainText(t *testing.T) {
+	transport := &fakeTransport{responses: []fakeResponse{
+		{status: http.StatusOK, contentType: "text/plain", body: "log line"},
+	}}
+	client := newFakeClient(transport)
+
+	var out string
+	if err := client.call(context.Background(), http.MethodGet, "/api/logs", nil, &out); err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if out != "log line" {
+		t.Errorf("out = %q, want %q", out, "log line")
+	}
+}
+
+func TestCallReturnsStatusError(t *testing.T) {
+	transport := &fakeTransport{responses: []fakeResponse{
+		jsonResponse(http.StatusNotFound, `{"success": false, "error": {"code": "NOT_FOUND", "message": "no such service"}}`),
+	}}
+	client := newFakeClient(transport)
+
+	err := client.call(context.Background(), http.MethodGet, "/api/v1/services/x", nil, nil)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("call error = %v, want a *StatusError", err)
+	}
+	if statusErr.StatusCode != http.StatusNotFound || statusErr.Info == nil || statusErr.Info.Code != "NOT_FOUND" {
+		t.Errorf("status error = %+v, want 404 with code NOT_FOUND", statusErr)
+	}
+	if len(transport.requests) != 1 {
+		t.Errorf("sent %d requests, want 1 as a 404 is not retried", len(transport.requests))
+	}
+}
+
+func TestCallRetriesTransientStatuses(t *testing.T) {
+	for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		transport := &fakeTransport{responses: []fakeResponse{
+			{status: status},
+			jsonResponse(http.StatusOK, `{"ok": true}`),
+		}}
+		client := newFakeClient(transport)
+
+		if err := client.call(context.Background(), http.MethodGet, "/api/v1/status", nil, nil); err != nil {
+			t.Errorf("GET after a %d failed: %v", status, err)
+		}
+		if len(transport.requests) != 2 {
+			t.Errorf("GET after a %d sent %d requests, want 2", status, len(transport.requests))
+		}
+	}
+}
+
+func TestCallGivesUpAfterRetries(t *testing.T) {
+	transport := &fakeTransport{responses: []fakeResponse{{status: http.StatusServiceUnavailable}}}
+	client := newFakeClient(transport)
+
+	err := client.call(context.Background(), http.MethodGet, "/api/v1/status", nil, nil)
+	if !IsTransientError(err) {
+		t.Errorf("call error = %v, want the 503", err)
+	}
+	if want := 1 + defaultMaxRetries; len(transport.requests) != want {
+		t.Errorf("sent %d requests, want %d", len(transport.requests), want)
+	}
+}
+
+func TestCallDoesNotRetryPost(t *testing.T) {
+	transport := &fakeTransport{responses: []fakeResponse{
+		{status: http.StatusServiceUnavailable},
+		jsonResponse(http.StatusOK, `{"ok": true}`),
+	}}
+	client := newFakeClient(transport)
+
+	err := client.call(context.Background(), http.MethodPost, "/api/v1/lifecycle/start", nil, nil)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("POST error = %v, want the 503", err)
+	}
+	if len(transport.requests) != 1 {
+		t.Errorf("POST sent %d requests, want 1", len(transport.requests))
+	}
+}
+
+func TestBreakerOpensAfterRepeatedFailures(t *testing.T) {
+	transport := &fakeTransport{responses: []fakeResponse{{status: http.StatusBadGateway}}}
+	client := newFakeClient(transport)
+	client.maxRetries = 0
+
+	for i := 0; i < defaultBreakerThreshold; i++ {
+		if got := client.Circuit().State; got != CircuitClosed {
+			t.Fatalf("circuit %s after %d failures, want closed", got, i)
+		}
+		if err := client.call(context.Background(), http.MethodGet, "/api/v1/status", nil, nil); err == nil {
+			t.Fatal("call succeeded, want a 502")
+		}
+	}
+	if got := client.Circuit().State; got != CircuitOpen {
+		t.Fatalf("circuit %s after %d failures, want open", got, defaultBreakerThreshold)
+	}
+
+	err := client.call(context.Background(), http.MethodGet, "/api/v1/status", nil, nil)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("call with an open circuit = %v, want ErrCircuitOpen", err)
+	}
+	if len(transport.requests) != defaultBreakerThreshold {
+		t.Errorf("sent %d requests, want %d as the open circuit sends nothing", len(transport.requests), defaultBreakerThreshold)
+	}
+}
+
+func TestHealthCheckBypassesBreaker(t *testing.T) {
+	transport := &fakeTransport{responses: []fakeResponse{{status: http.StatusServiceUnavailable}}}
+	client := newFakeClient(transport)
+	client.maxRetries = 0
+
+	for i := 0; i < defaultBreakerThreshold; i++ {
+		_ = client.call(context.Background(), http.MethodGet, "/api/v1/status", nil, nil)
+	}
+	if got := client.Circuit().State; got != CircuitOpen {
+		t.Fatalf("circuit %s, want open", got)
+	}
+
+	sent := len(transport.requests)
+	if err := client.HealthCheck(context.Background()); err == nil {
+		t.Fatal("HealthCheck succeeded, want a 503")
+	}
+	if len(transport.requests) == sent {
+		t.Fatal("HealthCheck sent nothing with an open circuit")
+	}
+	if got := client.Circuit().Failures; got != defaultBreakerThreshold {
+		t.Errorf("failures = %d after a failed health check, want %d", got, defaultBreakerThreshold)
+	}
+
+	transport.responses = []fakeResponse{jsonResponse(http.StatusOK, `{}`)}
+	if err := client.HealthCheck(context.Background()); err != nil {
+		t.Fatalf("HealthCheck failed: %v", err)
+	}
+	if got := client.Circuit().State; got != CircuitClosed {
+		t.Errorf("circuit %s after a healthy check, want closed", got)
+	}
+}
+
+func TestBreakerIgnoresClientErrors(t *testing.T) {
+	transport := &fakeTransport{responses: []fakeResponse{jsonResponse(http.StatusBadRequest, `{}`)}}
+	client := newFakeClient(transport)
+
+	for i := 0; i < 2*defaultBreakerThreshold; i++ {
+		_ = client.call(context.Background(), http.MethodGet, "/api/v1/status", nil, nil)
+	}
+	if got := client.Circuit().State; got != CircuitClosed {
+		t.Errorf("circuit %s after repeated 400s, want closed", got)
+	}
+}
+
+func TestProbeConnectTimeoutOnlyForProbes(t *testing.T) {
+	transport := &fakeTransport{responses: []fakeResponse{jsonResponse(http.StatusOK, `{}`)}}
+	client := newFakeClient(transport)
+	client.SetProbeConnectTimeout(time.Second)
+
+	ctx := context.Background()
+	if _, err := client.GetStatus(ctx); err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if _, err := client.Ping(ctx); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	probes := len(transport.requests)
+	if err := client.StartStack(ctx); err != nil {
+		t.Fatalf("StartStack failed: %v", err)
+	}
+
+	for i, req := range transport.requests {
+		timeout, ok := httpx.ConnectTimeout(req.Context())
+		if i < probes && (!ok || timeout != time.Second) {
+			t.Errorf("%s %s has connect timeout %s, want 1s", req.Method, req.URL.Path, timeout)
+		}
+		if i >= probes && ok {
+			t.Errorf("%s %s has connect timeout %s, want the transport's", req.Method, req.URL.Path, timeout)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Package audit keeps a log of the lifecycle operations the launcher ran,
+// so diagnostics can show what happened before a problem
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/config"
+)
+
+// fileName is the audit log in the launcher's cache directory
+const fileName = "audit.log"
+
+// maxSize is the size after which the log is rotated to fileName.1
+const maxSize = 256 * 1024
+
+// Entry is an operation recorded in the audit log
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	Error     string    `json:"error,omitempty"` // Empty if the operation succeeded
+}
+
+// DefaultPath returns the audit log in the launcher's cache directory
+func DefaultPath() (string, error) {
+	dir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fileName), nil
+}
+
+// Record appends the outcome of operation to the default audit log
+func Record(operation string, err error) error {
+	path, pathErr := DefaultPath()
+	if pathErr != nil {
+		return pathErr
+	}
+
+	entry := Entry{Time: time.Now(), Operation: operation}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	return Append(path, entry)
+}
+
+// Append adds entry to the audit log at path, one JSON object per line.
+// A log that grew past maxSize is kept as path.1 and a new one started.
+// Errors may quote secrets, so the log is readable by its owner only.
+func Append(path string, entry Entry) error {
+	if info, err := os.Stat(path); err == nil && info.Size() > maxSize {
+		if err := os.Rename(path, path+".1"); err != nil {
+			return fmt.Errorf("failed to rotate audit log: %w", err)
+		}
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return file.Close()
+}
+
+// Tail returns the raw last n lines of the audit log at path, oldest
+// first. A missing log has no lines.
+func Tail(path string, n int) ([][]byte, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		lines = append(lines, append([]byte{}, scanner.Bytes()...))
+		if len(lines) > n {
+			lines = lines[1:]
+		}
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,42 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTailReturnsLastEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), fileName)
+	for i := 0; i < 5; i++ {
+		entry := Entry{Time: time.Unix(int64(i), 0), Operation: fmt.Sprintf("op%d", i)}
+		if err := Append(path, entry); err != nil {
+			t.Fatalf("Append failed: %v", err)
+		}
+	}
+
+	lines, err := Tail(path, 3)
+	if err != nil {
+		t.Fatalf("Tail failed: %v", err)
+	}
+	var operations []string
+	for _, line := range lines {
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			t.Fatalf("entry %q: %v", line, err)
+		}
+		operations = append(operations, entry.Operation)
+	}
+	if fmt.Sprint(operations) != "[op2 op3 op4]" {
+		t.Errorf("Tail = %v, want the last three entries oldest first", operations)
+	}
+}
+
+func TestTailMissingLog(t *testing.T) {
+	lines, err := Tail(filepath.Join(t.TempDir(), fileName), 3)
+	if err != nil || len(lines) != 0 {
+		t.Errorf("Tail of a missing log = %q, %v; want no lines", lines, err)
+	}
+}
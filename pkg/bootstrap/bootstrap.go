@@ -2,12 +2,24 @@ package bootstrap
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"time"
+
+	"github.com/ddalab/launcher/pkg/compose"
+)
+
+// Causes reported by CheckDockerExtension, from most to least fundamental
+var (
+	ErrDockerNotInstalled   = errors.New("docker is not installed")
+	ErrDockerNotRunning     = errors.New("docker daemon not accessible")
+	ErrDockerDesktopMissing = errors.New("Docker Desktop is required but not found")
+	ErrExtensionMissing     = errors.New("DDALAB Docker extension not found")
 )
 
 // Bootstrap provides minimal functionality to start the Docker extension backend
@@ -16,6 +28,7 @@ import (
 type Bootstrap struct {
 	extensionPath string
 	isAvailable   bool
+	issue         error // Why the extension is unavailable, from the last check
 }
 
 // NewBootstrap creates a new bootstrap instance
@@ -23,22 +36,29 @@ func NewBootstrap() *Bootstrap {
 	return &Bootstrap{}
 }
 
-// CheckDockerExtension checks if Docker Desktop and the DDALAB extension are available
+// CheckDockerExtension checks if Docker Desktop and the DDALAB extension
+// are available. The error wraps one of ErrDockerNotInstalled,
+// ErrDockerNotRunning, ErrDockerDesktopMissing or ErrExtensionMissing.
 func (b *Bootstrap) CheckDockerExtension() error {
-	// First, check if Docker is running
+	b.issue = b.checkDockerExtension()
+	return b.issue
+}
+
+func (b *Bootstrap) checkDockerExtension() error {
+	// First, check if Docker is installed and running
 	if err := b.checkDockerRunning(); err != nil {
-		return fmt.Errorf("Docker is not running: %w", err)
+		return err
 	}
 
 	// Check if Docker Desktop is installed (not just Docker Engine)
 	if !b.isDockerDesktop() {
-		return fmt.Errorf("Docker Desktop is required but not found")
+		return ErrDockerDesktopMissing
 	}
 
 	// Try to find the DDALAB extension
 	extensionPath, err := b.findExtension()
 	if err != nil {
-		return fmt.Errorf("DDALAB Docker extension not found: %w", err)
+		return fmt.Errorf("%w: %w", ErrExtensionMissing, err)
 	}
 
 	b.extensionPath = extensionPath
@@ -46,8 +66,45 @@ func (b *Bootstrap) CheckDockerExtension() error {
 	return nil
 }
 
-// checkDockerRunning verifies Docker daemon is accessible
+// Issue returns why the extension was unavailable at the last
+// CheckDockerExtension, or nil
+func (b *Bootstrap) Issue() error {
+	return b.issue
+}
+
+// CheckDocker returns ErrDockerNotInstalled or ErrDockerNotRunning if
+// Docker cannot be used
+func (b *Bootstrap) CheckDocker() error {
+	return b.checkDockerRunning()
+}
+
+// Remediation returns what the user should do about a CheckDockerExtension
+// error, or "" for other errors
+func (b *Bootstrap) Remediation(err error) string {
+	switch {
+	case errors.Is(err, ErrDockerNotInstalled):
+		return "Install Docker Desktop from https://www.docker.com/products/docker-desktop/ (or Docker Engine on Linux), then restart the launcher"
+	case errors.Is(err, ErrDockerNotRunning):
+		if runtime.GOOS == "linux" && !b.isDockerDesktop() {
+			return "Start the Docker daemon, e.g. with 'sudo systemctl start docker'"
+		}
+		return "Start Docker Desktop and wait until it reports that Docker is running"
+	case errors.Is(err, ErrDockerDesktopMissing):
+		return "Install Docker Desktop to use the DDALAB extension; without it DDALAB is bootstrapped with Docker Engine"
+	case errors.Is(err, ErrExtensionMissing):
+		return "Install the DDALAB extension from the Extensions Marketplace in Docker Desktop"
+	default:
+		return ""
+	}
+}
+
+// checkDockerRunning verifies Docker is installed and its daemon is
+// accessible
 func (b *Bootstrap) checkDockerRunning() error {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return ErrDockerNotInstalled
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -56,7 +113,7 @@ func (b *Bootstrap) checkDockerRunning() error {
 	cmd.Stderr = nil
 
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("docker daemon not accessible")
+		return ErrDockerNotRunning
 	}
 
 	return nil
@@ -165,22 +222,22 @@ func (b *Bootstrap) isBackendRunning() bool {
 	return string(output) == "200"
 }
 
-// StartMinimalServices starts only the essential DDALAB services locally
-// This is used when the Docker extension is not available
-func (b *Bootstrap) StartMinimalServices(ctx context.Context, ddalabPath string) error {
-	// Check if docker-compose.yml exists
-	composeFile := filepath.Join(ddalabPath, "docker-compose.yml")
+// StartMinimalServices starts only the essential DDALAB services of the
+// compose project locally, with its project name and env files. This is
+// used when the Docker extension is not available.
+func (b *Bootstrap) StartMinimalServices(ctx context.Context, project compose.Project) error {
+	composeFile := filepath.Join(project.Dir, compose.File)
 	if _, err := os.Stat(composeFile); os.IsNotExist(err) {
-		return fmt.Errorf("docker-compose.yml not found in %s", ddalabPath)
+		return fmt.Errorf("%s not found in %s", compose.File, project.Dir)
 	}
 
-	// Start only core services (postgres, redis, api)
-	cmd := exec.CommandContext(ctx, "docker-compose",
-		"-f", composeFile,
-		"up", "-d",
-		"postgres", "redis", "ddalab")
+	words, err := minimalServicesCommand(compose.DetectCommand(ctx), project)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, words[0], words[1:]...)
 
-	cmd.Dir = ddalabPath
+	cmd.Dir = project.Dir
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -191,6 +248,42 @@ func (b *Bootstrap) StartMinimalServices(ctx context.Context, ddalabPath string)
 	return nil
 }
 
+// minimalServicesCommand returns the command words that start the core
+// services of the project with the compose command base
+func minimalServicesCommand(base []string, project compose.Project) ([]string, error) {
+	invocations, err := compose.Invocations(base, project, "start")
+	if err != nil {
+		return nil, err
+	}
+	words := invocations[0]
+	return append(words, coreServices(filepath.Join(project.Dir, compose.File))...), nil
+}
+
+// minimalServices are the services a bootstrap starts: database, cache and API
+var minimalServices = []string{"postgres", "redis", "ddalab"}
+
+// coreServices returns the minimal services defined in the compose file.
+// If the file defines none of them under the usual names, nil is returned
+// so that compose starts every service rather than failing on unknown ones.
+func coreServices(composeFile string) []string {
+	content, err := os.ReadFile(composeFile)
+	if err != nil {
+		return minimalServices
+	}
+	defined, err := compose.ServiceNames(content)
+	if err != nil {
+		return minimalServices // Let compose report the broken file
+	}
+
+	var services []string
+	for _, service := range minimalServices {
+		if slices.Contains(defined, service) {
+			services = append(services, service)
+		}
+	}
+	return services
+}
+
 // GetBootstrapMode returns the current bootstrap capability
 func (b *Bootstrap) GetBootstrapMode() string {
 	if b.isAvailable {
@@ -199,10 +292,14 @@ func (b *Bootstrap) GetBootstrapMode() string {
 	if b.isDockerDesktop() {
 		return "Docker Desktop (No Extension)"
 	}
-	if b.checkDockerRunning() == nil {
+	switch err := b.checkDockerRunning(); {
+	case err == nil:
 		return "Docker Engine Only"
+	case errors.Is(err, ErrDockerNotInstalled):
+		return "Docker Not Installed"
+	default:
+		return "Docker Not Running"
 	}
-	return "No Docker"
 }
 
 // CanBootstrap returns true if some form of bootstrap is possible
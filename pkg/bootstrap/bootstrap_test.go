@@ -0,0 +1,36 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ddalab/launcher/pkg/compose"
+)
+
+func TestMinimalServicesCommandUsesProject(t *testing.T) {
+	dir := t.TempDir()
+	content := "services:\n  postgres: {}\n  ddalab: {}\n  web: {}\n"
+	if err := os.WriteFile(filepath.Join(dir, compose.File), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	project := compose.Project{
+		Dir:      dir,
+		Name:     "lab",
+		EnvFiles: []string{filepath.Join(dir, ".env"), filepath.Join(dir, ".env.local")},
+	}
+
+	words, err := minimalServicesCommand([]string{"docker", "compose"}, project)
+	if err != nil {
+		t.Fatalf("minimalServicesCommand failed: %v", err)
+	}
+	want := []string{
+		"docker", "compose", "-f", filepath.Join(dir, compose.File), "-p", "lab",
+		"--env-file", filepath.Join(dir, ".env"), "--env-file", filepath.Join(dir, ".env.local"),
+		"up", "-d", "postgres", "ddalab",
+	}
+	if !slices.Equal(words, want) {
+		t.Errorf("command = %q, want %q", words, want)
+	}
+}
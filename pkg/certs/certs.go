@@ -0,0 +1,210 @@
+// Package certs regenerates the TLS certificates of a DDALAB installation,
+// either with the installation's own script or with a built-in self-signed
+// generator.
+package certs
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Dir is the certificate directory inside an installation
+const Dir = "certs"
+
+// File names written by the built-in generator
+const (
+	CertFile = "server.crt"
+	KeyFile  = "server.key"
+)
+
+// validity is how long a generated certificate is valid
+const validity = 365 * 24 * time.Hour
+
+// scriptCandidates are the certificate scripts an installation may ship,
+// relative to its root, in order of preference
+var scriptCandidates = []string{
+	"scripts/generate-certs.sh",
+	"scripts/generate-certificates.sh",
+	"certs/generate.sh",
+}
+
+// Result describes a regeneration
+type Result struct {
+	Script   string    // Installation script that was run, empty for the built-in generator
+	CertPath string    // Generated certificate (built-in generator only)
+	KeyPath  string    // Generated private key (built-in generator only)
+	Backups  []string  // Previous files moved aside
+	NotAfter time.Time // Expiry of the generated certificate
+}
+
+// FindScript returns the installation's certificate script, or "" if it has
+// none. Scripts are shell scripts and are not used on Windows.
+func FindScript(installPath string) string {
+	if runtime.GOOS == "windows" {
+		return ""
+	}
+	for _, candidate := range scriptCandidates {
+		path := filepath.Join(installPath, filepath.FromSlash(candidate))
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return path
+		}
+	}
+	return ""
+}
+
+// Regenerate recreates the certificates of the installation at installPath.
+// The installation's script is preferred; without one a self-signed
+// certificate for domain is generated into certs/, and existing files are
+// backed up first. Each line of script output is passed to output, if set.
+func Regenerate(ctx context.Context, installPath, domain string, output func(line string)) (*Result, error) {
+	if script := FindScript(installPath); script != "" {
+		if err := runScript(ctx, installPath, script, domain, output); err != nil {
+			return nil, err
+		}
+		return &Result{Script: script}, nil
+	}
+
+	return Generate(filepath.Join(installPath, Dir), domain)
+}
+
+// runScript runs a certificate script from the installation root
+func runScript(ctx context.Context, installPath, script, domain string, output func(string)) error {
+	cmd := exec.CommandContext(ctx, "sh", script)
+	cmd.Dir = installPath
+	cmd.Env = append(os.Environ(), "DOMAIN="+domain)
+
+	out, err := cmd.CombinedOutput()
+	if output != nil {
+		for _, line := range strings.Split(strings.TrimRight(string(out), "\r\n"), "\n") {
+			if line != "" {
+				output(strings.TrimRight(line, "\r"))
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("certificate script %s failed: %w", filepath.Base(script), err)
+	}
+	return nil
+}
+
+// Generate writes a self-signed certificate and private key for domain into
+// dir, moving existing files aside. localhost and the loopback addresses are
+// always included as alternative names.
+func Generate(dir, domain string) (*Result, error) {
+	if domain == "" {
+		domain = "localhost"
+	}
+
+	certPEM, keyPEM, notAfter, err := selfSigned(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	result := &Result{
+		CertPath: filepath.Join(dir, CertFile),
+		KeyPath:  filepath.Join(dir, KeyFile),
+		NotAfter: notAfter,
+	}
+
+	suffix := ".bak-" + time.Now().Format("20060102-150405")
+	for _, path := range []string{result.CertPath, result.KeyPath} {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if err := os.Rename(path, path+suffix); err != nil {
+			return nil, fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+		result.Backups = append(result.Backups, path+suffix)
+	}
+
+	if err := os.WriteFile(result.KeyPath, keyPEM, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write private key: %w", err)
+	}
+	if err := os.WriteFile(result.CertPath, certPEM, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write certificate: %w", err)
+	}
+
+	return result, nil
+}
+
+// selfSigned creates a PEM-encoded self-signed certificate and key
+func selfSigned(domain string) (certPEM, keyPEM []byte, notAfter time.Time, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, time.Time{}, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, time.Time{}, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: domain, Organization: []string{"DDALAB (self-signed)"}},
+		NotBefore:             now.Add(-time.Hour), // Tolerate clock skew
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	addNames(template, domain, "localhost", "127.0.0.1", "::1")
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, time.Time{}, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, time.Time{}, fmt.Errorf("failed to encode key: %w", err)
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	// Make sure servers will accept the pair before anything is replaced
+	if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		return nil, nil, time.Time{}, fmt.Errorf("generated certificate is invalid: %w", err)
+	}
+
+	return certPEM, keyPEM, template.NotAfter, nil
+}
+
+// addNames adds each name to the certificate's alternative names, as an IP
+// address or a DNS name, skipping duplicates
+func addNames(cert *x509.Certificate, names ...string) {
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		if ip := net.ParseIP(name); ip != nil {
+			cert.IPAddresses = append(cert.IPAddresses, ip)
+		} else {
+			cert.DNSNames = append(cert.DNSNames, name)
+		}
+	}
+}
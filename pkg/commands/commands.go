@@ -2,10 +2,15 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/audit"
 	"github.com/ddalab/launcher/pkg/config"
+	"github.com/ddalab/launcher/pkg/oplock"
+	"github.com/ddalab/launcher/pkg/theme"
 )
 
 // Commander handles DDALAB operations via API
@@ -29,41 +34,61 @@ func (c *Commander) Start() error {
 
 // StartWithContext starts the DDALAB services with cancellation support via API
 func (c *Commander) StartWithContext(ctx context.Context) error {
-	err := c.apiClient.StartStack(ctx)
+	ctx, release, err := oplock.Acquire(ctx, "start")
 	if err != nil {
-		return fmt.Errorf("failed to start DDALAB: %w", err)
+		return err
 	}
+	defer release()
 
-	c.configManager.SetLastOperation("start")
-	_ = c.configManager.Save()
+	err = c.apiClient.StartStack(ctx)
+	c.recordOperation("start", err)
+	if err != nil {
+		return fmt.Errorf("failed to start DDALAB: %w", err)
+	}
 
 	return nil
 }
 
 // Stop stops the DDALAB services via API
 func (c *Commander) Stop() error {
-	ctx := context.Background()
-	err := c.apiClient.StopStack(ctx)
+	return c.StopWithContext(context.Background())
+}
+
+// StopWithContext stops the DDALAB services with cancellation support via API
+func (c *Commander) StopWithContext(ctx context.Context) error {
+	ctx, release, err := oplock.Acquire(ctx, "stop")
 	if err != nil {
-		return fmt.Errorf("failed to stop DDALAB: %w", err)
+		return err
 	}
+	defer release()
 
-	c.configManager.SetLastOperation("stop")
-	_ = c.configManager.Save()
+	err = c.apiClient.StopStack(ctx)
+	c.recordOperation("stop", err)
+	if err != nil {
+		return fmt.Errorf("failed to stop DDALAB: %w", err)
+	}
 
 	return nil
 }
 
 // Restart restarts the DDALAB services via API
 func (c *Commander) Restart() error {
-	ctx := context.Background()
-	err := c.apiClient.RestartStack(ctx)
+	return c.RestartWithContext(context.Background())
+}
+
+// RestartWithContext restarts the DDALAB services with cancellation support via API
+func (c *Commander) RestartWithContext(ctx context.Context) error {
+	ctx, release, err := oplock.Acquire(ctx, "restart")
 	if err != nil {
-		return fmt.Errorf("failed to restart DDALAB: %w", err)
+		return err
 	}
+	defer release()
 
-	c.configManager.SetLastOperation("restart")
-	_ = c.configManager.Save()
+	err = c.apiClient.RestartStack(ctx)
+	c.recordOperation("restart", err)
+	if err != nil {
+		return fmt.Errorf("failed to restart DDALAB: %w", err)
+	}
 
 	return nil
 }
@@ -108,17 +133,25 @@ func (c *Commander) LogsWithContext(ctx context.Context) (string, error) {
 
 // Backup creates a database backup via API
 func (c *Commander) Backup() error {
-	ctx := context.Background()
+	return c.BackupWithContext(context.Background())
+}
+
+// BackupWithContext creates a database backup with cancellation support via API
+func (c *Commander) BackupWithContext(ctx context.Context) error {
+	ctx, release, err := oplock.Acquire(ctx, "backup")
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	filename, err := c.apiClient.CreateBackup(ctx)
+	c.recordOperation("backup", err)
 	if err != nil {
 		return fmt.Errorf("failed to backup DDALAB: %w", err)
 	}
 
 	fmt.Printf("Backup created: %s\n", filename)
 
-	c.configManager.SetLastOperation("backup")
-	_ = c.configManager.Save()
-
 	return nil
 }
 
@@ -129,24 +162,33 @@ func (c *Commander) Update() error {
 
 // UpdateWithContext updates DDALAB to the latest version with cancellation support via API
 func (c *Commander) UpdateWithContext(ctx context.Context) error {
-	err := c.apiClient.UpdateStack(ctx)
+	ctx, release, err := oplock.Acquire(ctx, "update")
 	if err != nil {
-		return fmt.Errorf("failed to update DDALAB: %w", err)
+		return err
 	}
+	defer release()
 
-	c.configManager.SetLastOperation("update")
-	_ = c.configManager.Save()
+	err = c.apiClient.UpdateStack(ctx)
+	c.recordOperation("update", err)
+	if err != nil {
+		return fmt.Errorf("failed to update DDALAB: %w", err)
+	}
 
 	return nil
 }
 
 // Uninstall removes DDALAB (stops services and removes volumes) via API
 func (c *Commander) Uninstall() error {
-	ctx := context.Background()
+	ctx, release, err := oplock.Acquire(context.Background(), "uninstall")
+	if err != nil {
+		return err
+	}
+	defer release()
 
 	// Stop services first
-	err := c.apiClient.StopStack(ctx)
+	err = c.apiClient.StopStack(ctx)
 	if err != nil {
+		c.recordOperation("uninstall", err)
 		return fmt.Errorf("failed to stop DDALAB services: %w", err)
 	}
 
@@ -154,8 +196,7 @@ func (c *Commander) Uninstall() error {
 	// For now, we just stop the services
 	fmt.Println("DDALAB services stopped. Complete uninstall functionality requires backend implementation.")
 
-	c.configManager.SetLastOperation("uninstall")
-	_ = c.configManager.Save()
+	c.recordOperation("uninstall", nil)
 
 	return nil
 }
@@ -194,3 +235,44 @@ func (c *Commander) GetServiceHealth() (map[string]string, error) {
 
 	return services, nil
 }
+
+// recordOperation persists the outcome of a completed operation and adds it
+// to the audit log. Cancelled operations are not recorded.
+func (c *Commander) recordOperation(operation string, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	c.configManager.SetLastOperation(operation, err)
+	_ = c.configManager.Save()
+	_ = audit.Record(operation, err)
+}
+
+// FormatStatus formats status information from the API for display
+func FormatStatus(status *api.Status) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("DDALAB Status: %s\n", getStatusText(status.Running)))
+	b.WriteString(fmt.Sprintf("Version: %s\n", status.Installation.Version))
+	b.WriteString(fmt.Sprintf("Path: %s\n", status.Installation.Path))
+	b.WriteString("\nServices:\n")
+
+	for _, service := range status.Services {
+		statusIcon := theme.Error
+		if service.Status == "running" {
+			statusIcon = theme.Success
+		} else if service.Status == "starting" {
+			statusIcon = theme.Progress
+		}
+		b.WriteString(fmt.Sprintf("  %s %s: %s\n", statusIcon, service.Name, service.Status))
+	}
+
+	return b.String()
+}
+
+// getStatusText converts boolean status to readable text
+func getStatusText(running bool) string {
+	if running {
+		return "Running " + theme.Success.String()
+	}
+	return "Stopped " + theme.Error.String()
+}
@@ -0,0 +1,183 @@
+// Package compose builds the docker compose invocations that correspond to
+// launcher operations, for users who want to run them by hand
+package compose
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File is the compose file of a DDALAB deployment
+const File = "docker-compose.yml"
+
+// detectTimeout bounds the check for the compose v2 plugin
+const detectTimeout = 5 * time.Second
+
+// Operations lists the operations a command can be built for, in menu order
+var Operations = []string{"start", "stop", "deep-stop", "restart", "update", "logs", "status"}
+
+// operationArgs are the compose arguments of each operation. Some
+// operations need more than one invocation.
+var operationArgs = map[string][][]string{
+	"start":     {{"up", "-d"}},
+	"stop":      {{"stop"}},
+	"deep-stop": {{"down", "--remove-orphans"}},
+	"restart":   {{"restart"}},
+	"update":    {{"pull"}, {"up", "-d"}},
+	"logs":      {{"logs", "-f", "--tail", "100"}},
+	"status":    {{"ps"}},
+}
+
+// Project is a compose deployment
+type Project struct {
+	Dir      string   // Deployment directory
+	Name     string   // Compose project name
+	EnvFiles []string // Passed with --env-file, later files win; empty for compose's default .env
+}
+
+// NewProject returns the project in dir. An empty name falls back to the
+// name compose derives from the directory.
+func NewProject(dir, name string) Project {
+	if name == "" {
+		name = defaultProjectName(dir)
+	}
+	return Project{Dir: dir, Name: name}
+}
+
+var invalidProjectChars = regexp.MustCompile(`[^a-z0-9_-]`)
+
+// defaultProjectName mirrors compose's default: the lowercased directory
+// name without characters that are not allowed in project names
+func defaultProjectName(dir string) string {
+	name := invalidProjectChars.ReplaceAllString(strings.ToLower(filepath.Base(dir)), "")
+	return strings.TrimLeft(name, "_-")
+}
+
+// ServiceNames returns the names of the services a compose file defines,
+// sorted
+func ServiceNames(content []byte) ([]string, error) {
+	var file struct {
+		Services map[string]any `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("invalid compose file: %w", err)
+	}
+
+	names := make([]string, 0, len(file.Services))
+	for name := range file.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// DetectCommand returns the compose command available on this machine:
+// the docker compose plugin, or the standalone docker-compose. If neither
+// can be found, the plugin is assumed.
+func DetectCommand(ctx context.Context) []string {
+	ctx, cancel := context.WithTimeout(ctx, detectTimeout)
+	defer cancel()
+
+	if exec.CommandContext(ctx, "docker", "compose", "version").Run() == nil {
+		return []string{"docker", "compose"}
+	}
+	if _, err := exec.LookPath("docker-compose"); err == nil {
+		return []string{"docker-compose"}
+	}
+	return []string{"docker", "compose"}
+}
+
+// Invocations returns the command words of each invocation that performs
+// op on the project with the compose command base
+func Invocations(base []string, project Project, op string) ([][]string, error) {
+	invocations, ok := operationArgs[op]
+	if !ok {
+		return nil, fmt.Errorf("unknown operation '%s'", op)
+	}
+
+	var result [][]string
+	for _, args := range invocations {
+		words := append([]string{}, base...)
+		words = append(words, "-f", filepath.Join(project.Dir, File), "-p", project.Name)
+		for _, envFile := range project.EnvFiles {
+			words = append(words, "--env-file", envFile)
+		}
+		result = append(result, append(words, args...))
+	}
+	return result, nil
+}
+
+// Commands returns the shell command lines that perform op on the project
+// with the compose command base
+func Commands(base []string, project Project, op string) ([]string, error) {
+	invocations, err := Invocations(base, project, op)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]string, len(invocations))
+	for i, words := range invocations {
+		quoted := make([]string, len(words))
+		for j, word := range words {
+			quoted[j] = quote(word)
+		}
+		lines[i] = strings.Join(quoted, " ")
+	}
+	return lines, nil
+}
+
+// Run performs op on the project with the compose command base, one
+// invocation after the other. A failure includes compose's last output line.
+func Run(ctx context.Context, base []string, project Project, op string) error {
+	invocations, err := Invocations(base, project, op)
+	if err != nil {
+		return err
+	}
+
+	for _, words := range invocations {
+		cmd := exec.CommandContext(ctx, words[0], words[1:]...)
+		cmd.Dir = project.Dir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if line := lastLine(output); line != "" {
+				return fmt.Errorf("%s failed: %s", strings.Join(words[:len(base)], " "), line)
+			}
+			return fmt.Errorf("%s failed: %w", strings.Join(words[:len(base)], " "), err)
+		}
+	}
+	return nil
+}
+
+// lastLine returns the last non-empty line of output
+func lastLine(output []byte) string {
+	message := strings.TrimSpace(string(output))
+	if idx := strings.LastIndex(message, "\n"); idx >= 0 {
+		message = message[idx+1:]
+	}
+	return strings.TrimSpace(message)
+}
+
+var safeShellWord = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// quote quotes word for the platform's shell if it needs quoting
+func quote(word string) string {
+	if safeShellWord.MatchString(word) {
+		return word
+	}
+	if runtime.GOOS == "windows" {
+		return `"` + strings.ReplaceAll(word, `"`, `\"`) + `"`
+	}
+	return "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// configCodec serializes the launcher configuration in a specific file format
+type configCodec struct {
+	name      string
+	marshal   func(cfg *LauncherConfig) ([]byte, error)
+	unmarshal func(data []byte, v any) error
+}
+
+var (
+	jsonCodec = configCodec{
+		name: "json",
+		marshal: func(cfg *LauncherConfig) ([]byte, error) {
+			return json.MarshalIndent(cfg, "", "  ")
+		},
+		unmarshal: func(data []byte, v any) error {
+			return json.Unmarshal(data, v)
+		},
+	}
+
+	tomlCodec = configCodec{
+		name: "toml",
+		marshal: func(cfg *LauncherConfig) ([]byte, error) {
+			var buf bytes.Buffer
+			if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+				return nil, err
+			}
+			return buf.Bytes(), nil
+		},
+		unmarshal: func(data []byte, v any) error {
+			return toml.Unmarshal(data, v)
+		},
+	}
+
+	yamlCodec = configCodec{
+		name: "yaml",
+		marshal: func(cfg *LauncherConfig) ([]byte, error) {
+			return yaml.Marshal(cfg)
+		},
+		unmarshal: func(data []byte, v any) error {
+			return yaml.Unmarshal(data, v)
+		},
+	}
+)
+
+// codecForPath picks the codec from the file extension, defaulting to JSON
+func codecForPath(path string) configCodec {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		return tomlCodec
+	case ".yaml", ".yml":
+		return yamlCodec
+	default:
+		return jsonCodec
+	}
+}
@@ -1,9 +1,15 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -32,76 +38,271 @@ const (
 	ModeAuto OperationMode = "auto"
 )
 
+// DefaultAPIEndpoint is the Docker extension API base URL used when none is configured
+const DefaultAPIEndpoint = "http://localhost:8080"
+
 // LauncherConfig holds the persistent state of the launcher
 type LauncherConfig struct {
-	DDALABPath          string        `json:"ddalab_path"`
-	FirstRun            bool          `json:"first_run"`
-	LastOperation       string        `json:"last_operation"`
-	Version             string        `json:"version"`
-	AutoUpdateCheck     bool          `json:"auto_update_check"`
-	LastUpdateCheck     time.Time     `json:"last_update_check"`
-	UpdateCheckInterval int           `json:"update_check_interval_hours"` // in hours
-	OperationMode       OperationMode `json:"operation_mode"`              // mode: api or auto (local deprecated)
-	APIEndpoint         string        `json:"api_endpoint"`                // Docker extension API endpoint
+	DDALABPath          string         `json:"ddalab_path" toml:"ddalab_path" yaml:"ddalab_path"`
+	FirstRun            bool           `json:"first_run" toml:"first_run" yaml:"first_run"`
+	LastOperation       string         `json:"last_operation" toml:"last_operation" yaml:"last_operation"`
+	LastOperationFailed bool           `json:"last_operation_failed,omitempty" toml:"last_operation_failed,omitempty" yaml:"last_operation_failed,omitempty"` // Outcome of LastOperation
+	LastOperationTime   time.Time      `json:"last_operation_time" toml:"last_operation_time" yaml:"last_operation_time"`                                     // When LastOperation completed
+	LastMenuAction      string         `json:"last_menu_action,omitempty" toml:"last_menu_action,omitempty" yaml:"last_menu_action,omitempty"`                // Preselected in the main menu
+	Version             string         `json:"version" toml:"version" yaml:"version"`
+	AutoUpdateCheck     bool           `json:"auto_update_check" toml:"auto_update_check" yaml:"auto_update_check"`
+	AutoInstallUpdates  bool           `json:"auto_install_updates" toml:"auto_install_updates" yaml:"auto_install_updates"` // Install updates found at startup after a countdown
+	LastUpdateCheck     time.Time      `json:"last_update_check" toml:"last_update_check" yaml:"last_update_check"`
+	UpdateCheckInterval int            `json:"update_check_interval_hours" toml:"update_check_interval_hours" yaml:"update_check_interval_hours"`                                  // in hours
+	UpdateCheckTimeout  int            `json:"update_check_timeout_seconds,omitempty" toml:"update_check_timeout_seconds,omitempty" yaml:"update_check_timeout_seconds,omitempty"` // Timeout of one startup update check attempt
+	UpdateCheckFailures int            `json:"update_check_failures,omitempty" toml:"update_check_failures,omitempty" yaml:"update_check_failures,omitempty"`                      // Consecutive failed startup update checks
+	UpdateChannel       string         `json:"update_channel,omitempty" toml:"update_channel,omitempty" yaml:"update_channel,omitempty"`                                           // stable or beta launcher releases
+	OperationMode       OperationMode  `json:"operation_mode" toml:"operation_mode" yaml:"operation_mode"`                                                                         // mode: api or auto (local deprecated)
+	APIEndpoint         string         `json:"api_endpoint" toml:"api_endpoint" yaml:"api_endpoint"`                                                                               // Docker extension API endpoint
+	APIToken            string         `json:"api_token,omitempty" toml:"api_token,omitempty" yaml:"api_token,omitempty"`                                                          // Bearer token for the API (DDALAB_API_TOKEN overrides it)
+	APICACert           string         `json:"api_ca_cert,omitempty" toml:"api_ca_cert,omitempty" yaml:"api_ca_cert,omitempty"`                                                    // PEM file with extra CAs trusted for an HTTPS endpoint
+	Offline             bool           `json:"offline" toml:"offline" yaml:"offline"`                                                                                              // Disable external network calls
+	BootstrapTimeout    int            `json:"bootstrap_timeout_seconds" toml:"bootstrap_timeout_seconds" yaml:"bootstrap_timeout_seconds"`                                        // How long to wait for a bootstrapped backend
+	ConnectTimeout      int            `json:"connect_timeout_ms,omitempty" toml:"connect_timeout_ms,omitempty" yaml:"connect_timeout_ms,omitempty"`                               // Connect timeout for API requests
+	StatusTimeout       int            `json:"status_timeout_seconds,omitempty" toml:"status_timeout_seconds,omitempty" yaml:"status_timeout_seconds,omitempty"`                   // Overall timeout of a status check
+	PingInterval        int            `json:"ping_interval_seconds,omitempty" toml:"ping_interval_seconds,omitempty" yaml:"ping_interval_seconds,omitempty"`                      // How often API reachability is checked
+	APIHealthPath       string         `json:"api_health_path,omitempty" toml:"api_health_path,omitempty" yaml:"api_health_path,omitempty"`                                        // Health route if not /api/test
+	APIVersionPath      string         `json:"api_version_path,omitempty" toml:"api_version_path,omitempty" yaml:"api_version_path,omitempty"`                                     // Version route if not /api/version
+	SSHHost             string         `json:"ssh_host,omitempty" toml:"ssh_host,omitempty" yaml:"ssh_host,omitempty"`                                                             // SSH target forwarding the ports of a remote DDALAB, e.g. "user@server"
+	SSHPorts            []int          `json:"ssh_ports,omitempty" toml:"ssh_ports,omitempty" yaml:"ssh_ports,omitempty"`                                                          // Ports forwarded over SSH (default: the API endpoint's port)
+	ExpectedServices    []string       `json:"expected_services,omitempty" toml:"expected_services,omitempty" yaml:"expected_services,omitempty"`                                  // Compose services a valid installation must define (default: ddalab, postgres, redis)
+	Hooks               HooksConfig    `json:"hooks" toml:"hooks" yaml:"hooks"`                                                                                                    // Lifecycle hook commands
+	WebProbe            WebProbeConfig `json:"web_probe" toml:"web_probe" yaml:"web_probe"`                                                                                        // Check that the web UI answers after a start
+	Role                Role           `json:"role,omitempty" toml:"role,omitempty" yaml:"role,omitempty"`                                                                         // admin or operator
+	Locale              string         `json:"locale,omitempty" toml:"locale,omitempty" yaml:"locale,omitempty"`                                                                   // UI language, e.g. "de" (default: from LANG)
+	Theme               string         `json:"theme,omitempty" toml:"theme,omitempty" yaml:"theme,omitempty"`                                                                      // "plain" replaces emoji with ASCII
+	Palette             string         `json:"palette,omitempty" toml:"palette,omitempty" yaml:"palette,omitempty"`                                                                // default, high-contrast or colorblind
+	Environment         string         `json:"environment,omitempty" toml:"environment,omitempty" yaml:"environment,omitempty"`                                                    // Deployment directory to operate on
+	ServerVersion       string         `json:"server_version,omitempty" toml:"server_version,omitempty" yaml:"server_version,omitempty"`                                           // Backend version seen last
+	ServerFeatures      []string       `json:"server_features,omitempty" toml:"server_features,omitempty" yaml:"server_features,omitempty"`                                        // Backend features seen last
+	Telemetry           bool           `json:"telemetry" toml:"telemetry" yaml:"telemetry"`                                                                                        // Send anonymous failure reports (opt-in)
+	TelemetryEndpoint   string         `json:"telemetry_endpoint,omitempty" toml:"telemetry_endpoint,omitempty" yaml:"telemetry_endpoint,omitempty"`                               // Where failure reports are sent
+	TelemetryAsked      bool           `json:"telemetry_asked,omitempty" toml:"telemetry_asked,omitempty" yaml:"telemetry_asked,omitempty"`                                        // The user answered the consent prompt
+}
+
+// DefaultBootstrapTimeout is how long to wait for the backend after a bootstrap
+const DefaultBootstrapTimeout = 60 * time.Second
+
+// Status check timeouts. A stopped backend refuses or drops the connection
+// quickly, so the connect timeout is short; a busy backend gets more time
+// to answer.
+const (
+	DefaultConnectTimeout = time.Second
+	DefaultStatusTimeout  = 10 * time.Second
+)
+
+// DefaultUpdateCheckTimeout bounds one attempt of the startup update check
+const DefaultUpdateCheckTimeout = 10 * time.Second
+
+// DefaultPingInterval is how often the reachability of the API is checked
+const DefaultPingInterval = 5 * time.Second
+
+// HooksConfig holds shell command templates run around lifecycle operations.
+// Templates may reference {{.Path}}, {{.URL}} and {{.Event}}.
+type HooksConfig struct {
+	PreStart       string `json:"pre_start,omitempty" toml:"pre_start,omitempty" yaml:"pre_start,omitempty"`
+	PostStart      string `json:"post_start,omitempty" toml:"post_start,omitempty" yaml:"post_start,omitempty"`
+	PreStop        string `json:"pre_stop,omitempty" toml:"pre_stop,omitempty" yaml:"pre_stop,omitempty"`
+	PostStop       string `json:"post_stop,omitempty" toml:"post_stop,omitempty" yaml:"post_stop,omitempty"`
+	AbortOnFailure bool   `json:"abort_on_failure" toml:"abort_on_failure" yaml:"abort_on_failure"` // A failing pre hook aborts the operation
+}
+
+// WebProbeConfig configures the check that the web UI actually answers
+// after a start, not only that its containers are up
+type WebProbeConfig struct {
+	Enabled        bool   `json:"enabled" toml:"enabled" yaml:"enabled"`
+	Path           string `json:"path,omitempty" toml:"path,omitempty" yaml:"path,omitempty"`                                  // Requested below the access URL (default: /)
+	ExpectedStatus []int  `json:"expected_status,omitempty" toml:"expected_status,omitempty" yaml:"expected_status,omitempty"` // Statuses that count as serving (default: 200, 302)
+	TimeoutSeconds int    `json:"timeout_seconds,omitempty" toml:"timeout_seconds,omitempty" yaml:"timeout_seconds,omitempty"` // How long to wait for the web UI
 }
 
+// Web probe defaults
+const (
+	DefaultWebProbePath    = "/"
+	DefaultWebProbeTimeout = 30 * time.Second
+)
+
+// DefaultWebProbeStatus are the statuses of a serving web UI: the page
+// itself or a redirect to the login
+var DefaultWebProbeStatus = []int{200, 302}
+
 // ConfigManager handles loading and saving configuration
 type ConfigManager struct {
-	configPath string
-	config     *LauncherConfig
+	configPath     string
+	config         *LauncherConfig
+	offlineSession bool   // Offline for this session only (--offline)
+	saved          []byte // Settings as last loaded or saved, to detect unsaved changes
+
+	fileKeys  map[string]bool     // Settings the config file set when it was read
+	overrides map[string]override // Settings taken from flags or the environment
 }
 
-// NewConfigManager creates a new configuration manager
+// NewConfigManager creates a new configuration manager using the default
+// config file location
 func NewConfigManager() (*ConfigManager, error) {
-	homeDir, err := os.UserHomeDir()
+	configPath, err := DefaultConfigPath()
 	if err != nil {
 		return nil, err
 	}
 
-	configPath := filepath.Join(homeDir, ".ddalab-launcher")
+	return NewConfigManagerWithPath(configPath)
+}
 
+// NewConfigManagerWithPath creates a configuration manager for an explicit
+// config file. The file format (JSON, TOML or YAML) is chosen by extension.
+func NewConfigManagerWithPath(configPath string) (*ConfigManager, error) {
 	cm := &ConfigManager{
 		configPath: configPath,
-		config: &LauncherConfig{
-			FirstRun:            true,
-			Version:             GetVersion(),
-			AutoUpdateCheck:     true,                        // Default to enabled
-			UpdateCheckInterval: 24,                          // Check daily by default
-			LastUpdateCheck:     time.Time{},                 // Never checked
-			OperationMode:       ModeAuto,                    // Default to auto-detection
-			APIEndpoint:         "http://localhost:8080/api", // Docker extension API
-		},
+		config:     defaultConfig(),
 	}
 
 	// Try to load existing config
 	if err := cm.Load(); err != nil {
-		// If config doesn't exist, that's OK for first run
+		// If config doesn't exist, that's OK for first run. The defaults
+		// are only written once a setting changes.
 		if !os.IsNotExist(err) {
 			return nil, err
 		}
+		cm.markSaved()
 	}
 
 	return cm, nil
 }
 
+// defaultConfig returns the settings used for anything the config file
+// does not set
+func defaultConfig() *LauncherConfig {
+	return &LauncherConfig{
+		FirstRun:            true,
+		Version:             GetVersion(),
+		AutoUpdateCheck:     true,               // Default to enabled
+		UpdateCheckInterval: 24,                 // Check daily by default
+		LastUpdateCheck:     time.Time{},        // Never checked
+		OperationMode:       ModeAuto,           // Default to auto-detection
+		APIEndpoint:         DefaultAPIEndpoint, // Docker extension API
+		BootstrapTimeout:    int(DefaultBootstrapTimeout.Seconds()),
+	}
+}
+
 // Load reads the configuration from disk
 func (cm *ConfigManager) Load() error {
+	if err := cm.readFile(cm.config); err != nil {
+		return err
+	}
+	cm.markSaved()
+	cm.checkPermissions()
+
+	return nil
+}
+
+// readFile parses the config file into cfg and records which settings it
+// sets
+func (cm *ConfigManager) readFile(cfg *LauncherConfig) error {
 	data, err := os.ReadFile(cm.configPath)
 	if err != nil {
 		return err
 	}
 
-	return json.Unmarshal(data, cm.config)
+	codec := codecForPath(cm.configPath)
+	if err := codec.unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("failed to parse %s config %s: %w", codec.name, cm.configPath, err)
+	}
+	cm.recordFileKeys(codec, data)
+
+	// Older configs may contain un-normalized endpoints (e.g. with a trailing /api)
+	if normalized, err := NormalizeAPIEndpoint(cfg.APIEndpoint); err == nil {
+		cfg.APIEndpoint = normalized
+	}
+	return nil
+}
+
+// Reload replaces the settings with the config file's, e.g. after it was
+// edited by hand while the launcher runs, and returns the keys of the
+// settings that changed. Settings the file leaves out fall back to their
+// defaults. Changes not saved yet are lost; see HasUnsavedChanges.
+func (cm *ConfigManager) Reload() ([]string, error) {
+	fresh := defaultConfig()
+	if err := cm.readFile(fresh); err != nil {
+		return nil, err
+	}
+
+	changed := changedSettings(cm.config, fresh)
+	*cm.config = *fresh // Keep the pointer handed out by GetConfig valid
+	cm.markSaved()
+	cm.checkPermissions()
+	return changed, nil
+}
+
+// HasUnsavedChanges reports whether settings were changed in memory since
+// the config file was last loaded or saved
+func (cm *ConfigManager) HasUnsavedChanges() bool {
+	data, err := json.Marshal(cm.config)
+	return err != nil || !bytes.Equal(data, cm.saved)
+}
+
+// markSaved records the settings as matching the config file
+func (cm *ConfigManager) markSaved() {
+	cm.saved, _ = json.Marshal(cm.config)
+}
+
+// changedSettings returns the keys of the settings that differ between
+// old and new, named as in the config file
+func changedSettings(old, new *LauncherConfig) []string {
+	oldValue, newValue := reflect.ValueOf(old).Elem(), reflect.ValueOf(new).Elem()
+	configType := oldValue.Type()
+
+	var changed []string
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if reflect.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		changed = append(changed, name)
+	}
+	return changed
 }
 
 // Save writes the configuration to disk
 func (cm *ConfigManager) Save() error {
-	data, err := json.MarshalIndent(cm.config, "", "  ")
+	codec := codecForPath(cm.configPath)
+	data, err := codec.marshal(cm.config)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(cm.configPath, data, 0644)
+	// The XDG config directory may not exist yet
+	if err := os.MkdirAll(filepath.Dir(cm.configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	// Credentials must not be readable by other local users
+	mode := publicFileMode
+	if cm.config.hasSecrets() {
+		mode = privateFileMode
+	}
+
+	if err := writeFileAtomic(cm.configPath, data, mode); err != nil {
+		return err
+	}
+	cm.markSaved()
+	return nil
+}
+
+// GetConfigPath returns the path of the config file in use
+func (cm *ConfigManager) GetConfigPath() string {
+	return cm.configPath
 }
 
 // GetConfig returns the current configuration
@@ -115,9 +316,68 @@ func (cm *ConfigManager) SetDDALABPath(path string) {
 	cm.config.FirstRun = false
 }
 
-// SetLastOperation records the last operation performed
-func (cm *ConfigManager) SetLastOperation(operation string) {
+// SetLastMenuAction records the last action chosen in the main menu
+func (cm *ConfigManager) SetLastMenuAction(action string) {
+	cm.config.LastMenuAction = action
+}
+
+// GetLastMenuAction returns the last action chosen in the main menu
+func (cm *ConfigManager) GetLastMenuAction() string {
+	return cm.config.LastMenuAction
+}
+
+// SetServerCapabilities records the backend version and features seen,
+// so later runs can tell when features disappear
+func (cm *ConfigManager) SetServerCapabilities(version string, features []string) {
+	cm.config.ServerVersion = version
+	cm.config.ServerFeatures = features
+}
+
+// GetServerVersion returns the backend version seen last
+func (cm *ConfigManager) GetServerVersion() string {
+	return cm.config.ServerVersion
+}
+
+// GetServerFeatures returns the backend features seen last
+func (cm *ConfigManager) GetServerFeatures() []string {
+	return cm.config.ServerFeatures
+}
+
+// IsTelemetryEnabled returns true if the user opted in to failure reports
+// and offline mode is off
+func (cm *ConfigManager) IsTelemetryEnabled() bool {
+	return cm.config.Telemetry && !cm.IsOffline()
+}
+
+// SetTelemetry records the user's telemetry choice
+func (cm *ConfigManager) SetTelemetry(enabled bool) {
+	cm.config.Telemetry = enabled
+	cm.config.TelemetryAsked = true
+}
+
+// IsTelemetryAsked returns true if the user already answered the telemetry
+// consent prompt
+func (cm *ConfigManager) IsTelemetryAsked() bool {
+	return cm.config.TelemetryAsked
+}
+
+// GetTelemetryEndpoint returns where failure reports are sent ("" for
+// nowhere)
+func (cm *ConfigManager) GetTelemetryEndpoint() string {
+	return cm.config.TelemetryEndpoint
+}
+
+// SetLastOperation records the outcome of the last completed operation
+func (cm *ConfigManager) SetLastOperation(operation string, err error) {
 	cm.config.LastOperation = operation
+	cm.config.LastOperationFailed = err != nil
+	cm.config.LastOperationTime = time.Now()
+}
+
+// GetLastOperation returns the last completed operation, whether it failed
+// and when it completed ("" if none was recorded)
+func (cm *ConfigManager) GetLastOperation() (operation string, failed bool, at time.Time) {
+	return cm.config.LastOperation, cm.config.LastOperationFailed, cm.config.LastOperationTime
 }
 
 // IsFirstRun returns true if this is the first time running the launcher
@@ -130,6 +390,44 @@ func (cm *ConfigManager) GetDDALABPath() string {
 	return cm.config.DDALABPath
 }
 
+// GetWebProbe returns the web probe settings with defaults filled in
+func (cm *ConfigManager) GetWebProbe() WebProbeConfig {
+	probe := cm.config.WebProbe
+	if probe.Path == "" {
+		probe.Path = DefaultWebProbePath
+	}
+	if len(probe.ExpectedStatus) == 0 {
+		probe.ExpectedStatus = DefaultWebProbeStatus
+	}
+	if probe.TimeoutSeconds <= 0 {
+		probe.TimeoutSeconds = int(DefaultWebProbeTimeout / time.Second)
+	}
+	return probe
+}
+
+// GetHooks returns the configured lifecycle hooks
+func (cm *ConfigManager) GetHooks() HooksConfig {
+	return cm.config.Hooks
+}
+
+// ThemePlain selects ASCII icons instead of emoji
+const ThemePlain = "plain"
+
+// GetTheme returns the configured UI theme, or "" for the default
+func (cm *ConfigManager) GetTheme() string {
+	return cm.config.Theme
+}
+
+// GetPalette returns the configured color palette, or "" for the default
+func (cm *ConfigManager) GetPalette() string {
+	return cm.config.Palette
+}
+
+// GetLocale returns the configured UI language, or "" to use the environment
+func (cm *ConfigManager) GetLocale() string {
+	return cm.config.Locale
+}
+
 // Update-related methods
 
 // SetAutoUpdateCheck enables or disables automatic update checking
@@ -142,6 +440,32 @@ func (cm *ConfigManager) IsAutoUpdateCheckEnabled() bool {
 	return cm.config.AutoUpdateCheck
 }
 
+// SetAutoInstallUpdates enables or disables installing launcher updates
+// found by the startup check without asking
+func (cm *ConfigManager) SetAutoInstallUpdates(enabled bool) {
+	cm.config.AutoInstallUpdates = enabled
+}
+
+// IsAutoInstallUpdatesEnabled returns true if launcher updates found at
+// startup are installed automatically
+func (cm *ConfigManager) IsAutoInstallUpdatesEnabled() bool {
+	return cm.config.AutoInstallUpdates
+}
+
+// GetUpdateChannel returns the launcher release channel, "stable" unless
+// beta builds were opted into
+func (cm *ConfigManager) GetUpdateChannel() string {
+	if cm.config.UpdateChannel == "" {
+		return "stable"
+	}
+	return cm.config.UpdateChannel
+}
+
+// SetUpdateChannel sets the launcher release channel
+func (cm *ConfigManager) SetUpdateChannel(channel string) {
+	cm.config.UpdateChannel = channel
+}
+
 // SetUpdateCheckInterval sets the interval between update checks in hours
 func (cm *ConfigManager) SetUpdateCheckInterval(hours int) {
 	cm.config.UpdateCheckInterval = hours
@@ -162,9 +486,30 @@ func (cm *ConfigManager) GetLastUpdateCheck() time.Time {
 	return cm.config.LastUpdateCheck
 }
 
+// GetUpdateCheckTimeout returns how long one attempt of the startup update
+// check may take
+func (cm *ConfigManager) GetUpdateCheckTimeout() time.Duration {
+	if cm.config.UpdateCheckTimeout <= 0 {
+		return DefaultUpdateCheckTimeout
+	}
+	return time.Duration(cm.config.UpdateCheckTimeout) * time.Second
+}
+
+// RecordUpdateCheck stores the time and outcome of an update check and
+// returns how many checks in a row have failed
+func (cm *ConfigManager) RecordUpdateCheck(failed bool) int {
+	cm.config.LastUpdateCheck = time.Now()
+	if failed {
+		cm.config.UpdateCheckFailures++
+	} else {
+		cm.config.UpdateCheckFailures = 0
+	}
+	return cm.config.UpdateCheckFailures
+}
+
 // ShouldCheckForUpdates determines if we should check for updates now
 func (cm *ConfigManager) ShouldCheckForUpdates() bool {
-	if !cm.config.AutoUpdateCheck {
+	if !cm.config.AutoUpdateCheck || cm.IsOffline() {
 		return false
 	}
 
@@ -172,6 +517,22 @@ func (cm *ConfigManager) ShouldCheckForUpdates() bool {
 	return time.Since(cm.config.LastUpdateCheck) >= interval
 }
 
+// SetOffline enables or disables offline mode in the saved configuration
+func (cm *ConfigManager) SetOffline(offline bool) {
+	cm.config.Offline = offline
+}
+
+// SetOfflineSession enables offline mode for this session without saving it
+func (cm *ConfigManager) SetOfflineSession() {
+	cm.offlineSession = true
+}
+
+// IsOffline returns true if external network calls (update checks,
+// release notes) must be skipped
+func (cm *ConfigManager) IsOffline() bool {
+	return cm.config.Offline || cm.offlineSession
+}
+
 // Operation mode related methods
 
 // SetOperationMode sets the operation mode (killswitch)
@@ -184,9 +545,15 @@ func (cm *ConfigManager) GetOperationMode() OperationMode {
 	return cm.config.OperationMode
 }
 
-// SetAPIEndpoint sets the API endpoint for Docker extension communication
-func (cm *ConfigManager) SetAPIEndpoint(endpoint string) {
-	cm.config.APIEndpoint = endpoint
+// SetAPIEndpoint validates, normalizes and sets the API endpoint for Docker extension communication
+func (cm *ConfigManager) SetAPIEndpoint(endpoint string) error {
+	normalized, err := NormalizeAPIEndpoint(endpoint)
+	if err != nil {
+		return err
+	}
+
+	cm.config.APIEndpoint = normalized
+	return nil
 }
 
 // GetAPIEndpoint returns the API endpoint
@@ -194,6 +561,86 @@ func (cm *ConfigManager) GetAPIEndpoint() string {
 	return cm.config.APIEndpoint
 }
 
+// GetAPIToken returns the configured API bearer token, if any
+func (cm *ConfigManager) GetAPIToken() string {
+	return cm.config.APIToken
+}
+
+// GetBootstrapTimeout returns how long to wait for the backend to become
+// healthy after bootstrapping it
+func (cm *ConfigManager) GetBootstrapTimeout() time.Duration {
+	if cm.config.BootstrapTimeout <= 0 {
+		return DefaultBootstrapTimeout
+	}
+	return time.Duration(cm.config.BootstrapTimeout) * time.Second
+}
+
+// GetConnectTimeout returns how long to wait for a connection to the API
+func (cm *ConfigManager) GetConnectTimeout() time.Duration {
+	if cm.config.ConnectTimeout <= 0 {
+		return DefaultConnectTimeout
+	}
+	return time.Duration(cm.config.ConnectTimeout) * time.Millisecond
+}
+
+// GetStatusTimeout returns how long a single status check may take
+func (cm *ConfigManager) GetStatusTimeout() time.Duration {
+	if cm.config.StatusTimeout <= 0 {
+		return DefaultStatusTimeout
+	}
+	return time.Duration(cm.config.StatusTimeout) * time.Second
+}
+
+// GetExpectedServices returns the compose services a valid installation
+// must define, or nil for the detector's defaults
+func (cm *ConfigManager) GetExpectedServices() []string {
+	return cm.config.ExpectedServices
+}
+
+// GetPingInterval returns how often the reachability of the API is checked
+func (cm *ConfigManager) GetPingInterval() time.Duration {
+	if cm.config.PingInterval <= 0 {
+		return DefaultPingInterval
+	}
+	return time.Duration(cm.config.PingInterval) * time.Second
+}
+
+// GetAPICACert returns the path of a PEM file with additional CAs trusted
+// for the API endpoint, if any
+func (cm *ConfigManager) GetAPICACert() string {
+	return cm.config.APICACert
+}
+
+// GetAPIHealthPaths returns the configured routes of the API health and
+// version endpoints. Empty routes mean the defaults.
+func (cm *ConfigManager) GetAPIHealthPaths() (healthPath, versionPath string) {
+	return cm.config.APIHealthPath, cm.config.APIVersionPath
+}
+
+// GetSSHForward returns the SSH target whose ports are forwarded to
+// localhost and the ports to forward. Without configured ports the API
+// endpoint's port is forwarded. An empty host means no forwarding.
+func (cm *ConfigManager) GetSSHForward() (host string, ports []int) {
+	if cm.config.SSHHost == "" {
+		return "", nil
+	}
+	if len(cm.config.SSHPorts) > 0 {
+		return cm.config.SSHHost, cm.config.SSHPorts
+	}
+
+	port := 8080
+	if endpoint, err := url.Parse(cm.config.APIEndpoint); err == nil {
+		if p, err := strconv.Atoi(endpoint.Port()); err == nil {
+			port = p
+		} else if endpoint.Scheme == "https" {
+			port = 443
+		} else if endpoint.Scheme == "http" {
+			port = 80
+		}
+	}
+	return cm.config.SSHHost, []int{port}
+}
+
 // IsAPIMode returns true if the launcher should use API mode
 func (cm *ConfigManager) IsAPIMode() bool {
 	return cm.config.OperationMode == ModeAPI
@@ -208,3 +655,44 @@ func (cm *ConfigManager) IsLocalMode() bool {
 func (cm *ConfigManager) IsAutoMode() bool {
 	return cm.config.OperationMode == ModeAuto
 }
+
+// NormalizeAPIEndpoint turns user input such as "localhost:8080" or
+// "http://localhost:8080/api/" into a canonical base URL ("http://localhost:8080").
+// The API client appends the /api prefix itself, so one trailing /api is
+// removed; a reverse proxy prefix before it is kept, e.g. "/ddalab/api"
+// becomes "/ddalab".
+func NormalizeAPIEndpoint(endpoint string) (string, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		return "", fmt.Errorf("API endpoint cannot be empty")
+	}
+
+	// Add a scheme if the user only provided host[:port]
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid API endpoint '%s': %w", endpoint, err)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("invalid API endpoint '%s': scheme must be http or https", endpoint)
+	}
+
+	if parsed.Host == "" || parsed.Hostname() == "" {
+		return "", fmt.Errorf("invalid API endpoint '%s': missing host", endpoint)
+	}
+
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", fmt.Errorf("invalid API endpoint '%s': query strings and fragments are not allowed", endpoint)
+	}
+
+	// Strip trailing slashes and the /api the client adds on its own
+	path := strings.TrimSuffix(strings.TrimRight(parsed.Path, "/"), "/api")
+	parsed.Path = strings.TrimRight(path, "/")
+	parsed.RawPath = ""
+
+	return parsed.String(), nil
+}
@@ -0,0 +1,41 @@
+package config
+
+import "testing"
+
+func TestNormalizeAPIEndpoint(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"localhost:8080", "http://localhost:8080"},
+		{"http://localhost:8080/", "http://localhost:8080"},
+		{"http://localhost:8080/api", "http://localhost:8080"},
+		{"http://localhost:8080/api/", "http://localhost:8080"},
+		{"http://localhost:8080//api//", "http://localhost:8080"},
+		{"https://host/ddalab", "https://host/ddalab"},
+		{"https://host/ddalab/", "https://host/ddalab"},
+		{"https://host/ddalab/api", "https://host/ddalab"},
+		{"https://host/ddalab/api/", "https://host/ddalab"},
+		{"https://host/api/api/", "https://host/api"},
+		{"https://host/apiary", "https://host/apiary"},
+		{"http://[::1]:8080/api", "http://[::1]:8080"},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeAPIEndpoint(tt.input)
+		if err != nil {
+			t.Errorf("NormalizeAPIEndpoint(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeAPIEndpoint(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeAPIEndpointRejects(t *testing.T) {
+	for _, input := range []string{"", "ftp://host", "http://", "http://host/?x=1", "http://host/#top"} {
+		if got, err := NormalizeAPIEndpoint(input); err == nil {
+			t.Errorf("NormalizeAPIEndpoint(%q) = %q, want an error", input, got)
+		}
+	}
+}
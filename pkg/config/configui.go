@@ -2,56 +2,13 @@ package config
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-)
 
-// Styles for the UI
-var (
-	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("205")).
-			Padding(1, 2)
-
-	headerStyle = lipgloss.NewStyle().
-			Bold(true).
-			Background(lipgloss.Color("62")).
-			Foreground(lipgloss.Color("230")).
-			Padding(0, 1)
-
-	selectedStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("57")).
-			Foreground(lipgloss.Color("230"))
-
-	normalStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252"))
-
-	requiredStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
-			Bold(true)
-
-	secretStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("208"))
-
-	sectionStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("99")).
-			Margin(1, 0, 0, 0)
-
-	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
-			Margin(1, 0)
-
-	inputStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62")).
-			Padding(0, 1)
-
-	warningStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("214")).
-			Bold(true)
+	"github.com/ddalab/launcher/pkg/theme"
 )
 
 // ConfigEditorModel represents the configuration editor state
@@ -70,8 +27,61 @@ type ConfigEditorModel struct {
 	saved        bool
 	message      string
 	showSecrets  bool
+	showResolved bool     // Show values with ${VAR} references expanded
+	onlyChanged  bool     // Only show variables customized from .env.example
+	savedKeys    []string // Keys whose changes have been written to disk
+	offerRestart bool     // DDALAB is running, so "a" saves and restarts
+	restart      bool     // The user chose to save and restart
+	restartReady bool     // Saved with backend warnings, "a" again restarts
+	validate     func(variables []EnvVar) ([]EnvIssue, error)
+	validating   bool // Waiting for validate before saving
+
+	replaceStep  replaceStep   // Progress of search-and-replace
+	replaceFind  string        // Text or pattern to replace
+	replaceWith  string        // Replacement text
+	replaceRegex bool          // Treat replaceFind as a regular expression
+	replacePlan  []Replacement // Changes waiting for confirmation
+}
+
+// replaceStep is the prompt search-and-replace is waiting for
+type replaceStep int
+
+const (
+	replaceOff        replaceStep = iota // Not replacing
+	replaceFinding                       // Typing the text to find
+	replaceEntering                      // Typing the replacement
+	replaceConfirming                    // Reviewing the preview
+)
+
+// EditorOptions configures RunConfigEditor
+type EditorOptions struct {
+	// OfferRestart offers saving and restarting in one step, for when
+	// DDALAB is running
+	OfferRestart bool
+
+	// Validate, if set, checks the variables before each save, e.g. with
+	// the backend. An issue that is an error stops the save; if Validate
+	// itself fails, the file is saved without the check.
+	Validate func(variables []EnvVar) ([]EnvIssue, error)
 }
 
+// EnvIssue is a problem an external check found with a variable
+type EnvIssue struct {
+	Key     string
+	Message string
+	Error   bool // Rejects the configuration; otherwise a warning
+}
+
+// validationMsg carries the result of the validate callback
+type validationMsg struct {
+	issues  []EnvIssue
+	err     error
+	restart bool // Restart after saving
+}
+
+// maxReplacePreview limits how many changes the replace preview lists
+const maxReplacePreview = 10
+
 // NewConfigEditor creates a new configuration editor model
 func NewConfigEditor(config *EnvConfig) *ConfigEditorModel {
 	model := &ConfigEditorModel{
@@ -100,7 +110,24 @@ func (m *ConfigEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 
+	case validationMsg:
+		return m.finishSave(msg)
+
 	case tea.KeyMsg:
+		if m.validating {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil // The values must not change while they are checked
+		}
+		if msg.String() != "a" {
+			m.restartReady = false
+		}
+
+		if m.replaceStep != replaceOff {
+			return m.handleReplaceMode(msg)
+		}
+
 		if m.editMode {
 			return m.handleEditMode(msg)
 		}
@@ -156,15 +183,19 @@ func (m *ConfigEditorModel) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd
 		m.filterVariables()
 
 	case "s":
-		if err := m.config.SaveEnvFile(); err != nil {
-			m.message = fmt.Sprintf("Error saving: %v", err)
-		} else {
-			m.saved = true
-			m.message = "Configuration saved successfully!"
-			// Update original vars to reflect saved state
-			m.originalVars = make([]EnvVar, len(m.config.Variables))
-			copy(m.originalVars, m.config.Variables)
+		return m, m.save(false)
+
+	case "a":
+		// Save and leave the editor to restart DDALAB
+		if !m.offerRestart {
+			m.message = "DDALAB is not running - press s to save, changes apply on the next start"
+			break
 		}
+		if m.restartReady {
+			m.restart = true
+			return m, tea.Quit
+		}
+		return m, m.save(true)
 
 	case "r":
 		// Reset to original values
@@ -182,17 +213,174 @@ func (m *ConfigEditorModel) handleNormalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd
 			m.message = "Hiding secret values"
 		}
 
+	case "x":
+		// Toggle the "changed from example" filter
+		if !m.config.HasExample {
+			m.message = "No .env.example found to compare against"
+			break
+		}
+		m.onlyChanged = !m.onlyChanged
+		m.filterVariables()
+		if m.onlyChanged {
+			m.message = "Showing only variables changed from .env.example"
+		} else {
+			m.message = "Showing all variables"
+		}
+
+	case "e":
+		// Toggle interpolated value display
+		m.showResolved = !m.showResolved
+		if m.showResolved {
+			m.message = "Showing resolved values"
+		} else {
+			m.message = "Showing raw values"
+		}
+
+	case "!":
+		m.jumpToNextIssue()
+
+	case "R":
+		m.replaceStep = replaceFinding
+		m.replaceFind, m.replaceWith, m.replacePlan = "", "", nil
+		m.message = ""
+
 	case "?":
-		m.message = "Help: ↑/↓=navigate, Enter=edit, /=search, s=save, r=revert, t=toggle secrets, q=quit"
+		m.message = "Help: ↑/↓=navigate, Enter=edit, /=search, R=replace, !=next issue, s=save, a=save and restart, r=revert, t=toggle secrets, e=toggle resolved, x=changed only, q=quit"
+	}
+
+	return m, nil
+}
+
+// save checks the variables and writes the .env file, quitting for a
+// restart afterwards if restart is set. With a validate callback the check
+// runs in the background and finishSave writes the file once it answers.
+func (m *ConfigEditorModel) save(restart bool) tea.Cmd {
+	if invalid := m.config.InvalidVariables(); len(invalid) > 0 {
+		m.message = fmt.Sprintf("Not saved: %s %s", invalid[0].Key, invalid[0].ValidateValue(invalid[0].Value))
+		return nil
+	}
+
+	if m.validate == nil {
+		return m.finishSaveCmd(validationMsg{restart: restart})
+	}
+
+	m.validating = true
+	m.message = "Checking the configuration with the backend..."
+	variables := append([]EnvVar(nil), m.config.Variables...)
+	validate := m.validate
+	return func() tea.Msg {
+		issues, err := validate(variables)
+		return validationMsg{issues: issues, err: err, restart: restart}
+	}
+}
+
+// finishSaveCmd is finishSave for callers that only need the command
+func (m *ConfigEditorModel) finishSaveCmd(msg validationMsg) tea.Cmd {
+	_, cmd := m.finishSave(msg)
+	return cmd
+}
+
+// finishSave writes the .env file unless the check found an error. Warnings
+// are shown after saving; a restart then needs a second "a" so they are
+// not missed.
+func (m *ConfigEditorModel) finishSave(msg validationMsg) (tea.Model, tea.Cmd) {
+	m.validating = false
+
+	var errs, warnings []EnvIssue
+	for _, issue := range msg.issues {
+		if issue.Error {
+			errs = append(errs, issue)
+		} else {
+			warnings = append(warnings, issue)
+		}
+	}
+	if len(errs) > 0 {
+		m.message = fmt.Sprintf("Not saved: the backend rejects %s: %s", errs[0].Key, errs[0].Message)
+		if len(errs) > 1 {
+			m.message += fmt.Sprintf(" (and %d more)", len(errs)-1)
+		}
+		return m, nil
+	}
+
+	if !m.write() {
+		return m, nil
 	}
 
+	switch {
+	case msg.err != nil:
+		m.message = fmt.Sprintf("Configuration saved without the backend check (%v)", msg.err)
+	case len(warnings) > 0:
+		m.message = fmt.Sprintf("Saved with a backend warning for %s: %s", warnings[0].Key, warnings[0].Message)
+		if len(warnings) > 1 {
+			m.message += fmt.Sprintf(" (and %d more)", len(warnings)-1)
+		}
+		if msg.restart {
+			m.message += " - press a again to restart anyway"
+			m.restartReady = true
+			return m, nil
+		}
+	default:
+		m.message = "Configuration saved successfully!"
+	}
+
+	if msg.restart {
+		m.restart = true
+		return m, tea.Quit
+	}
 	return m, nil
 }
 
+// write writes the .env file and reports whether it succeeded. Failures
+// are shown as the message.
+func (m *ConfigEditorModel) write() bool {
+	if err := m.config.SaveEnvFile(); err != nil {
+		m.message = fmt.Sprintf("Error saving: %v", err)
+		return false
+	}
+
+	m.saved = true
+	m.recordSavedKeys()
+	// Update original vars to reflect saved state
+	m.originalVars = make([]EnvVar, len(m.config.Variables))
+	copy(m.originalVars, m.config.Variables)
+	return true
+}
+
+// jumpToNextIssue moves the cursor to the next shown variable after it
+// whose value needs attention, wrapping around at the end
+func (m *ConfigEditorModel) jumpToNextIssue() {
+	count := len(m.filteredVars)
+	for offset := 1; offset <= count; offset++ {
+		i := (m.cursor + offset) % count
+		if issue := m.filteredVars[i].Issue(); issue != "" {
+			m.cursor = i
+			m.message = fmt.Sprintf("%s: %s", m.filteredVars[i].Key, issue)
+			return
+		}
+	}
+
+	if len(m.config.Issues()) > 0 {
+		m.message = "No issues among the shown variables - clear the filter to see the rest"
+	} else {
+		m.message = "No issues found"
+	}
+}
+
 // handleEditMode handles key presses when editing a value
 func (m *ConfigEditorModel) handleEditMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	editing := m.editingVar()
+	if len(editing.Choices) > 0 {
+		return m.handleChoiceMode(msg, editing.Choices)
+	}
+
 	switch msg.String() {
 	case "enter":
+		// Reject values the schema does not allow
+		if problem := m.editingVar().ValidateValue(m.editingValue); problem != "" {
+			m.message = fmt.Sprintf("%s %s", m.editingKey, problem)
+			break
+		}
+
 		// Save the edited value
 		m.config.UpdateVariable(m.editingKey, m.editingValue)
 		m.filterVariables() // Refresh filtered vars
@@ -214,16 +402,134 @@ func (m *ConfigEditorModel) handleEditMode(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 		// Clear the line
 		m.editingValue = ""
 
+	case "up":
+		m.editingValue = editing.Step(m.editingValue, 1)
+
+	case "down":
+		m.editingValue = editing.Step(m.editingValue, -1)
+
 	default:
-		// Add character to editing value
-		if len(msg.String()) == 1 {
-			m.editingValue += msg.String()
+		// Add character to editing value; numbers only take digits
+		key := msg.String()
+		if len(key) == 1 && (!editing.IsNumeric() || isDigits(key)) {
+			m.editingValue += key
+		}
+	}
+
+	return m, nil
+}
+
+// handleChoiceMode handles key presses when editing a variable with a
+// fixed set of values, which are cycled instead of typed
+func (m *ConfigEditorModel) handleChoiceMode(msg tea.KeyMsg, choices []string) (tea.Model, tea.Cmd) {
+	current := choiceIndex(choices, m.editingValue)
+
+	switch msg.String() {
+	case "left", "h", "up", "k":
+		if current <= 0 {
+			current = len(choices)
+		}
+		m.editingValue = choices[current-1]
+
+	case "right", "l", "down", "j", "tab", " ":
+		m.editingValue = choices[(current+1)%len(choices)]
+
+	case "enter":
+		m.config.UpdateVariable(m.editingKey, m.editingValue)
+		m.filterVariables()
+		m.editMode = false
+		m.message = fmt.Sprintf("Updated %s", m.editingKey)
+
+	case "esc":
+		m.editMode = false
+		m.editingValue = ""
+		m.editingKey = ""
+	}
+
+	return m, nil
+}
+
+// editingVar returns the variable being edited
+func (m *ConfigEditorModel) editingVar() EnvVar {
+	for _, envVar := range m.config.Variables {
+		if envVar.Key == m.editingKey {
+			return envVar
+		}
+	}
+	return EnvVar{Key: m.editingKey}
+}
+
+// handleReplaceMode handles key presses during search-and-replace: typing
+// the text to find, then the replacement, then confirming the preview
+func (m *ConfigEditorModel) handleReplaceMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "esc" || (m.replaceStep == replaceConfirming && key == "n") {
+		m.replaceStep = replaceOff
+		m.message = "Replace cancelled"
+		return m, nil
+	}
+
+	if m.replaceStep == replaceConfirming {
+		if key == "y" || key == "enter" {
+			m.config.ApplyReplacements(m.replacePlan)
+			m.filterVariables()
+			m.replaceStep = replaceOff
+			m.message = fmt.Sprintf("Replaced in %d variable(s) - press s to save", len(m.replacePlan))
+		}
+		return m, nil
+	}
+
+	field := &m.replaceFind
+	if m.replaceStep == replaceEntering {
+		field = &m.replaceWith
+	}
+
+	switch key {
+	case "enter":
+		if m.replaceStep == replaceEntering {
+			m.planReplace()
+		} else if m.replaceFind != "" {
+			m.replaceStep = replaceEntering
+		}
+
+	case "tab":
+		m.replaceRegex = !m.replaceRegex
+
+	case "backspace":
+		if len(*field) > 0 {
+			*field = (*field)[:len(*field)-1]
+		}
+
+	case "ctrl+u":
+		*field = ""
+
+	default:
+		if len(key) == 1 {
+			*field += key
 		}
 	}
 
 	return m, nil
 }
 
+// planReplace shows the preview of the replacement, or why there is
+// nothing to replace
+func (m *ConfigEditorModel) planReplace() {
+	plan, err := m.config.PlanReplace(m.replaceFind, m.replaceWith, m.replaceRegex)
+	switch {
+	case err != nil:
+		m.replaceStep = replaceFinding
+		m.message = err.Error()
+	case len(plan) == 0:
+		m.replaceStep = replaceOff
+		m.message = fmt.Sprintf("No value matches '%s'", m.replaceFind)
+	default:
+		m.replaceStep = replaceConfirming
+		m.replacePlan = plan
+		m.message = ""
+	}
+}
+
 // handleSearchMode handles key presses when searching
 func (m *ConfigEditorModel) handleSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -250,15 +556,18 @@ func (m *ConfigEditorModel) handleSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd
 	return m, nil
 }
 
-// filterVariables filters variables based on search term
+// filterVariables filters variables based on search term and active filters
 func (m *ConfigEditorModel) filterVariables() {
-	if m.searchTerm == "" {
+	if m.searchTerm == "" && !m.onlyChanged {
 		m.filteredVars = m.config.Variables
 	} else {
 		m.filteredVars = []EnvVar{}
 		searchLower := strings.ToLower(m.searchTerm)
 
 		for _, envVar := range m.config.Variables {
+			if m.onlyChanged && !envVar.ChangedFromDefault {
+				continue
+			}
 			if strings.Contains(strings.ToLower(envVar.Key), searchLower) ||
 				strings.Contains(strings.ToLower(envVar.Value), searchLower) ||
 				strings.Contains(strings.ToLower(envVar.Comment), searchLower) ||
@@ -276,6 +585,19 @@ func (m *ConfigEditorModel) filterVariables() {
 
 // View renders the configuration editor
 func (m *ConfigEditorModel) View() string {
+	styles := theme.Styles()
+	compact := theme.IsCompact(m.width)
+
+	// Small terminals get less padding and spacing, and the file lines are
+	// cut at the terminal width instead of wrapped
+	titleStyle, sectionStyle, helpStyle, clip := styles.Title, styles.Section, styles.EditorHelp, lipgloss.NewStyle()
+	if compact {
+		titleStyle = titleStyle.Padding(0, 1)
+		sectionStyle = sectionStyle.UnsetMargins()
+		helpStyle = helpStyle.UnsetMargins().Width(m.width)
+		clip = clip.MaxWidth(m.width)
+	}
+
 	var b strings.Builder
 
 	// Title
@@ -283,31 +605,68 @@ func (m *ConfigEditorModel) View() string {
 	b.WriteString(title + "\n")
 
 	// File path
-	b.WriteString(fmt.Sprintf("File: %s\n\n", m.config.FilePath))
+	b.WriteString(clip.Render(fmt.Sprintf("File: %s", m.config.FilePath)) + "\n")
+	if m.config.LocalPath != "" {
+		b.WriteString(clip.Render(fmt.Sprintf("Overlay: %s (LOC values win and are saved there)", m.config.LocalPath)) + "\n")
+	}
+
+	// Remaining issues, so the user knows when remediation is done
+	if issues := len(m.config.Issues()); issues > 0 {
+		b.WriteString(styles.Error.Render(fmt.Sprintf("%d issue(s) remaining - press ! to jump to the next", issues)) + "\n")
+	}
+	b.WriteString("\n")
 
 	// Search bar
 	if m.searchMode {
-		searchPrompt := inputStyle.Render(fmt.Sprintf("Search: %s█", m.searchTerm))
+		searchPrompt := styles.Prompt.Render(fmt.Sprintf("Search: %s█", m.searchTerm))
 		b.WriteString(searchPrompt + "\n\n")
 	} else if m.searchTerm != "" {
 		searchInfo := fmt.Sprintf("Filter: '%s' (%d/%d vars)", m.searchTerm, len(m.filteredVars), len(m.config.Variables))
-		b.WriteString(warningStyle.Render(searchInfo) + "\n\n")
+		b.WriteString(styles.Warning.Render(searchInfo) + "\n\n")
+	} else if m.onlyChanged {
+		filterInfo := fmt.Sprintf("Filter: changed from .env.example (%d/%d vars)", len(m.filteredVars), len(m.config.Variables))
+		b.WriteString(styles.Warning.Render(filterInfo) + "\n\n")
+	}
+
+	// Search-and-replace
+	if m.replaceStep != replaceOff {
+		m.writeReplace(&b)
 	}
 
 	// Edit mode
 	if m.editMode {
-		editPrompt := inputStyle.Render(fmt.Sprintf("Editing %s: %s█", m.editingKey, m.editingValue))
-		b.WriteString(editPrompt + "\n\n")
+		if choices := m.editingVar().Choices; len(choices) > 0 {
+			b.WriteString(styles.Prompt.Render(fmt.Sprintf("Editing %s: %s", m.editingKey, m.renderChoices(choices))) + "\n\n")
+		} else {
+			editPrompt := styles.Prompt.Render(fmt.Sprintf("Editing %s: %s█", m.editingKey, m.editingValue))
+			b.WriteString(editPrompt + "\n\n")
+		}
 	}
 
 	// Table header
-	header := fmt.Sprintf("%-30s %-40s %-20s %s", "KEY", "VALUE", "SECTION", "STATUS")
-	b.WriteString(headerStyle.Render(header) + "\n")
+	keyWidth, valueWidth, sectionWidth := m.columns()
+	header := fmt.Sprintf("%-*s %-*s ", keyWidth, "KEY", valueWidth, "VALUE")
+	if sectionWidth > 0 {
+		header += fmt.Sprintf("%-*s ", sectionWidth, "SECTION")
+	}
+	b.WriteString(styles.EditorHeader.Render(header+"STATUS") + "\n")
+
+	// The lines below the table are rendered first, so a small terminal
+	// can give the table exactly the rows left between them and the header
+	var details, help strings.Builder
+	m.writeSelectedDetail(&details, helpStyle)
+	m.writeHelp(&help, helpStyle, compact)
 
 	// Variables table
 	displayHeight := m.height - 15 // Account for header, title, etc.
 	startIdx := max(0, m.cursor-displayHeight/2)
 	endIdx := min(len(m.filteredVars), startIdx+displayHeight)
+	if compact {
+		// One line is kept for the scrolling indicator
+		displayHeight = max(1, m.height-strings.Count(b.String(), "\n")-
+			strings.Count(details.String(), "\n")-strings.Count(help.String(), "\n")-2)
+		startIdx, endIdx = m.visibleRows(displayHeight)
+	}
 
 	var currentSection string
 	for i := startIdx; i < endIdx; i++ {
@@ -322,72 +681,260 @@ func (m *ConfigEditorModel) View() string {
 
 		// Format value display
 		value := envVar.Value
-		if envVar.IsSecret && !m.showSecrets && value != "" {
-			value = strings.Repeat("*", min(len(value), 20))
+		if m.showResolved {
+			value = m.config.ResolveValue(envVar.Key)
 		}
-		if len(value) > 35 {
-			value = value[:32] + "..."
+		if envVar.IsSecret && !m.showSecrets {
+			value = maskSecret(value)
 		}
 
 		// Format status
 		status := ""
+		if envVar.Issue() != "" {
+			status += "ERR "
+		}
 		if envVar.IsRequired {
 			status += "REQ "
 		}
 		if envVar.IsSecret {
 			status += "SEC "
 		}
+		if envVar.ChangedFromDefault {
+			status += "CHG "
+		}
+		if envVar.Local {
+			status += "LOC "
+		}
 		if m.hasChanged(envVar) {
 			status += "MOD"
 		}
 
 		// Format row
-		row := fmt.Sprintf("%-30s %-40s %-20s %s",
-			truncate(envVar.Key, 28),
-			truncate(value, 38),
-			truncate(envVar.Section, 18),
-			status,
+		row := fmt.Sprintf("%-*s %-*s ",
+			keyWidth, truncate(envVar.Key, keyWidth-2),
+			valueWidth, truncate(value, valueWidth-5),
 		)
+		if sectionWidth > 0 {
+			row += fmt.Sprintf("%-*s ", sectionWidth, truncate(envVar.Section, sectionWidth-2))
+		}
+		row += status
 
 		// Apply styling
 		var style lipgloss.Style
 		if i == m.cursor {
-			style = selectedStyle
+			style = styles.EditorSelected
 		} else if envVar.IsRequired {
-			style = requiredStyle
+			style = styles.Error
 		} else if envVar.IsSecret {
-			style = secretStyle
+			style = styles.Secret
 		} else {
-			style = normalStyle
+			style = styles.EditorItem
 		}
 
 		b.WriteString(style.Render(row) + "\n")
 	}
 
+	b.WriteString(details.String())
+
 	// Show scrolling indicator
-	if len(m.filteredVars) > displayHeight {
+	if startIdx > 0 || endIdx < len(m.filteredVars) {
 		scrollInfo := fmt.Sprintf("(%d-%d of %d)", startIdx+1, endIdx, len(m.filteredVars))
 		b.WriteString("\n" + helpStyle.Render(scrollInfo))
 	}
 
+	b.WriteString(help.String())
+
+	return b.String()
+}
+
+// writeReplace renders the search-and-replace prompts and the preview of
+// the changes
+func (m *ConfigEditorModel) writeReplace(b *strings.Builder) {
+	styles := theme.Styles()
+
+	mode := "text"
+	if m.replaceRegex {
+		mode = "regex"
+	}
+
+	switch m.replaceStep {
+	case replaceFinding:
+		b.WriteString(styles.Prompt.Render(fmt.Sprintf("Find (%s): %s█", mode, m.replaceFind)) + "\n\n")
+
+	case replaceEntering:
+		b.WriteString(styles.Prompt.Render(fmt.Sprintf("Replace '%s' (%s) with: %s█", m.replaceFind, mode, m.replaceWith)) + "\n\n")
+
+	case replaceConfirming:
+		b.WriteString(styles.Warning.Render(fmt.Sprintf("Replace in %d variable(s)?", len(m.replacePlan))) + "\n")
+		for i, change := range m.replacePlan {
+			if i == maxReplacePreview {
+				b.WriteString(fmt.Sprintf("  ... and %d more\n", len(m.replacePlan)-i))
+				break
+			}
+			oldValue, newValue := change.OldValue, change.NewValue
+			if change.IsSecret && !m.showSecrets {
+				oldValue, newValue = maskSecret(oldValue), maskSecret(newValue)
+			}
+			b.WriteString(fmt.Sprintf("  %s: %s → %s\n", change.Key, truncate(oldValue, 30), truncate(newValue, 30)))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// maskSecret hides a secret value, keeping a hint of its length
+func maskSecret(value string) string {
+	return strings.Repeat("*", min(len(value), 20))
+}
+
+// renderChoices lists the allowed values with the current one highlighted
+func (m *ConfigEditorModel) renderChoices(choices []string) string {
+	styles := theme.Styles()
+
+	current := choiceIndex(choices, m.editingValue)
+	rendered := make([]string, len(choices))
+	for i, choice := range choices {
+		if i == current {
+			rendered[i] = styles.EditorSelected.Render(" " + choice + " ")
+		} else {
+			rendered[i] = styles.EditorItem.Render(" " + choice + " ")
+		}
+	}
+	// A value outside the choices is kept until another one is picked
+	if current < 0 && m.editingValue != "" {
+		rendered = append(rendered, styles.Error.Render(" "+m.editingValue+" "))
+	}
+	return strings.Join(rendered, " ")
+}
+
+// writeSelectedDetail writes the description, origin and resolved value of
+// the selected variable, as far as they are known and shown
+func (m *ConfigEditorModel) writeSelectedDetail(b *strings.Builder, helpStyle lipgloss.Style) {
+	// Show the schema description of the selected variable
+	if m.cursor < len(m.filteredVars) && m.filteredVars[m.cursor].Description != "" {
+		selected := m.filteredVars[m.cursor]
+		b.WriteString("\n" + helpStyle.Render(fmt.Sprintf("%s: %s", selected.Key, selected.Description)))
+	}
+
+	// Show where the value of the selected variable comes from
+	if m.cursor < len(m.filteredVars) && m.filteredVars[m.cursor].Local {
+		m.writeOriginDetail(b, m.filteredVars[m.cursor])
+	}
+
+	// Show raw and resolved values for the selected variable
+	if m.showResolved && m.cursor < len(m.filteredVars) {
+		m.writeResolvedDetail(b, m.filteredVars[m.cursor])
+	}
+}
+
+// writeHelp writes the status message and the key help of the current mode
+func (m *ConfigEditorModel) writeHelp(b *strings.Builder, helpStyle lipgloss.Style, compact bool) {
+	styles := theme.Styles()
+
 	// Status message
 	if m.message != "" {
-		b.WriteString("\n" + warningStyle.Render(m.message))
+		b.WriteString("\n" + styles.Warning.Render(m.message))
 	}
 
 	// Help text
-	if !m.editMode && !m.searchMode {
-		help := "↑/↓: navigate • Enter: edit • /: search • s: save • r: revert • t: toggle secrets • q: quit"
+	if m.replaceStep == replaceConfirming {
+		b.WriteString("\n" + helpStyle.Render("y/Enter: apply • n/Esc: cancel"))
+	} else if m.replaceStep != replaceOff {
+		b.WriteString("\n" + helpStyle.Render("Enter: next • Tab: toggle regex • Esc: cancel • Ctrl+U: clear"))
+	} else if !m.editMode && !m.searchMode {
+		help := "↑/↓: navigate • Enter: edit • /: search • R: replace • !: next issue • s: save • r: revert • t: toggle secrets • e: toggle resolved • x: changed only • q: quit"
+		if compact {
+			help = "Enter: edit • /: search • s: save • r: revert • q: quit"
+		}
+		if m.offerRestart {
+			help = strings.Replace(help, "s: save", "s: save • a: save and restart", 1)
+		}
 		b.WriteString("\n" + helpStyle.Render(help))
 	} else if m.editMode {
 		help := "Enter: save • Esc: cancel • Ctrl+U: clear"
+		if editing := m.editingVar(); len(editing.Choices) > 0 {
+			help = "←/→: choose • Enter: save • Esc: cancel"
+		} else if editing.IsNumeric() {
+			help = "0-9: type • ↑/↓: increment/decrement • Enter: save • Esc: cancel • Ctrl+U: clear"
+		}
 		b.WriteString("\n" + helpStyle.Render(help))
 	} else if m.searchMode {
 		help := "Type to search • Enter/Esc: exit search • Ctrl+U: clear"
 		b.WriteString("\n" + helpStyle.Render(help))
 	}
+}
 
-	return b.String()
+// visibleRows returns the range of variables around the cursor that fit in
+// rows lines, counting the section headers shown before them
+func (m *ConfigEditorModel) visibleRows(rows int) (start, end int) {
+	start = max(0, m.cursor-rows/2)
+	end = m.rowsEnd(start, rows)
+	for start < m.cursor && end <= m.cursor {
+		start++
+		end = m.rowsEnd(start, rows)
+	}
+	return start, end
+}
+
+// rowsEnd returns the end of the variables from start that fit in rows lines
+func (m *ConfigEditorModel) rowsEnd(start, rows int) int {
+	var section string
+	end := start
+	for ; end < len(m.filteredVars); end++ {
+		needed := 1
+		if next := m.filteredVars[end].Section; next != section && next != "" {
+			needed++
+			section = next
+		}
+		if rows < needed {
+			break
+		}
+		rows -= needed
+	}
+	return end
+}
+
+// writeResolvedDetail shows the raw template next to its resolved value
+func (m *ConfigEditorModel) writeResolvedDetail(b *strings.Builder, envVar EnvVar) {
+	if !HasReferences(envVar.Value) {
+		return
+	}
+
+	raw := envVar.Value
+	resolved := m.config.ResolveValue(envVar.Key)
+	if envVar.IsSecret && !m.showSecrets {
+		resolved = strings.Repeat("*", min(len(resolved), 20))
+	}
+
+	detail := fmt.Sprintf("%s: %s → %s", envVar.Key, raw, resolved)
+	b.WriteString("\n" + theme.Styles().EditorHelp.Render(detail))
+}
+
+// writeOriginDetail tells where the effective value of an overlay
+// variable comes from and which .env value it overrides
+func (m *ConfigEditorModel) writeOriginDetail(b *strings.Builder, envVar EnvVar) {
+	detail := fmt.Sprintf("%s: set in %s", envVar.Key, filepath.Base(m.config.LocalPath))
+	if envVar.Overrides {
+		baseValue := envVar.BaseValue
+		if envVar.IsSecret && !m.showSecrets {
+			baseValue = maskSecret(baseValue)
+		}
+		detail += fmt.Sprintf(", overrides %s value %q", filepath.Base(m.config.FilePath), baseValue)
+	}
+	b.WriteString("\n" + theme.Styles().EditorHelp.Render(detail))
+}
+
+// recordSavedKeys remembers which variables were changed by the last save
+func (m *ConfigEditorModel) recordSavedKeys() {
+	for _, envVar := range m.config.Variables {
+		if m.hasChanged(envVar) && !contains(m.savedKeys, envVar.Key) {
+			m.savedKeys = append(m.savedKeys, envVar.Key)
+		}
+	}
+}
+
+// SavedKeys returns the keys of all variables changed and saved in this session
+func (m *ConfigEditorModel) SavedKeys() []string {
+	return m.savedKeys
 }
 
 // hasChanged checks if a variable has been modified
@@ -415,6 +962,21 @@ func min(a, b int) int {
 	return b
 }
 
+// statusWidth fits the longest status, "ERR REQ SEC CHG MOD"
+const statusWidth = 19
+
+// columns returns the widths of the key, value and section columns. The
+// compact layout drops the section column and splits the terminal width
+// between key and value.
+func (m *ConfigEditorModel) columns() (key, value, section int) {
+	if !theme.IsCompact(m.width) {
+		return 30, 40, 20
+	}
+	available := max(m.width-statusWidth-2, 24)
+	key = available * 2 / 5
+	return key, available - key, 0
+}
+
 func truncate(s string, length int) string {
 	if len(s) <= length {
 		return s
@@ -422,24 +984,28 @@ func truncate(s string, length int) string {
 	return s[:length-3] + "..."
 }
 
-// RunConfigEditor runs the configuration editor
-func RunConfigEditor(configPath string) error {
+// RunConfigEditor runs the configuration editor and returns the keys of the
+// variables whose changes were saved. restart reports whether the user
+// chose to save and restart, which options.OfferRestart enables.
+func RunConfigEditor(configPath string, options EditorOptions) (savedKeys []string, restart bool, err error) {
 	// Load configuration
 	config, err := LoadEnvFile(configPath)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return nil, false, fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// Create model
 	model := NewConfigEditor(config)
+	model.offerRestart = options.OfferRestart
+	model.validate = options.Validate
 
 	// Create program
 	p := tea.NewProgram(model, tea.WithAltScreen())
 
 	// Run program
 	if _, err := p.Run(); err != nil {
-		return fmt.Errorf("failed to run config editor: %w", err)
+		return nil, false, fmt.Errorf("failed to run config editor: %w", err)
 	}
 
-	return nil
+	return model.SavedKeys(), model.restart, nil
 }
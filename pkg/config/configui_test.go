@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestConfigEditorCompactFitsTerminal(t *testing.T) {
+	const width, height = 60, 20
+
+	config := &EnvConfig{
+		FilePath:  "/home/researcher/projects/ddalab-deployment/production/.env",
+		LocalPath: "/home/researcher/projects/ddalab-deployment/production/.env.local",
+	}
+	for i := range 30 {
+		config.Variables = append(config.Variables, EnvVar{
+			Key:                fmt.Sprintf("DDALAB_SOME_RATHER_LONG_VARIABLE_NAME_%d", i),
+			Value:              strings.Repeat("value", 20),
+			Section:            "A Section With A Long Name",
+			IsRequired:         i%3 == 0,
+			IsSecret:           i%2 == 0,
+			ChangedFromDefault: true,
+			Description:        "A description long enough to need more than one line in a narrow terminal",
+		})
+	}
+	model := NewConfigEditor(config)
+	model.Update(tea.WindowSizeMsg{Width: width, Height: height})
+	model.cursor = 15
+
+	view := model.View()
+	if !strings.Contains(view, "DDALAB_SOM...") {
+		t.Fatalf("view shows no variables:\n%s", view)
+	}
+	lines := strings.Split(view, "\n")
+	if len(lines) > height {
+		t.Errorf("view has %d lines, want at most %d:\n%s", len(lines), height, view)
+	}
+	for i, line := range lines {
+		if w := lipgloss.Width(line); w > width {
+			t.Errorf("line %d is %d columns wide, want at most %d: %q", i+1, w, width, line)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// EncodingError reports a .env file that is not UTF-8 text, e.g. a binary
+// file or one saved in another encoding
+type EncodingError struct {
+	Path string
+	Line int
+}
+
+func (e *EncodingError) Error() string {
+	return fmt.Sprintf("%s line %d is not valid UTF-8 text - the file may be binary or saved in another encoding", e.Path, e.Line)
+}
+
+// CheckEnvAccess returns an error wrapping os.ErrPermission if the .env
+// file cannot be both read and written, so the editor is not opened on a
+// file it cannot load or save
+func CheckEnvAccess(path string) error {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// FixEnvPermissions makes the .env file readable and writable by its owner
+// only. It fails if the file belongs to another user.
+func FixEnvPermissions(path string) error {
+	if runtime.GOOS == "windows" {
+		return errors.New("file permissions must be changed in the file's properties on Windows")
+	}
+
+	if err := os.Chmod(path, privateFileMode); err != nil {
+		return fmt.Errorf("failed to change permissions of %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestLoadEnvFileWithoutPermission(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes do not restrict access on Windows")
+	}
+	if os.Geteuid() == 0 {
+		t.Skip("root can read files regardless of their mode")
+	}
+
+	envPath := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(envPath, []byte("A=1\n"), 0000); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CheckEnvAccess(envPath); !errors.Is(err, os.ErrPermission) {
+		t.Errorf("CheckEnvAccess error = %v, want a permission error", err)
+	}
+	config, err := LoadEnvFile(envPath)
+	if !errors.Is(err, os.ErrPermission) {
+		t.Errorf("LoadEnvFile error = %v, want a permission error", err)
+	}
+	if config != nil {
+		t.Errorf("LoadEnvFile returned %+v with the error", config)
+	}
+}
+
+func TestLoadEnvFileRejectsBinary(t *testing.T) {
+	tests := map[string][]byte{
+		"nul bytes":     []byte("A=1\nB=\x00\x01\x02\n"),
+		"invalid utf-8": []byte("A=1\nB=\xff\xfe\n"),
+		"no newlines":   bytes.Repeat([]byte{0x7f, 'E', 'L', 'F'}, 32*1024),
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			envPath := filepath.Join(t.TempDir(), ".env")
+			if err := os.WriteFile(envPath, content, 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			config, err := LoadEnvFile(envPath)
+			var encodingErr *EncodingError
+			if !errors.As(err, &encodingErr) {
+				t.Fatalf("LoadEnvFile error = %v, want an EncodingError", err)
+			}
+			if encodingErr.Path != envPath {
+				t.Errorf("EncodingError.Path = %q, want %q", encodingErr.Path, envPath)
+			}
+			if config != nil {
+				t.Errorf("LoadEnvFile returned %+v with the error", config)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EnvBackupDir is the directory next to the .env file holding its backups
+const EnvBackupDir = ".env-backups"
+
+// MaxEnvBackups is how many backups are kept per .env file
+const MaxEnvBackups = 20
+
+// envBackupTimeFormat is the timestamp in backup names. It avoids colons,
+// which Windows does not allow in file names, and has microseconds so two
+// saves within a second get their own backups.
+const envBackupTimeFormat = "2006-01-02T15-04-05.000000"
+
+// legacyEnvBackupTimeFormat is the timestamp of backups made before names
+// had microseconds
+const legacyEnvBackupTimeFormat = "2006-01-02T15-04-05"
+
+// EnvBackup is a saved copy of a .env file
+type EnvBackup struct {
+	Path string
+	Time time.Time
+}
+
+// envBackupPrefix returns the file name prefix of the backups of envPath,
+// e.g. ".env.bak."
+func envBackupPrefix(envPath string) string {
+	return filepath.Base(envPath) + ".bak."
+}
+
+// backupEnvFile copies envPath to a timestamped file in EnvBackupDir and
+// prunes the oldest backups beyond MaxEnvBackups
+func backupEnvFile(envPath string) error {
+	dir := filepath.Join(filepath.Dir(envPath), EnvBackupDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	// A backup is never overwritten, even if the clock repeats a timestamp
+	stamp := time.Now()
+	backupPath := filepath.Join(dir, envBackupPrefix(envPath)+stamp.Format(envBackupTimeFormat))
+	for _, err := os.Lstat(backupPath); err == nil; _, err = os.Lstat(backupPath) {
+		stamp = stamp.Add(time.Microsecond)
+		backupPath = filepath.Join(dir, envBackupPrefix(envPath)+stamp.Format(envBackupTimeFormat))
+	}
+	if err := copyFile(envPath, backupPath); err != nil {
+		return err
+	}
+
+	return pruneEnvBackups(envPath)
+}
+
+// pruneEnvBackups removes all but the newest MaxEnvBackups backups
+func pruneEnvBackups(envPath string) error {
+	backups, err := ListEnvBackups(envPath)
+	if err != nil {
+		return err
+	}
+
+	for _, backup := range backups[min(len(backups), MaxEnvBackups):] {
+		if err := os.Remove(backup.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListEnvBackups returns the backups of envPath, newest first
+func ListEnvBackups(envPath string) ([]EnvBackup, error) {
+	dir := filepath.Join(filepath.Dir(envPath), EnvBackupDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	prefix := envBackupPrefix(envPath)
+	var backups []EnvBackup
+	for _, entry := range entries {
+		stamp, ok := strings.CutPrefix(entry.Name(), prefix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		backupTime, err := time.ParseInLocation(envBackupTimeFormat, stamp, time.Local)
+		if err != nil {
+			backupTime, err = time.ParseInLocation(legacyEnvBackupTimeFormat, stamp, time.Local)
+		}
+		if err != nil {
+			continue
+		}
+		backups = append(backups, EnvBackup{Path: filepath.Join(dir, entry.Name()), Time: backupTime})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Time.After(backups[j].Time)
+	})
+	return backups, nil
+}
+
+// RestoreEnvBackup replaces envPath with the backup. The current file is
+// backed up first, so a restore can itself be undone.
+func RestoreEnvBackup(envPath string, backup EnvBackup) error {
+	if _, err := os.Stat(envPath); err == nil {
+		if err := backupEnvFile(envPath); err != nil {
+			return fmt.Errorf("failed to back up the current file: %w", err)
+		}
+	}
+
+	if err := copyFile(backup.Path, envPath); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", filepath.Base(backup.Path), err)
+	}
+	return nil
+}
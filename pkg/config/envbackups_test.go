@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBackupEnvFileKeepsSameSecondBackups(t *testing.T) {
+	envPath := filepath.Join(t.TempDir(), ".env")
+	for _, content := range []string{"A=1\n", "A=2\n", "A=3\n"} {
+		if err := os.WriteFile(envPath, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := backupEnvFile(envPath); err != nil {
+			t.Fatalf("backupEnvFile: %v", err)
+		}
+	}
+
+	backups, err := ListEnvBackups(envPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 3 {
+		t.Fatalf("got %d backups, want 3", len(backups))
+	}
+	newest, err := os.ReadFile(backups[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(newest) != "A=3\n" {
+		t.Errorf("newest backup = %q, want %q", newest, "A=3\n")
+	}
+}
+
+func TestListEnvBackupsReadsLegacyNames(t *testing.T) {
+	envPath := filepath.Join(t.TempDir(), ".env")
+	dir := filepath.Join(filepath.Dir(envPath), EnvBackupDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	legacy := filepath.Join(dir, ".env.bak.2024-06-01T10-30-05")
+	if err := os.WriteFile(legacy, []byte("A=0\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(envPath, []byte("A=1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := backupEnvFile(envPath); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := ListEnvBackups(envPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 || backups[1].Path != legacy {
+		t.Fatalf("backups = %+v, want the legacy backup listed last", backups)
+	}
+}
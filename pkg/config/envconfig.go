@@ -2,11 +2,13 @@ package config
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"unicode/utf8"
 )
 
 // EnvVar represents a single environment variable
@@ -18,17 +20,83 @@ type EnvVar struct {
 	IsRequired bool
 	IsSecret   bool
 	Example    string
+	// Set from the schema file, if any
+	Description string
+	Type        string   // See the Type constants, empty if unknown
+	Choices     []string // Allowed values, empty for any
+	// ChangedFromDefault is true when the value differs from .env.example
+	// (or the variable is not in the example at all)
+	ChangedFromDefault bool
+	// Set for variables from the .env.local overlay
+	Local     bool   // The value comes from .env.local and is saved there
+	Overrides bool   // .env sets the variable too, BaseValue is its value there
+	BaseValue string // The overridden .env value
 }
 
 // EnvConfig manages environment configuration
 type EnvConfig struct {
-	Variables []EnvVar
-	FilePath  string
-	Sections  []string
+	Variables  []EnvVar
+	FilePath   string
+	LocalPath  string // The .env.local overlay merged over FilePath, if any
+	Sections   []string
+	HasExample bool // A sibling .env.example was found and loaded
+
+	exampleValues map[string]string
+	schema        *EnvSchema // From a sibling env.schema.json, if any
 }
 
-// LoadEnvFile loads environment variables from a .env file
+// LoadEnvFile loads environment variables from a .env file and compares
+// them against a sibling .env.example, if one exists. A sibling .env.local
+// overlay, if present, is merged over them and its values win. A sibling
+// env.schema.json, if present, describes the variables instead of the
+// keyword heuristics.
 func LoadEnvFile(filePath string) (*EnvConfig, error) {
+	config, err := parseEnvFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := config.loadLocalOverlay(filePath); err != nil {
+		return nil, err
+	}
+	config.loadSiblingSchema(filePath)
+
+	examplePath := filepath.Join(filepath.Dir(filePath), ".env.example")
+	if filepath.Clean(examplePath) == filepath.Clean(filePath) {
+		return config, nil
+	}
+
+	if example, err := parseEnvFile(examplePath); err == nil {
+		config.applyExample(example)
+	}
+
+	return config, nil
+}
+
+// applyExample records example values and marks customized variables
+func (c *EnvConfig) applyExample(example *EnvConfig) {
+	c.HasExample = true
+	c.exampleValues = make(map[string]string, len(example.Variables))
+	for _, envVar := range example.Variables {
+		c.exampleValues[envVar.Key] = envVar.Value
+	}
+
+	for i := range c.Variables {
+		c.Variables[i].Example = c.exampleValues[c.Variables[i].Key]
+		c.Variables[i].ChangedFromDefault = c.isChangedFromDefault(c.Variables[i].Key, c.Variables[i].Value)
+	}
+}
+
+// isChangedFromDefault reports whether a value differs from the example
+func (c *EnvConfig) isChangedFromDefault(key, value string) bool {
+	if !c.HasExample {
+		return false
+	}
+	exampleValue, exists := c.exampleValues[key]
+	return !exists || exampleValue != value
+}
+
+// parseEnvFile parses a .env-style file without consulting any example
+func parseEnvFile(filePath string) (*EnvConfig, error) {
 	config := &EnvConfig{
 		FilePath:  filePath,
 		Variables: make([]EnvVar, 0),
@@ -48,7 +116,11 @@ func LoadEnvFile(filePath string) (*EnvConfig, error) {
 
 	for scanner.Scan() {
 		lineNumber++
-		line := strings.TrimSpace(scanner.Text())
+		raw := scanner.Text()
+		if !utf8.ValidString(raw) || strings.ContainsRune(raw, 0) {
+			return nil, &EncodingError{Path: filePath, Line: lineNumber}
+		}
+		line := strings.TrimSpace(raw)
 
 		// Skip empty lines
 		if line == "" {
@@ -94,13 +166,12 @@ func LoadEnvFile(filePath string) (*EnvConfig, error) {
 				}
 
 				envVar := EnvVar{
-					Key:        key,
-					Value:      value,
-					Comment:    currentComment,
-					Section:    currentSection,
-					IsRequired: isRequiredVar(key, value),
-					IsSecret:   isSecretVar(key),
+					Key:     key,
+					Value:   value,
+					Comment: currentComment,
+					Section: currentSection,
 				}
+				config.describe(&envVar)
 
 				config.Variables = append(config.Variables, envVar)
 				currentComment = ""
@@ -109,6 +180,10 @@ func LoadEnvFile(filePath string) (*EnvConfig, error) {
 	}
 
 	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			// Binary files rarely contain line breaks
+			return nil, &EncodingError{Path: filePath, Line: lineNumber + 1}
+		}
 		return nil, fmt.Errorf("error reading env file: %w", err)
 	}
 
@@ -118,15 +193,29 @@ func LoadEnvFile(filePath string) (*EnvConfig, error) {
 	return config, nil
 }
 
-// SaveEnvFile saves the environment configuration back to file
+// SaveEnvFile saves the environment configuration back to file. With a
+// .env.local overlay, each variable is written back to the file it came
+// from; new variables go to .env.
 func (c *EnvConfig) SaveEnvFile() error {
-	// Create backup
-	backupPath := c.FilePath + ".backup"
-	if err := copyFile(c.FilePath, backupPath); err != nil {
+	if c.LocalPath == "" {
+		return c.writeEnvFile(c.FilePath, c.Variables)
+	}
+
+	base, local := c.splitByOrigin()
+	if err := c.writeEnvFile(c.FilePath, base); err != nil {
+		return err
+	}
+	return c.writeEnvFile(c.LocalPath, local)
+}
+
+// writeEnvFile writes variables to path, grouped by section
+func (c *EnvConfig) writeEnvFile(path string, variables []EnvVar) error {
+	// Keep the previous version in the backup history
+	if err := backupEnvFile(path); err != nil {
 		return fmt.Errorf("failed to create backup: %w", err)
 	}
 
-	file, err := os.Create(c.FilePath)
+	file, err := os.Create(path)
 	if err != nil {
 		return fmt.Errorf("failed to create env file: %w", err)
 	}
@@ -142,7 +231,7 @@ func (c *EnvConfig) SaveEnvFile() error {
 
 	// Group variables by section
 	sectionVars := make(map[string][]EnvVar)
-	for _, envVar := range c.Variables {
+	for _, envVar := range variables {
 		section := envVar.Section
 		if section == "" {
 			section = "General"
@@ -211,6 +300,7 @@ func (c *EnvConfig) UpdateVariable(key, newValue string) bool {
 	for i, envVar := range c.Variables {
 		if envVar.Key == key {
 			c.Variables[i].Value = newValue
+			c.Variables[i].ChangedFromDefault = c.isChangedFromDefault(key, newValue)
 			return true
 		}
 	}
@@ -219,13 +309,24 @@ func (c *EnvConfig) UpdateVariable(key, newValue string) bool {
 
 // AddVariable adds a new environment variable
 func (c *EnvConfig) AddVariable(envVar EnvVar) {
+	if c.schema != nil {
+		c.describe(&envVar)
+	}
+	envVar.ChangedFromDefault = c.isChangedFromDefault(envVar.Key, envVar.Value)
 	c.Variables = append(c.Variables, envVar)
 }
 
-// RemoveVariable removes an environment variable
+// RemoveVariable removes an environment variable. Removing an override
+// from .env.local makes the .env value effective again.
 func (c *EnvConfig) RemoveVariable(key string) bool {
 	for i, envVar := range c.Variables {
 		if envVar.Key == key {
+			if envVar.Overrides {
+				c.Variables[i].Local, c.Variables[i].Overrides = false, false
+				c.Variables[i].Value, c.Variables[i].BaseValue = envVar.BaseValue, ""
+				c.Variables[i].ChangedFromDefault = c.isChangedFromDefault(key, envVar.BaseValue)
+				return true
+			}
 			c.Variables = append(c.Variables[:i], c.Variables[i+1:]...)
 			return true
 		}
@@ -235,6 +336,14 @@ func (c *EnvConfig) RemoveVariable(key string) bool {
 
 // Helper functions
 
+// knownChoices lists the allowed values of well-known variables that have
+// a fixed set, used when no schema describes them
+var knownChoices = map[string][]string{
+	"LOG_LEVEL": {"debug", "info", "warn", "error"},
+	"SCHEME":    {"http", "https"},
+	"NODE_ENV":  {"development", "production", "test"},
+}
+
 func isRequiredVar(key, value string) bool {
 	requiredVars := []string{
 		"DB_PASSWORD", "MINIO_ROOT_PASSWORD", "JWT_SECRET_KEY",
@@ -248,6 +357,11 @@ func isRequiredVar(key, value string) bool {
 	}
 
 	// Check for placeholder values
+	return hasPlaceholderValue(value)
+}
+
+// hasPlaceholderValue reports whether a value is a template placeholder
+func hasPlaceholderValue(value string) bool {
 	placeholders := []string{
 		"CHANGE_ME", "GENERATE_WITH", "YOUR_", "EXAMPLE_",
 	}
@@ -262,7 +376,9 @@ func isRequiredVar(key, value string) bool {
 	return false
 }
 
-func isSecretVar(key string) bool {
+// IsSecretVar reports whether key names a credential whose value must not
+// be shown or logged in plain text
+func IsSecretVar(key string) bool {
 	secretKeys := []string{
 		"PASSWORD", "SECRET", "KEY", "TOKEN", "BIND_PASSWORD",
 	}
@@ -277,6 +393,34 @@ func isSecretVar(key string) bool {
 	return false
 }
 
+// IsRestartSensitiveVar reports whether changing key only takes effect after
+// DDALAB services are restarted (credentials, connection and network settings)
+func IsRestartSensitiveVar(key string) bool {
+	sensitivePatterns := []string{
+		"PASSWORD", "SECRET", "KEY", "TOKEN", "USER",
+		"DB_", "POSTGRES", "REDIS", "MINIO",
+		"HOST", "PORT", "DOMAIN", "URL",
+	}
+
+	upperKey := strings.ToUpper(key)
+	for _, pattern := range sensitivePatterns {
+		if strings.Contains(upperKey, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsUnsafeWhileRunningVar reports whether changing key while services are
+// running can break them (e.g. database credentials already in use)
+func IsUnsafeWhileRunningVar(key string) bool {
+	upperKey := strings.ToUpper(key)
+	return strings.Contains(upperKey, "PASSWORD") &&
+		(strings.Contains(upperKey, "DB_") || strings.Contains(upperKey, "POSTGRES") ||
+			strings.Contains(upperKey, "MINIO") || strings.Contains(upperKey, "REDIS"))
+}
+
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
@@ -286,6 +430,8 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
+// copyFile copies src to dst. Env files hold credentials, so dst is only
+// readable by its owner, even if it already existed with a wider mode.
 func copyFile(src, dst string) error {
 	sourceFile, err := os.Open(src)
 	if err != nil {
@@ -293,12 +439,16 @@ func copyFile(src, dst string) error {
 	}
 	defer sourceFile.Close()
 
-	destFile, err := os.Create(dst)
+	destFile, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, privateFileMode)
 	if err != nil {
 		return err
 	}
 	defer destFile.Close()
 
+	if err := restrictPermissions(dst); err != nil {
+		return err
+	}
+
 	_, err = destFile.ReadFrom(sourceFile)
 	return err
 }
@@ -310,11 +460,25 @@ func CopyFile(src, dst string) error {
 
 // GetEnvFilePath finds the .env file in the DDALAB installation
 func GetEnvFilePath(ddalabPath string) (string, error) {
+	return GetEnvFilePathFor(ddalabPath, "")
+}
+
+// GetEnvFilePathFor finds the .env file of the named environment. With no
+// environment the common locations are searched in order.
+func GetEnvFilePathFor(ddalabPath, environment string) (string, error) {
+	dirs := []string{
+		ddalabPath,
+		filepath.Join(ddalabPath, "ddalab-deploy"),
+		filepath.Join(ddalabPath, "deployments", "development-local"),
+	}
+	if environment != "" {
+		dirs = []string{environmentDir(ddalabPath, environment)}
+	}
+
 	// Try common locations for .env file
-	candidates := []string{
-		filepath.Join(ddalabPath, ".env"),
-		filepath.Join(ddalabPath, "ddalab-deploy", ".env"),
-		filepath.Join(ddalabPath, "deployments", "development-local", ".env"),
+	candidates := make([]string, len(dirs))
+	for i, dir := range dirs {
+		candidates[i] = filepath.Join(dir, ".env")
 	}
 
 	for _, candidate := range candidates {
@@ -324,10 +488,9 @@ func GetEnvFilePath(ddalabPath string) (string, error) {
 	}
 
 	// If no .env file exists, try to find .env.example and suggest copying
-	exampleCandidates := []string{
-		filepath.Join(ddalabPath, ".env.example"),
-		filepath.Join(ddalabPath, "ddalab-deploy", ".env.example"),
-		filepath.Join(ddalabPath, "deployments", "development-local", ".env.example"),
+	exampleCandidates := make([]string, len(dirs))
+	for i, dir := range dirs {
+		exampleCandidates[i] = filepath.Join(dir, ".env.example")
 	}
 
 	for _, candidate := range exampleCandidates {
@@ -0,0 +1,141 @@
+package config
+
+import "strings"
+
+// ResolveValue returns the value of key with ${VAR} and $VAR references to
+// other variables in the file expanded, following docker-compose
+// interpolation rules ($$ escapes, ${VAR:-default} and ${VAR-default}).
+// References that form a cycle are left unexpanded. The stored value is
+// never modified, so saving preserves the original template.
+func (c *EnvConfig) ResolveValue(key string) string {
+	value, _ := c.lookupResolved(key, make(map[string]bool))
+	return value
+}
+
+// HasReferences reports whether a raw value contains variable references
+func HasReferences(value string) bool {
+	return strings.Contains(strings.ReplaceAll(value, "$$", ""), "$")
+}
+
+// lookupResolved finds a variable and expands its value. The bool result is
+// false when the variable is not defined in the file.
+func (c *EnvConfig) lookupResolved(key string, visiting map[string]bool) (string, bool) {
+	if visiting[key] {
+		return "${" + key + "}", true
+	}
+
+	for _, envVar := range c.Variables {
+		if envVar.Key == key {
+			visiting[key] = true
+			value := c.expand(envVar.Value, visiting)
+			delete(visiting, key)
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// expand replaces all variable references in value
+func (c *EnvConfig) expand(value string, visiting map[string]bool) string {
+	var b strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		if ch != '$' || i+1 >= len(value) {
+			b.WriteByte(ch)
+			continue
+		}
+
+		next := value[i+1]
+		switch {
+		case next == '$':
+			// $$ is an escaped literal dollar sign
+			b.WriteByte('$')
+			i++
+
+		case next == '{':
+			end := closingBrace(value, i+2)
+			if end < 0 {
+				// Unterminated reference, keep the rest as-is
+				b.WriteString(value[i:])
+				return b.String()
+			}
+			b.WriteString(c.expandExpression(value[i+2:end], visiting))
+			i = end
+
+		case isVarNameStart(next):
+			j := i + 1
+			for j < len(value) && isVarNameChar(value[j]) {
+				j++
+			}
+			resolved, _ := c.lookupResolved(value[i+1:j], visiting)
+			b.WriteString(resolved)
+			i = j - 1
+
+		default:
+			b.WriteByte(ch)
+		}
+	}
+
+	return b.String()
+}
+
+// closingBrace returns the index of the } that closes the ${ reference
+// whose contents start at start, skipping nested references and $$
+// escapes, or -1 if the reference is not closed
+func closingBrace(value string, start int) int {
+	depth := 1
+	for i := start; i < len(value); i++ {
+		switch {
+		case strings.HasPrefix(value[i:], "$$"):
+			i++
+		case strings.HasPrefix(value[i:], "${"):
+			depth++
+			i++
+		case value[i] == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// expandExpression expands the contents of a ${...} reference
+func (c *EnvConfig) expandExpression(expr string, visiting map[string]bool) string {
+	// The operator follows the name; the default may hold references itself
+	n := 0
+	for n < len(expr) && isVarNameChar(expr[n]) {
+		n++
+	}
+	name, rest := expr[:n], expr[n:]
+
+	switch {
+	case strings.HasPrefix(rest, ":-"):
+		// ${VAR:-default} uses the default when VAR is unset or empty
+		if resolved, ok := c.lookupResolved(name, visiting); ok && resolved != "" {
+			return resolved
+		}
+		return c.expand(rest[2:], visiting)
+
+	case strings.HasPrefix(rest, "-"):
+		// ${VAR-default} uses the default only when VAR is unset
+		if resolved, ok := c.lookupResolved(name, visiting); ok {
+			return resolved
+		}
+		return c.expand(rest[1:], visiting)
+	}
+
+	resolved, _ := c.lookupResolved(expr, visiting)
+	return resolved
+}
+
+func isVarNameStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isVarNameChar(ch byte) bool {
+	return isVarNameStart(ch) || (ch >= '0' && ch <= '9')
+}
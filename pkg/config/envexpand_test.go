@@ -0,0 +1,41 @@
+package config
+
+import "testing"
+
+func TestResolveValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", "$B/x", "b/x"},
+		{"braced", "${B}x", "bx"},
+		{"unset", "[${UNSET}]", "[]"},
+		{"default for unset", "${UNSET:-d}", "d"},
+		{"default for empty", "${EMPTY:-d}", "d"},
+		{"set ignores default", "${A:-d}", "a"},
+		{"dash default skips empty", "[${EMPTY-d}]", "[]"},
+		{"dash default for unset", "${UNSET-d}", "d"},
+		{"nested default", "${UNSET:-${B}}", "b"},
+		{"nested default for empty", "${EMPTY:-${B}}/x", "b/x"},
+		{"nested default unused", "${A:-${B}}", "a"},
+		{"doubly nested", "${UNSET:-${EMPTY:-${B}}}", "b"},
+		{"nested in dash default", "${UNSET-${EMPTY:-d}}", "d"},
+		{"nested unset", "[${UNSET:-${ALSO_UNSET}}]", "[]"},
+		{"escaped", "$${B}", "${B}"},
+		{"escaped in default", "${UNSET:-$${B}}", "${B}"},
+		{"escaped brace in default", "${UNSET:-x$$}y", "x$y"},
+		{"unterminated", "${UNSET:-${B}", "${UNSET:-${B}"},
+	}
+	for _, tt := range tests {
+		env := &EnvConfig{Variables: []EnvVar{
+			{Key: "A", Value: "a"},
+			{Key: "B", Value: "b"},
+			{Key: "EMPTY", Value: ""},
+			{Key: "X", Value: tt.value},
+		}}
+		if got := env.ResolveValue("X"); got != tt.want {
+			t.Errorf("%s: ResolveValue(%q) = %q, want %q", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
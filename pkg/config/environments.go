@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ddalab/launcher/pkg/compose"
+)
+
+// DefaultEnvironment is the name of the deployment at the installation root
+const DefaultEnvironment = "default"
+
+// Environment is a deployment directory inside a DDALAB installation with
+// its own compose file and .env
+type Environment struct {
+	Name string // "default", "ddalab-deploy" or the name under deployments/
+	Dir  string // Absolute directory
+}
+
+// environmentDir returns the directory of the named environment
+func environmentDir(ddalabPath, name string) string {
+	switch name {
+	case "", DefaultEnvironment:
+		return ddalabPath
+	case "ddalab-deploy":
+		return filepath.Join(ddalabPath, "ddalab-deploy")
+	default:
+		return filepath.Join(ddalabPath, "deployments", name)
+	}
+}
+
+// isDeploymentDir returns true if dir contains a compose file or .env
+func isDeploymentDir(dir string) bool {
+	for _, name := range []string{"docker-compose.yml", ".env", ".env.example"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// FindEnvironments lists the deployment directories of an installation:
+// the installation root, ddalab-deploy and each directory under
+// deployments/ that contains a compose file or .env
+func FindEnvironments(ddalabPath string) []Environment {
+	var environments []Environment
+
+	for _, name := range []string{DefaultEnvironment, "ddalab-deploy"} {
+		if dir := environmentDir(ddalabPath, name); isDeploymentDir(dir) {
+			environments = append(environments, Environment{Name: name, Dir: dir})
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Join(ddalabPath, "deployments"))
+	if err != nil {
+		return environments
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if dir := environmentDir(ddalabPath, entry.Name()); isDeploymentDir(dir) {
+			environments = append(environments, Environment{Name: entry.Name(), Dir: dir})
+		}
+	}
+
+	return environments
+}
+
+// GetEnvironment returns the selected environment name ("" for automatic
+// selection)
+func (cm *ConfigManager) GetEnvironment() string {
+	return cm.config.Environment
+}
+
+// SetEnvironment selects the environment the launcher operates on
+func (cm *ConfigManager) SetEnvironment(name string) error {
+	if name != "" && cm.config.DDALABPath != "" && !isDeploymentDir(environmentDir(cm.config.DDALABPath, name)) {
+		return fmt.Errorf("environment '%s' not found in %s", name, cm.config.DDALABPath)
+	}
+	cm.config.Environment = name
+	return nil
+}
+
+// EnvironmentDir returns the directory of the selected environment, or the
+// installation root when none is selected
+func (cm *ConfigManager) EnvironmentDir() string {
+	return environmentDir(cm.config.DDALABPath, cm.config.Environment)
+}
+
+// EnvFilePath returns the .env file of the selected environment
+func (cm *ConfigManager) EnvFilePath() (string, error) {
+	return GetEnvFilePathFor(cm.config.DDALABPath, cm.config.Environment)
+}
+
+// ComposeProject returns the compose project of the selected deployment:
+// the directory holding its .env and the project name from
+// COMPOSE_PROJECT_NAME, falling back to compose's default. Compose does
+// not read a .env.local overlay by itself, so both files are passed then.
+func (cm *ConfigManager) ComposeProject() compose.Project {
+	dir := cm.EnvironmentDir()
+
+	envPath, err := cm.EnvFilePath()
+	if err != nil {
+		return compose.NewProject(dir, "")
+	}
+	dir = filepath.Dir(envPath)
+
+	envConfig, err := LoadEnvFile(envPath)
+	if err != nil {
+		return compose.NewProject(dir, "")
+	}
+	project := compose.NewProject(dir, envConfig.ResolveValue("COMPOSE_PROJECT_NAME"))
+	if envConfig.LocalPath != "" {
+		project.EnvFiles = []string{envPath, envConfig.LocalPath}
+	}
+	return project
+}
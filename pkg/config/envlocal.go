@@ -0,0 +1,104 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"sort"
+)
+
+// LocalEnvSuffix names the overlay beside a .env file: machine-specific
+// overrides in ".env.local" win over the values in ".env"
+const LocalEnvSuffix = ".local"
+
+// localEnvPath returns the path of the overlay of the env file at filePath
+func localEnvPath(filePath string) string {
+	return filePath + LocalEnvSuffix
+}
+
+// loadLocalOverlay merges the sibling .env.local, if one exists, into the
+// variables. A missing overlay is not an error.
+func (c *EnvConfig) loadLocalOverlay(filePath string) error {
+	local, err := parseEnvFile(localEnvPath(filePath))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	c.applyLocal(local)
+	return nil
+}
+
+// applyLocal layers the overlay's variables over the .env ones. A variable
+// set in both keeps its place, comment and section from .env unless the
+// overlay gives its own.
+func (c *EnvConfig) applyLocal(local *EnvConfig) {
+	c.LocalPath = local.FilePath
+
+	for _, localVar := range local.Variables {
+		localVar.Local = true
+
+		i := c.indexOf(localVar.Key)
+		if i < 0 {
+			c.Variables = append(c.Variables, localVar)
+			continue
+		}
+
+		base := c.Variables[i]
+		localVar.Overrides = true
+		localVar.BaseValue = base.Value
+		if localVar.Comment == "" {
+			localVar.Comment = base.Comment
+		}
+		if localVar.Section == "" {
+			localVar.Section = base.Section
+		}
+		c.Variables[i] = localVar
+	}
+
+	for _, section := range local.Sections {
+		if !contains(c.Sections, section) {
+			c.Sections = append(c.Sections, section)
+		}
+	}
+	sort.Strings(c.Sections)
+}
+
+// indexOf returns the index of the variable named key, or -1
+func (c *EnvConfig) indexOf(key string) int {
+	for i, envVar := range c.Variables {
+		if envVar.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Origin returns the file the effective value of envVar comes from, e.g.
+// ".env.local" or ".env"
+func (c *EnvConfig) Origin(envVar EnvVar) string {
+	if envVar.Local && c.LocalPath != "" {
+		return c.LocalPath
+	}
+	return c.FilePath
+}
+
+// splitByOrigin returns the variables to write to .env and to .env.local.
+// An overridden variable is written to both: its .env value stays as it
+// was loaded, the overlay holds the effective one.
+func (c *EnvConfig) splitByOrigin() (base, local []EnvVar) {
+	for _, envVar := range c.Variables {
+		if !envVar.Local {
+			base = append(base, envVar)
+			continue
+		}
+
+		local = append(local, envVar)
+		if envVar.Overrides {
+			baseVar := envVar
+			baseVar.Value = envVar.BaseValue
+			base = append(base, baseVar)
+		}
+	}
+	return base, local
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Replacement is the change search-and-replace makes to one variable
+type Replacement struct {
+	Key      string
+	OldValue string
+	NewValue string
+	IsSecret bool
+}
+
+// PlanReplace returns the changes replacing find with replacement in every
+// value would make, without applying them. With useRegex, find is a regular
+// expression and replacement may refer to groups as $1 or ${name}.
+func (c *EnvConfig) PlanReplace(find, replacement string, useRegex bool) ([]Replacement, error) {
+	if find == "" {
+		return nil, fmt.Errorf("nothing to find")
+	}
+
+	replace := func(value string) string {
+		return strings.ReplaceAll(value, find, replacement)
+	}
+	if useRegex {
+		pattern, err := regexp.Compile(find)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %w", err)
+		}
+		replace = func(value string) string {
+			return pattern.ReplaceAllString(value, replacement)
+		}
+	}
+
+	var plan []Replacement
+	for _, envVar := range c.Variables {
+		if newValue := replace(envVar.Value); newValue != envVar.Value {
+			plan = append(plan, Replacement{
+				Key:      envVar.Key,
+				OldValue: envVar.Value,
+				NewValue: newValue,
+				IsSecret: envVar.IsSecret,
+			})
+		}
+	}
+	return plan, nil
+}
+
+// ApplyReplacements sets the new values of a plan from PlanReplace
+func (c *EnvConfig) ApplyReplacements(plan []Replacement) {
+	for _, change := range plan {
+		c.UpdateVariable(change.Key, change.NewValue)
+	}
+}
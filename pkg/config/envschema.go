@@ -0,0 +1,117 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SchemaFileName is the optional file next to the .env file that describes
+// its variables
+const SchemaFileName = "env.schema.json"
+
+// Variable types known to the schema
+const (
+	TypeString = "string"
+	TypeNumber = "number"
+	TypePort   = "port"
+	TypeBool   = "bool"
+	TypeURL    = "url"
+)
+
+// EnvSchema describes the variables of a .env file, e.g.
+//
+//	{"variables": {"LOG_LEVEL": {"description": "Log verbosity",
+//	  "type": "string", "enum": ["debug", "info", "warn", "error"]}}}
+type EnvSchema struct {
+	Variables map[string]VarSchema `json:"variables"`
+}
+
+// VarSchema describes a single variable
+type VarSchema struct {
+	Description string   `json:"description"`
+	Type        string   `json:"type"` // string (default), number, port, bool or url
+	Required    bool     `json:"required"`
+	Secret      bool     `json:"secret"`
+	Enum        []string `json:"enum"` // Allowed values, empty for any
+}
+
+// LoadEnvSchema reads a schema file
+func LoadEnvSchema(path string) (*EnvSchema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema EnvSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("invalid schema %s: %w", path, err)
+	}
+	return &schema, nil
+}
+
+// loadSiblingSchema loads the schema next to filePath, if there is one. A
+// broken schema is reported and ignored so the .env file stays editable.
+func (c *EnvConfig) loadSiblingSchema(filePath string) {
+	schema, err := LoadEnvSchema(filepath.Join(filepath.Dir(filePath), SchemaFileName))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Warning: ignoring env schema: %v", err)
+		}
+		return
+	}
+	c.applySchema(schema)
+}
+
+// applySchema replaces the keyword heuristics with the schema's metadata
+// for every variable it describes
+func (c *EnvConfig) applySchema(schema *EnvSchema) {
+	c.schema = schema
+	for i := range c.Variables {
+		c.describe(&c.Variables[i])
+	}
+}
+
+// describe sets the metadata of envVar from the schema or, for variables
+// the schema does not know, from the keyword heuristics
+func (c *EnvConfig) describe(envVar *EnvVar) {
+	if c.schema != nil {
+		if varSchema, ok := c.schema.Variables[envVar.Key]; ok {
+			envVar.IsRequired = varSchema.Required
+			envVar.IsSecret = varSchema.Secret
+			envVar.Type = varSchema.Type
+			envVar.Description = varSchema.Description
+			envVar.Choices = varSchema.Enum
+			return
+		}
+	}
+
+	envVar.IsRequired = isRequiredVar(envVar.Key, envVar.Value)
+	envVar.IsSecret = IsSecretVar(envVar.Key)
+	envVar.Choices = knownChoices[envVar.Key]
+	if !envVar.IsSecret {
+		envVar.Type = inferType(envVar.Key, envVar.Value)
+	}
+}
+
+// inferType guesses the type of a variable the schema does not describe:
+// *_PORT keys are ports and other all-digit values are numbers
+func inferType(key, value string) string {
+	upperKey := strings.ToUpper(key)
+	switch {
+	case upperKey == "PORT" || strings.HasSuffix(upperKey, "_PORT"):
+		return TypePort
+	case isDigits(value):
+		return TypeNumber
+	default:
+		return ""
+	}
+}
+
+// HasSchema reports whether the variables are described by a schema file
+func (c *EnvConfig) HasSchema() bool {
+	return c.schema != nil
+}
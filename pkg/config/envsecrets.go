@@ -0,0 +1,63 @@
+package config
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// secretBytes is the amount of random data used for generated secrets
+const secretBytes = 32
+
+// SecureDefaultsResult summarizes the changes made by SecureDefaults
+type SecureDefaultsResult struct {
+	// Generated lists secret variables that received a fresh random value
+	Generated []string
+	// NeedsAttention lists required variables that still hold placeholders
+	// but cannot be generated (e.g. DOMAIN) and must be set by hand
+	NeedsAttention []string
+}
+
+// SecureDefaults replaces placeholder values (CHANGE_ME, GENERATE_WITH, ...)
+// of secret variables with freshly generated strong secrets. Required
+// non-secret variables that still hold placeholders are reported but left
+// untouched. The caller is responsible for saving the file.
+func (c *EnvConfig) SecureDefaults() (*SecureDefaultsResult, error) {
+	result := &SecureDefaultsResult{}
+
+	for i, envVar := range c.Variables {
+		needsValue := hasPlaceholderValue(envVar.Value) ||
+			(envVar.Value == "" && envVar.IsRequired && envVar.IsSecret)
+		if !needsValue {
+			continue
+		}
+
+		if !envVar.IsSecret {
+			if envVar.IsRequired {
+				result.NeedsAttention = append(result.NeedsAttention, envVar.Key)
+			}
+			continue
+		}
+
+		secret, err := GenerateSecret()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate value for %s: %w", envVar.Key, err)
+		}
+
+		c.Variables[i].Value = secret
+		c.describe(&c.Variables[i])
+		c.Variables[i].ChangedFromDefault = c.isChangedFromDefault(envVar.Key, secret)
+		result.Generated = append(result.Generated, envVar.Key)
+	}
+
+	return result, nil
+}
+
+// GenerateSecret returns a cryptographically random hex-encoded secret
+func GenerateSecret() (string, error) {
+	buf := make([]byte, secretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Issue returns why the variable's value needs attention, or "" if it is
+// fine. Required variables must not be empty, no variable may keep a
+// template placeholder such as CHANGE_ME, and variables with choices must
+// hold one of them.
+func (v EnvVar) Issue() string {
+	switch {
+	case hasPlaceholderValue(v.Value):
+		return "still a placeholder"
+	case v.IsRequired && v.Value == "":
+		return "required but empty"
+	default:
+		return v.ValidateValue(v.Value)
+	}
+}
+
+// Port range accepted for port variables
+const (
+	minPort = 1
+	maxPort = 65535
+)
+
+// ValidateValue checks a candidate value against the variable's
+// constraints and returns the problem, or "" if it is acceptable. An empty
+// value is left to the required check, and values with ${VAR} references
+// are only checked once resolved.
+func (v EnvVar) ValidateValue(value string) string {
+	if value == "" || HasReferences(value) {
+		return ""
+	}
+	if len(v.Choices) > 0 && choiceIndex(v.Choices, value) < 0 {
+		return fmt.Sprintf("must be one of %s", strings.Join(v.Choices, ", "))
+	}
+
+	switch v.Type {
+	case TypeNumber:
+		if !isDigits(value) {
+			return "must be a whole number"
+		}
+	case TypePort:
+		if port, err := strconv.Atoi(value); err != nil || port < minPort || port > maxPort {
+			return fmt.Sprintf("must be a port between %d and %d", minPort, maxPort)
+		}
+	case TypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return "must be true or false"
+		}
+	case TypeURL:
+		if parsed, err := url.Parse(value); err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return "must be a URL such as https://example.com"
+		}
+	}
+	return ""
+}
+
+// IsNumeric reports whether the variable holds a number or a port
+func (v EnvVar) IsNumeric() bool {
+	return v.Type == TypeNumber || v.Type == TypePort
+}
+
+// Step returns the value incremented by delta, clamped to the valid range
+// of the variable's type. Non-numeric values are returned unchanged.
+func (v EnvVar) Step(value string, delta int) string {
+	if !v.IsNumeric() || (value != "" && !isDigits(value)) {
+		return value
+	}
+
+	n, _ := strconv.Atoi(value)
+	n += delta
+
+	low, high := 0, math.MaxInt32
+	if v.Type == TypePort {
+		low, high = minPort, maxPort
+	}
+	return strconv.Itoa(max(low, min(high, n)))
+}
+
+// InvalidVariables returns the variables whose values break their type or
+// choice constraints. Unlike Issues, placeholders and empty required values
+// are not included, since they do not keep the file from being saved.
+func (c *EnvConfig) InvalidVariables() []EnvVar {
+	var invalid []EnvVar
+	for _, envVar := range c.Variables {
+		if envVar.ValidateValue(envVar.Value) != "" {
+			invalid = append(invalid, envVar)
+		}
+	}
+	return invalid
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// choiceIndex returns the index of value in choices, ignoring case since
+// most services accept e.g. both "info" and "INFO", or -1
+func choiceIndex(choices []string, value string) int {
+	return slices.IndexFunc(choices, func(choice string) bool {
+		return strings.EqualFold(choice, value)
+	})
+}
+
+// Issues returns the variables whose values need attention, in file order
+func (c *EnvConfig) Issues() []EnvVar {
+	var issues []EnvVar
+	for _, envVar := range c.Variables {
+		if envVar.Issue() != "" {
+			issues = append(issues, envVar)
+		}
+	}
+	return issues
+}
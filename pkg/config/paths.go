@@ -0,0 +1,130 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// appDirName is the directory name used below the XDG base directories
+const appDirName = "ddalab-launcher"
+
+// configExtensions lists the supported config file suffixes in lookup order
+var configExtensions = []string{"", ".toml", ".yaml", ".yml"}
+
+// DefaultConfigPath returns the launcher config file to use.
+//
+// On Linux the file lives in $XDG_CONFIG_HOME/ddalab-launcher (default
+// ~/.config/ddalab-launcher) as config.json, config.toml, config.yaml or
+// config.yml. A legacy ~/.ddalab-launcher file is migrated there on first
+// use. Other platforms keep using ~/.ddalab-launcher (JSON), or an existing
+// .toml, .yaml or .yml variant of it.
+func DefaultConfigPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	legacyPath := findConfigFile(filepath.Join(homeDir, ".ddalab-launcher"), "")
+	if runtime.GOOS != "linux" {
+		if legacyPath != "" {
+			return legacyPath, nil
+		}
+		return filepath.Join(homeDir, ".ddalab-launcher"), nil
+	}
+
+	configDir := xdgDir("XDG_CONFIG_HOME", filepath.Join(homeDir, ".config"))
+	if path := findConfigFile(filepath.Join(configDir, "config"), ".json"); path != "" {
+		return path, nil
+	}
+
+	if legacyPath != "" {
+		migrated, err := migrateLegacyConfig(legacyPath, configDir)
+		if err != nil {
+			// Keep working with the legacy file rather than losing settings
+			return legacyPath, nil
+		}
+		return migrated, nil
+	}
+
+	return filepath.Join(configDir, "config.json"), nil
+}
+
+// CacheDir returns the directory for launcher caches and logs, creating it
+// if needed. On Linux this honors $XDG_CACHE_HOME (default
+// ~/.cache/ddalab-launcher); elsewhere the OS user cache directory is used.
+func CacheDir() (string, error) {
+	var cacheDir string
+	if runtime.GOOS == "linux" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		cacheDir = xdgDir("XDG_CACHE_HOME", filepath.Join(homeDir, ".cache"))
+	} else {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		cacheDir = filepath.Join(userCacheDir, appDirName)
+	}
+
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return cacheDir, nil
+}
+
+// xdgDir returns the launcher directory below the XDG base directory named
+// by envVar. Relative values are invalid per the spec and are ignored.
+func xdgDir(envVar, fallback string) string {
+	base := os.Getenv(envVar)
+	if base == "" || !filepath.IsAbs(base) {
+		base = fallback
+	}
+	return filepath.Join(base, appDirName)
+}
+
+// findConfigFile returns the first existing config file named base plus one
+// of the supported extensions. jsonExt is the suffix used for JSON files.
+func findConfigFile(base, jsonExt string) string {
+	for _, ext := range configExtensions {
+		if ext == "" {
+			ext = jsonExt
+		}
+		if _, err := os.Stat(base + ext); err == nil {
+			return base + ext
+		}
+	}
+	return ""
+}
+
+// migrateLegacyConfig moves a ~/.ddalab-launcher config into configDir,
+// keeping its format, and returns the new path
+func migrateLegacyConfig(legacyPath, configDir string) (string, error) {
+	ext := filepath.Ext(legacyPath)
+	if ext == "" || ext == ".ddalab-launcher" {
+		ext = ".json"
+	}
+	newPath := filepath.Join(configDir, "config"+ext)
+
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	// Rename fails across filesystems; fall back to copying
+	if err := os.Rename(legacyPath, newPath); err != nil {
+		data, readErr := os.ReadFile(legacyPath)
+		if readErr != nil {
+			return "", fmt.Errorf("failed to read legacy config: %w", readErr)
+		}
+		if writeErr := os.WriteFile(newPath, data, 0644); writeErr != nil {
+			return "", fmt.Errorf("failed to migrate legacy config: %w", writeErr)
+		}
+		_ = os.Remove(legacyPath)
+	}
+
+	return newPath, nil
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// File modes for files written by the launcher
+const (
+	publicFileMode  os.FileMode = 0644
+	privateFileMode os.FileMode = 0600 // Files holding credentials
+)
+
+// hasSecrets reports whether the config holds credentials that other local
+// users must not be able to read
+func (c *LauncherConfig) hasSecrets() bool {
+	if c.APIToken != "" {
+		return true
+	}
+	parsed, err := url.Parse(c.APIEndpoint)
+	return err == nil && parsed.User != nil
+}
+
+// isTooPermissive reports whether mode lets the group or other users access
+// the file
+func isTooPermissive(mode os.FileMode) bool {
+	return mode.Perm()&0077 != 0
+}
+
+// restrictPermissions makes path readable by its owner only. Windows does
+// not use Unix permission bits, so nothing is changed there.
+func restrictPermissions(path string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !isTooPermissive(info.Mode()) {
+		return nil
+	}
+
+	if err := os.Chmod(path, privateFileMode); err != nil {
+		return fmt.Errorf("failed to restrict permissions of %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeFileAtomic replaces the file at path with data. The data goes to a
+// temporary file in the same directory that has mode before it holds any
+// data, so credentials are never readable by others, not even while an
+// existing, more permissive file is overwritten. A symlinked path replaces
+// the link's target.
+func writeFileAtomic(path string, data []byte, mode os.FileMode) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // No-op after the rename
+
+	if err := tmp.Chmod(mode); err != nil && runtime.GOOS != "windows" {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// checkPermissions tightens the permissions of a config file holding
+// secrets that other local users can read, and warns if that fails
+func (cm *ConfigManager) checkPermissions() {
+	if runtime.GOOS == "windows" || !cm.config.hasSecrets() {
+		return
+	}
+
+	info, err := os.Stat(cm.configPath)
+	if err != nil || !isTooPermissive(info.Mode()) {
+		return
+	}
+
+	if err := restrictPermissions(cm.configPath); err != nil {
+		log.Printf("Warning: config file %s contains secrets and is readable by other users (mode %04o): %v",
+			cm.configPath, info.Mode().Perm(), err)
+		return
+	}
+	log.Printf("Warning: config file %s contained secrets but was readable by other users; permissions changed to %04o",
+		cm.configPath, privateFileMode)
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestSaveWithSecretsIsPrivate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows does not use Unix permission bits")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(`{}`), publicFileMode); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, publicFileMode); err != nil {
+		t.Fatal(err)
+	}
+
+	cm, err := NewConfigManagerWithPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cm.GetConfig().APIToken = "secret"
+	if err := cm.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Mode().Perm(); got != privateFileMode {
+		t.Errorf("config file mode = %o, want %o", got, privateFileMode)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("config directory holds %d files, want only the config without temporary files", len(entries))
+	}
+
+	reloaded, err := NewConfigManagerWithPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.GetAPIToken(); got != "secret" {
+		t.Errorf("saved api_token = %q, want secret", got)
+	}
+}
+
+func TestSaveThroughSymlinkKeepsLink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks need privileges on Windows")
+	}
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "dotfiles", "config.json")
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(target, []byte(`{}`), publicFileMode); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "config.json")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	cm, err := NewConfigManagerWithPath(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cm.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	info, err := os.Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("config path is no longer a symlink")
+	}
+}
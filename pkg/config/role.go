@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Role controls which launcher actions a user may perform
+type Role string
+
+const (
+	RoleAdmin    Role = "admin"    // All actions
+	RoleOperator Role = "operator" // Day-to-day operation only
+)
+
+// RoleEnvVar locks the role regardless of the config file, e.g. when set
+// in a managed login profile on shared machines
+const RoleEnvVar = "DDALAB_LAUNCHER_ROLE"
+
+// operatorRestricted lists the menu actions an operator may not perform
+var operatorRestricted = map[string]bool{
+	"uninstall":        true,
+	"edit-config":      true,
+	"restore-env":      true,
+	"configure":        true,
+	"environment":      true,
+	"regenerate-certs": true,
+	"telemetry":        true,
+	"update-channel":   true,
+}
+
+// ParseRole converts a role name to a Role
+func ParseRole(name string) (Role, error) {
+	switch Role(strings.ToLower(strings.TrimSpace(name))) {
+	case RoleAdmin:
+		return RoleAdmin, nil
+	case RoleOperator:
+		return RoleOperator, nil
+	default:
+		return "", fmt.Errorf("invalid role '%s'. Valid roles: admin, operator", name)
+	}
+}
+
+// Allows returns true if the role may perform the menu action
+func (r Role) Allows(action string) bool {
+	return r != RoleOperator || !operatorRestricted[action]
+}
+
+// GetRole returns the effective role. The environment variable takes
+// precedence over the config file, and an unrecognised locked role is
+// treated as operator.
+func (cm *ConfigManager) GetRole() Role {
+	if locked, ok := os.LookupEnv(RoleEnvVar); ok {
+		if role, err := ParseRole(locked); err == nil {
+			return role
+		}
+		return RoleOperator
+	}
+
+	if cm.config.Role == "" {
+		return RoleAdmin
+	}
+	if role, err := ParseRole(string(cm.config.Role)); err == nil {
+		return role
+	}
+	return RoleAdmin
+}
+
+// SetRole sets the role stored in the config file
+func (cm *ConfigManager) SetRole(role Role) {
+	cm.config.Role = role
+}
+
+// IsRoleLocked returns true if the role is set by the environment and
+// cannot be changed through the config file
+func (cm *ConfigManager) IsRoleLocked() bool {
+	_, ok := os.LookupEnv(RoleEnvVar)
+	return ok
+}
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Sources of an effective setting other than a flag or environment variable
+const (
+	SourceDefault = "default"
+	SourceFile    = "config file"
+)
+
+// Setting is the effective value of a setting and where it comes from
+type Setting struct {
+	Key    string `json:"key"` // Named as in the config file
+	Value  string `json:"value"`
+	Source string `json:"source"` // SourceDefault, SourceFile or e.g. "--mode flag"
+}
+
+// override is a setting taken from outside the config file
+type override struct {
+	source string
+	value  any // Effective value, nil if the setting itself holds it
+}
+
+// stateKeys are config entries the launcher records itself rather than
+// settings a user chooses, so they are left out of the effective settings
+var stateKeys = map[string]bool{
+	"first_run":             true,
+	"last_operation":        true,
+	"last_operation_failed": true,
+	"last_operation_time":   true,
+	"last_menu_action":      true,
+	"version":               true,
+	"last_update_check":     true,
+	"update_check_failures": true,
+	"server_version":        true,
+	"server_features":       true,
+	"telemetry_asked":       true,
+}
+
+// secretKeys are settings whose values are never shown
+var secretKeys = map[string]bool{
+	"api_token": true,
+}
+
+// effectiveValues resolve settings whose effective value differs from the
+// stored one, e.g. a timeout of 0 meaning the default
+var effectiveValues = map[string]func(cm *ConfigManager) any{
+	"update_channel":               func(cm *ConfigManager) any { return cm.GetUpdateChannel() },
+	"update_check_timeout_seconds": func(cm *ConfigManager) any { return int(cm.GetUpdateCheckTimeout() / time.Second) },
+	"bootstrap_timeout_seconds":    func(cm *ConfigManager) any { return int(cm.GetBootstrapTimeout() / time.Second) },
+	"connect_timeout_ms":           func(cm *ConfigManager) any { return int(cm.GetConnectTimeout() / time.Millisecond) },
+	"status_timeout_seconds":       func(cm *ConfigManager) any { return int(cm.GetStatusTimeout() / time.Second) },
+	"ping_interval_seconds":        func(cm *ConfigManager) any { return int(cm.GetPingInterval() / time.Second) },
+	"role":                         func(cm *ConfigManager) any { return string(cm.GetRole()) },
+	"web_probe":                    func(cm *ConfigManager) any { return cm.GetWebProbe() },
+}
+
+// SetSource records that the setting key was set from source, e.g. the
+// "--mode flag", rather than read from the config file
+func (cm *ConfigManager) SetSource(key, source string) {
+	cm.setOverride(key, override{source: source})
+}
+
+// Override records that source, e.g. the "--palette flag", sets the
+// effective value of the setting key for this session without changing
+// the stored setting
+func (cm *ConfigManager) Override(key string, value any, source string) {
+	cm.setOverride(key, override{source: source, value: value})
+}
+
+// recordFileKeys remembers which settings the config file data sets. It is
+// called for the data read, not for saves, as a save writes every setting.
+func (cm *ConfigManager) recordFileKeys(codec configCodec, data []byte) {
+	var keys map[string]any
+	if codec.unmarshal(data, &keys) != nil {
+		return
+	}
+	cm.fileKeys = make(map[string]bool, len(keys))
+	for key := range keys {
+		cm.fileKeys[key] = true
+	}
+}
+
+func (cm *ConfigManager) setOverride(key string, o override) {
+	if cm.overrides == nil {
+		cm.overrides = make(map[string]override)
+	}
+	cm.overrides[key] = o
+}
+
+// EffectiveSettings returns every setting with its effective value and
+// where it comes from, in config file order. Flags and environment
+// variables win over the config file, which wins over the defaults. A
+// setting the file holds at its default value is reported as the default,
+// since the launcher writes every setting whenever it saves. Secrets are
+// redacted.
+func (cm *ConfigManager) EffectiveSettings() []Setting {
+	value := reflect.ValueOf(cm.config).Elem()
+	defaults := reflect.ValueOf(defaultConfig()).Elem()
+	configType := value.Type()
+
+	var settings []Setting
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		key, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || key == "" || stateKeys[key] {
+			continue
+		}
+
+		setting := Setting{Key: key, Source: SourceDefault}
+		effective := value.Field(i).Interface()
+		if cm.fileKeys[key] && !isDefault(value.Field(i), defaults.Field(i)) {
+			setting.Source = SourceFile
+		}
+		if resolve, ok := effectiveValues[key]; ok {
+			effective = resolve(cm)
+		}
+		if key == "role" && cm.IsRoleLocked() {
+			setting.Source = RoleEnvVar + " environment variable"
+		}
+		if o, ok := cm.overrides[key]; ok {
+			setting.Source = o.source
+			if o.value != nil {
+				effective = o.value
+			}
+		}
+
+		setting.Value = formatSetting(effective)
+		if secretKeys[key] && setting.Value != "" {
+			setting.Value = "<redacted>"
+		}
+		settings = append(settings, setting)
+	}
+	return settings
+}
+
+// isDefault reports whether a setting holds its default value. Empty and
+// missing lists count as equal.
+func isDefault(value, defaultValue reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		if value.Len() == 0 && defaultValue.Len() == 0 {
+			return true
+		}
+	}
+	return reflect.DeepEqual(value.Interface(), defaultValue.Interface())
+}
+
+// formatSetting renders a setting value: strings and numbers as they are,
+// lists and sections as JSON
+func formatSetting(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case OperationMode, Role, bool, int:
+		return fmt.Sprint(v)
+	}
+
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Slice && v.Len() == 0 {
+		return "[]"
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
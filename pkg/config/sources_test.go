@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func sources(cm *ConfigManager) map[string]string {
+	bySetting := make(map[string]string)
+	for _, setting := range cm.EffectiveSettings() {
+		bySetting[setting.Key] = setting.Source
+	}
+	return bySetting
+}
+
+// A config file written by Save holds every setting, most at their
+// defaults, which must still be reported as defaults
+func TestEffectiveSettingsSources(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	writer, err := NewConfigManagerWithPath(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.SetAPIEndpoint("http://192.168.1.20:8080"); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if got := sources(writer)["api_endpoint"]; got != SourceDefault {
+		t.Errorf("api_endpoint source after Save = %q, want %q as the file was not read", got, SourceDefault)
+	}
+
+	cm, err := NewConfigManagerWithPath(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cm.SetSource("operation_mode", "--mode flag")
+	cm.Override("palette", "high-contrast", "--palette flag")
+
+	want := map[string]string{
+		"api_endpoint":      SourceFile,
+		"auto_update_check": SourceDefault,
+		"update_channel":    SourceDefault,
+		"ssh_ports":         SourceDefault,
+		"operation_mode":    "--mode flag",
+		"palette":           "--palette flag",
+	}
+	got := sources(cm)
+	for key, source := range want {
+		if got[key] != source {
+			t.Errorf("source of %s = %q, want %q", key, got[key], source)
+		}
+	}
+
+	for _, setting := range cm.EffectiveSettings() {
+		if setting.Key == "palette" && setting.Value != "high-contrast" {
+			t.Errorf("palette = %q, want the override high-contrast", setting.Value)
+		}
+	}
+}
+
+func TestEffectiveSettingsRedactsSecrets(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("api_token: secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cm, err := NewConfigManagerWithPath(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, setting := range cm.EffectiveSettings() {
+		if setting.Key != "api_token" {
+			continue
+		}
+		if setting.Value != "<redacted>" || setting.Source != SourceFile {
+			t.Errorf("api_token = %q from %q, want <redacted> from %q", setting.Value, setting.Source, SourceFile)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/compose"
+	"github.com/ddalab/launcher/pkg/oplock"
+)
+
+// ErrNoComposeProject is returned when an operation must run docker compose
+// but no local installation is configured
+var ErrNoComposeProject = errors.New("no local DDALAB installation configured to run docker compose in")
+
+// ComposeProject returns the compose project of the selected deployment,
+// see config.ConfigManager.ComposeProject
+func (c *Controller) ComposeProject() compose.Project {
+	return c.configManager.ComposeProject()
+}
+
+// DeepStop stops all DDALAB services and, unlike Stop, removes their
+// containers together with orphans left by services that were renamed or
+// removed. Volumes are kept. The backend does it if it announces the
+// feature; otherwise "docker compose down --remove-orphans" runs in the
+// local installation, without bootstrapping the backend first.
+func (c *Controller) DeepStop(ctx context.Context) error {
+	if client := c.modeManager.GetAPIClient(); client != nil && c.modeManager.IsAPIMode() &&
+		client.HasFeature(api.FeatureRemoveOrphans) {
+		return c.lifecycle(ctx, "deep-stop", (*api.Client).DeepStopStack)
+	}
+
+	ctx, release, err := oplock.Acquire(ctx, "deep-stop")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if c.configManager.GetDDALABPath() == "" {
+		return ErrNoComposeProject
+	}
+
+	project := c.ComposeProject()
+	return c.runLifecycle(ctx, "deep-stop", func(ctx context.Context) error {
+		return compose.Run(ctx, compose.DetectCommand(ctx), project, "deep-stop")
+	})
+}
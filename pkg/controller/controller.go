@@ -0,0 +1,389 @@
+// Package controller provides UI-independent control of a DDALAB
+// installation. It is used by the launcher's TUI and can be embedded in
+// other tools that need to start, stop or inspect DDALAB programmatically.
+//
+// A minimal embedding looks like:
+//
+//	cm, err := config.NewConfigManager()
+//	if err != nil {
+//		return err
+//	}
+//	ctrl := controller.New(cm)
+//	if err := ctrl.Initialize(); err != nil {
+//		return err
+//	}
+//	if err := ctrl.Start(ctx); err != nil {
+//		return err
+//	}
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/audit"
+	"github.com/ddalab/launcher/pkg/config"
+	"github.com/ddalab/launcher/pkg/hooks"
+	"github.com/ddalab/launcher/pkg/mode"
+	"github.com/ddalab/launcher/pkg/oplock"
+)
+
+// DefaultAccessURL is where DDALAB is served once started
+const DefaultAccessURL = "https://localhost"
+
+// ErrAPIUnavailable is returned when the DDALAB API cannot be reached and
+// bootstrapping it failed
+var ErrAPIUnavailable = errors.New("API mode unavailable and bootstrap failed - ensure Docker is running")
+
+// Controller performs DDALAB lifecycle operations via the Docker extension API
+type Controller struct {
+	configManager *config.ConfigManager
+	modeManager   *mode.Manager
+	hookOutput    func(line string)
+}
+
+// lifecycleHooks maps lifecycle operations to the hooks run before and after
+// them. Restart and update bring the stack down and up again.
+var lifecycleHooks = map[string]struct{ pre, post hooks.Event }{
+	"start":     {hooks.PreStart, hooks.PostStart},
+	"stop":      {hooks.PreStop, hooks.PostStop},
+	"deep-stop": {hooks.PreStop, hooks.PostStop},
+	"restart":   {hooks.PreStop, hooks.PostStart},
+	"update":    {hooks.PreStop, hooks.PostStart},
+}
+
+// LogOptions narrows the logs returned by Logs
+type LogOptions struct {
+	Service string // Only include this service (empty for all services)
+	Tail    int    // Only include the last Tail lines (0 for all lines)
+	Level   string // Minimum level: debug, info, warn or error (empty for all)
+}
+
+// BackupResult describes a created database backup
+type BackupResult struct {
+	Filename string
+}
+
+// New creates a controller with its own mode manager
+func New(configManager *config.ConfigManager) *Controller {
+	return NewWithModeManager(configManager, mode.NewManager(configManager))
+}
+
+// NewWithModeManager creates a controller that shares an existing mode manager
+func NewWithModeManager(configManager *config.ConfigManager, modeManager *mode.Manager) *Controller {
+	return &Controller{
+		configManager: configManager,
+		modeManager:   modeManager,
+	}
+}
+
+// Initialize detects the operation mode. It must be called before any
+// operation when the controller was created with New.
+func (c *Controller) Initialize() error {
+	return c.modeManager.Initialize()
+}
+
+// ModeManager returns the mode manager used by the controller
+func (c *Controller) ModeManager() *mode.Manager {
+	return c.modeManager
+}
+
+// SetHookOutput sets where lifecycle hook output and hook warnings are
+// reported, one line at a time
+func (c *Controller) SetHookOutput(output func(line string)) {
+	c.hookOutput = output
+}
+
+// Start starts all DDALAB services
+func (c *Controller) Start(ctx context.Context) error {
+	return c.lifecycle(ctx, "start", (*api.Client).StartStack)
+}
+
+// Stop stops all DDALAB services
+func (c *Controller) Stop(ctx context.Context) error {
+	return c.lifecycle(ctx, "stop", (*api.Client).StopStack)
+}
+
+// Restart restarts all DDALAB services
+func (c *Controller) Restart(ctx context.Context) error {
+	return c.lifecycle(ctx, "restart", (*api.Client).RestartStack)
+}
+
+// Update updates DDALAB to the latest version
+func (c *Controller) Update(ctx context.Context) error {
+	return c.lifecycle(ctx, "update", (*api.Client).UpdateStack)
+}
+
+// Status returns the current DDALAB status
+func (c *Controller) Status(ctx context.Context) (*api.Status, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := client.GetStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DDALAB status: %w", err)
+	}
+
+	return status, nil
+}
+
+// ActiveJobs returns the analyses currently running in DDALAB. It returns
+// api.ErrUnsupported if the backend cannot report them.
+func (c *Controller) ActiveJobs(ctx context.Context) ([]api.Job, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetActiveJobs(ctx)
+}
+
+// ValidateEnv asks the backend whether it would accept the .env variables,
+// without saving them. Unlike operations it never bootstraps the backend:
+// it returns ErrAPIUnavailable when not in API mode and api.ErrUnsupported
+// if the backend cannot validate.
+func (c *Controller) ValidateEnv(ctx context.Context, variables []config.EnvVar) (*api.ValidationReport, error) {
+	client := c.modeManager.GetAPIClient()
+	if client == nil {
+		return nil, ErrAPIUnavailable
+	}
+
+	payload := make([]api.EnvVariable, len(variables))
+	for i, envVar := range variables {
+		payload[i] = api.EnvVariable{
+			Key:        envVar.Key,
+			Value:      envVar.Value,
+			Comment:    envVar.Comment,
+			Section:    envVar.Section,
+			IsRequired: envVar.IsRequired,
+			IsSecret:   envVar.IsSecret,
+		}
+	}
+	return client.ValidateEnvConfig(ctx, payload)
+}
+
+// StreamEvents sends the backend's application events to out until ctx is
+// done. Like ValidateEnv it never bootstraps the backend: it returns
+// ErrAPIUnavailable when not in API mode and api.ErrUnsupported if the
+// backend has no event stream.
+func (c *Controller) StreamEvents(ctx context.Context, out chan<- api.Event) error {
+	client := c.modeManager.GetAPIClient()
+	if client == nil || !c.modeManager.IsAPIMode() {
+		return ErrAPIUnavailable
+	}
+
+	return client.StreamEvents(ctx, out)
+}
+
+// CheckForUpdate reports whether a newer DDALAB release is available. It
+// returns api.ErrUnsupported if the backend cannot tell.
+func (c *Controller) CheckForUpdate(ctx context.Context) (*api.StackUpdate, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.CheckStackUpdate(ctx)
+}
+
+// Logs returns service logs filtered according to opts
+func (c *Controller) Logs(ctx context.Context, opts LogOptions) (string, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// Let the backend filter a single service's logs where it can, so only
+	// the requested lines are transferred
+	if opts.Service != "" {
+		logs, err := client.GetServiceLogs(ctx, opts.Service, api.LogQuery{Tail: opts.Tail, Level: opts.Level})
+		if err == nil {
+			return strings.TrimRight(logs, "\n"), nil
+		}
+		if !errors.Is(err, api.ErrUnsupported) {
+			return "", fmt.Errorf("failed to get %s logs: %w", opts.Service, err)
+		}
+	}
+
+	logs, err := client.GetLogs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get DDALAB logs: %w", err)
+	}
+
+	logs = FilterLogs(logs, opts.Service, 0)
+	if opts.Level != "" {
+		logs = FilterLogLevel(logs, opts.Level)
+	}
+	return FilterLogs(logs, "", opts.Tail), nil
+}
+
+// Backup creates a database backup
+func (c *Controller) Backup(ctx context.Context) (*BackupResult, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, release, err := oplock.Acquire(ctx, "backup")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if err := checkCircuit(client, "backup"); err != nil {
+		return nil, err
+	}
+
+	filename, err := client.CreateBackup(ctx)
+	c.recordOperation("backup", err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to backup DDALAB: %w", err)
+	}
+
+	return &BackupResult{Filename: filename}, nil
+}
+
+// APIClient returns the API client, bootstrapping the backend if needed
+func (c *Controller) APIClient(ctx context.Context) (*api.Client, error) {
+	return c.client(ctx)
+}
+
+// Bootstrap starts the minimal DDALAB services when the API backend is not
+// available and switches to API mode. It fails with oplock.ErrBusy while
+// another operation is running.
+func (c *Controller) Bootstrap(ctx context.Context) error {
+	ctx, release, err := oplock.Acquire(ctx, "bootstrap")
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	err = c.modeManager.PerformBootstrap(ctx)
+	c.recordOperation("bootstrap", err)
+	return err
+}
+
+// lifecycle runs a lifecycle action and records it as the last operation.
+// It fails with oplock.ErrBusy while another operation is running.
+func (c *Controller) lifecycle(ctx context.Context, operation string, action func(*api.Client, context.Context) error) error {
+	ctx, release, err := oplock.Acquire(ctx, operation)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	client, err := c.client(ctx)
+	if err != nil {
+		return err
+	}
+	if err := checkCircuit(client, operation); err != nil {
+		return err
+	}
+
+	return c.runLifecycle(ctx, operation, func(ctx context.Context) error {
+		return action(client, ctx)
+	})
+}
+
+// checkCircuit fails fast while the client's circuit breaker is open, so
+// operations back off together with the status monitor
+func checkCircuit(client *api.Client, operation string) error {
+	circuit := client.Circuit()
+	if circuit.State == api.CircuitOpen && time.Now().Before(circuit.RetryAt) {
+		return fmt.Errorf("cannot %s DDALAB: %w (retry after %s)", operation, api.ErrCircuitOpen, circuit.RetryAt.Format("15:04:05"))
+	}
+	return nil
+}
+
+// runLifecycle runs action between the operation's hooks and records it as
+// the last operation. The caller holds the operation lock.
+func (c *Controller) runLifecycle(ctx context.Context, operation string, action func(ctx context.Context) error) error {
+	runner := hooks.NewRunner(c.configManager.GetHooks(), c.hookOutput)
+	events := lifecycleHooks[operation]
+
+	if err := c.runHook(ctx, runner, events.pre); err != nil {
+		if runner.AbortOnFailure() {
+			return fmt.Errorf("failed to %s DDALAB: %w", operation, err)
+		}
+		c.reportHookError(err)
+	}
+
+	err := action(ctx)
+	c.recordOperation(operation, err)
+	if err != nil {
+		return fmt.Errorf("failed to %s DDALAB: %w", operation, err)
+	}
+
+	// The operation already succeeded, so post hook failures are only reported
+	if err := c.runHook(ctx, runner, events.post); err != nil {
+		c.reportHookError(err)
+	}
+
+	return nil
+}
+
+// runHook runs the hook for event, if any
+func (c *Controller) runHook(ctx context.Context, runner *hooks.Runner, event hooks.Event) error {
+	if event == "" {
+		return nil
+	}
+
+	return runner.Run(ctx, hooks.Context{
+		Event: event,
+		Path:  c.configManager.GetDDALABPath(),
+		URL:   DefaultAccessURL,
+	})
+}
+
+// reportHookError passes a non-fatal hook failure to the hook output
+func (c *Controller) reportHookError(err error) {
+	if c.hookOutput != nil {
+		c.hookOutput(fmt.Sprintf("Warning: %v", err))
+	}
+}
+
+// client returns an API client, bootstrapping the backend when it is not
+// yet available. The bootstrap takes the operation lock, unless ctx
+// already holds it.
+func (c *Controller) client(ctx context.Context) (*api.Client, error) {
+	if c.modeManager.IsAPIMode() {
+		if client := c.modeManager.GetAPIClient(); client != nil {
+			return client, nil
+		}
+	}
+
+	if err := c.modeManager.GetBootstrapper().CheckDocker(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAPIUnavailable, err)
+	}
+
+	ctx, release, err := oplock.Acquire(ctx, "bootstrap")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAPIUnavailable, err)
+	}
+	defer release()
+
+	if err := c.modeManager.PerformBootstrap(ctx); err == nil {
+		if client := c.modeManager.GetAPIClient(); client != nil {
+			return client, nil
+		}
+	}
+
+	return nil, ErrAPIUnavailable
+}
+
+// recordOperation persists the outcome of a completed operation and adds it
+// to the audit log. Cancelled operations are not recorded.
+func (c *Controller) recordOperation(operation string, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	c.configManager.SetLastOperation(operation, err)
+	_ = c.configManager.Save()
+	_ = audit.Record(operation, err)
+}
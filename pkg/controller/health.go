@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/api"
+)
+
+// healthPollInterval is how often WaitHealthy checks the status
+const healthPollInterval = 2 * time.Second
+
+// Restart detection after an update. The backend usually drops within a
+// few seconds of accepting the update; if it stays up for the whole window
+// it is assumed to have reloaded without going away.
+const (
+	restartPollInterval = time.Second
+	restartDetectWindow = 20 * time.Second
+)
+
+// WaitHealthy polls the status until DDALAB is running and no service
+// reports a health other than healthy, or ctx is done. The polls bypass the
+// client's circuit breaker, so the failures of a backend that is still
+// coming up do not hold them off.
+func (c *Controller) WaitHealthy(ctx context.Context) (*api.Status, error) {
+	ctx = api.WithoutBreaker(ctx)
+	ticker := time.NewTicker(healthPollInterval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		status, err := c.Status(ctx)
+		if err == nil && IsHealthy(status) {
+			return status, nil
+		}
+		if err != nil {
+			lastErr = err
+		} else {
+			lastErr = fmt.Errorf("services not healthy yet: %s", describeUnhealthy(status))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("DDALAB did not become healthy: %w (%w)", lastErr, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitRestarted waits for the backend to go down after an operation that
+// restarts it and then polls until DDALAB is healthy again. restarting, if
+// set, is called once the backend is seen going down.
+func (c *Controller) WaitRestarted(ctx context.Context, restarting func()) (*api.Status, error) {
+	if c.waitForDrop(ctx) && restarting != nil {
+		restarting()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.WaitHealthy(ctx)
+}
+
+// waitForDrop polls the status until the backend is unreachable or reports
+// DDALAB as not running. It returns false if that did not happen within
+// restartDetectWindow.
+func (c *Controller) waitForDrop(ctx context.Context) bool {
+	detectCtx, cancel := context.WithTimeout(api.WithoutBreaker(ctx), restartDetectWindow)
+	defer cancel()
+
+	ticker := time.NewTicker(restartPollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := c.Status(detectCtx)
+		// An error caused by the window closing is not a drop
+		if detectCtx.Err() != nil {
+			return false
+		}
+		if err != nil || !status.Running {
+			return true
+		}
+
+		select {
+		case <-detectCtx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// IsHealthy returns true if DDALAB is running and every service with a
+// health check reports healthy
+func IsHealthy(status *api.Status) bool {
+	return status.Running && len(status.Services) > 0 && len(UnhealthyServices(status)) == 0
+}
+
+// UnhealthyServices returns the services with a health check that do not
+// report healthy
+func UnhealthyServices(status *api.Status) []api.Service {
+	var unhealthy []api.Service
+	for _, service := range status.Services {
+		if service.Health != "" && !strings.EqualFold(service.Health, "healthy") {
+			unhealthy = append(unhealthy, service)
+		}
+	}
+	return unhealthy
+}
+
+// describeUnhealthy lists the services that are not healthy yet
+func describeUnhealthy(status *api.Status) string {
+	var names []string
+	for _, service := range UnhealthyServices(status) {
+		names = append(names, fmt.Sprintf("%s (%s)", service.Name, service.Health))
+	}
+	return strings.Join(names, ", ")
+}
+
+// ProbeURL requests url and returns an error unless it answers 200 OK.
+// Certificate verification is skipped because local installations use
+// self-signed certificates.
+func ProbeURL(ctx context.Context, url string) error {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("invalid URL %s: %w", url, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LogLevels are the minimum levels accepted by FilterLogLevel, lowest first
+var LogLevels = []string{"debug", "info", "warn", "error"}
+
+// logLevelPattern finds the level of a log line, e.g. "ERROR", "[warn]" or
+// "level=info"
+var logLevelPattern = regexp.MustCompile(`(?i)\b(debug|info|warn|warning|error|fatal|critical|panic)\b`)
+
+// FilterLogs narrows combined docker-compose log output to a single service
+// and keeps only the last tail lines. An empty service keeps all services
+// and a tail of 0 or less keeps every line.
+func FilterLogs(logs, service string, tail int) string {
+	lines := strings.Split(strings.TrimRight(logs, "\n"), "\n")
+
+	if service != "" {
+		filtered := make([]string, 0, len(lines))
+		for _, line := range lines {
+			if logLineMatchesService(line, service) {
+				filtered = append(filtered, line)
+			}
+		}
+		lines = filtered
+	}
+
+	if tail > 0 && len(lines) > tail {
+		lines = lines[len(lines)-tail:]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// FilterLogLevel keeps the log lines at level or above. A line without a
+// recognizable level, e.g. a stack trace, belongs to the line before it.
+func FilterLogLevel(logs, level string) string {
+	minimum := logLevelRank(level)
+	lines := strings.Split(logs, "\n")
+
+	filtered := make([]string, 0, len(lines))
+	keep := false
+	for _, line := range lines {
+		// Skip the container prefix, which may contain a matching word
+		message := line
+		if _, rest, found := strings.Cut(line, "|"); found {
+			message = rest
+		}
+		if match := logLevelPattern.FindStringSubmatch(message); match != nil {
+			keep = logLevelRank(match[1]) >= minimum
+		}
+		if keep {
+			filtered = append(filtered, line)
+		}
+	}
+
+	return strings.Join(filtered, "\n")
+}
+
+// logLevelRank orders levels as in LogLevels; unknown levels rank lowest
+func logLevelRank(level string) int {
+	switch strings.ToLower(level) {
+	case "info":
+		return 1
+	case "warn", "warning":
+		return 2
+	case "error", "fatal", "critical", "panic":
+		return 3
+	default:
+		return 0
+	}
+}
+
+// logLineMatchesService reports whether a docker-compose log line such as
+// "ddalab-postgres-1  | message" or "postgres_1 | message" belongs to service
+func logLineMatchesService(line, service string) bool {
+	prefix, _, found := strings.Cut(line, "|")
+	if !found {
+		return false
+	}
+
+	container := strings.TrimSpace(prefix)
+
+	// Strip the replica suffix ("-1" or "_1")
+	if idx := strings.LastIndexAny(container, "-_"); idx > 0 && isDigits(container[idx+1:]) {
+		container = container[:idx]
+	}
+
+	// Allow a compose project prefix ("ddalab-postgres")
+	return container == service ||
+		strings.HasSuffix(container, "-"+service) ||
+		strings.HasSuffix(container, "_"+service)
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
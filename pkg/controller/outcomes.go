@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/api"
+)
+
+// snapshotTimeout bounds the status checks around an operation
+const snapshotTimeout = 5 * time.Second
+
+// ServiceOutcome is what a lifecycle operation did to one service
+type ServiceOutcome struct {
+	Service string
+	Before  string // Status before the operation, empty if unknown
+	After   string // Status afterwards, empty if the service is gone
+	Health  string // Health afterwards, empty without a health check
+	Failed  bool   // The service did not reach the state the operation aimed for
+}
+
+// Describe returns a short summary such as "up", "healthy" or "failed to
+// start (exited)"
+func (o ServiceOutcome) Describe(op Operation) string {
+	state := o.After
+	if o.Health != "" {
+		state = o.Health
+	}
+	if state == "" {
+		state = "gone"
+	}
+	if !o.Failed {
+		return state
+	}
+	if op.Stops() {
+		return "still " + state
+	}
+	return "failed to " + string(op) + " (" + state + ")"
+}
+
+// ServiceOutcomes compares each service's status after op with the status
+// before it. before may be nil, e.g. when the backend only came up with the
+// operation.
+func ServiceOutcomes(op Operation, before, after *api.Status) []ServiceOutcome {
+	previous := make(map[string]string)
+	if before != nil {
+		for _, service := range before.Services {
+			previous[service.Name] = service.Status
+		}
+	}
+
+	outcomes := make([]ServiceOutcome, 0, len(after.Services))
+	seen := make(map[string]bool)
+	for _, service := range after.Services {
+		seen[service.Name] = true
+		outcome := ServiceOutcome{
+			Service: service.Name,
+			Before:  previous[service.Name],
+			After:   service.Status,
+			Health:  service.Health,
+		}
+		if op.Stops() {
+			outcome.Failed = !isDownStatus(service.Status)
+		} else {
+			outcome.Failed = isDownStatus(service.Status) || strings.EqualFold(service.Health, "unhealthy")
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	// A service that vanished did not come back, unless it was stopped
+	if before != nil && !op.Stops() {
+		for _, service := range before.Services {
+			if !seen[service.Name] {
+				outcomes = append(outcomes, ServiceOutcome{Service: service.Name, Before: service.Status, Failed: true})
+			}
+		}
+	}
+	return outcomes
+}
+
+// isDownStatus reports whether a container status means it is not running
+func isDownStatus(status string) bool {
+	status = strings.ToLower(status)
+	for _, down := range []string{"exited", "dead", "error", "failed", "created", "stopped"} {
+		if strings.Contains(status, down) {
+			return true
+		}
+	}
+	return false
+}
+
+// statusSnapshot returns the current status if the backend is already
+// reachable, or nil. Unlike Status it never bootstraps the backend.
+func (c *Controller) statusSnapshot(ctx context.Context) *api.Status {
+	if !c.modeManager.IsAPIMode() {
+		return nil
+	}
+	client := c.modeManager.GetAPIClient()
+	if client == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, snapshotTimeout)
+	defer cancel()
+
+	status, err := client.GetStatus(ctx)
+	if err != nil {
+		return nil
+	}
+	return status
+}
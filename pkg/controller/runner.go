@@ -0,0 +1,314 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/oplock"
+)
+
+// settleTimeout bounds the wait for DDALAB to come back after an update
+const settleTimeout = 5 * time.Minute
+
+// Operation names a lifecycle operation run through an OperationRunner
+type Operation string
+
+const (
+	OpStart     Operation = "start"
+	OpStop      Operation = "stop"
+	OpDeepStop  Operation = "deep-stop" // Stop and remove the containers, including orphans
+	OpRestart   Operation = "restart"
+	OpUpdate    Operation = "update"
+	OpBackup    Operation = "backup"
+	OpBootstrap Operation = "bootstrap" // Start minimal services when the API backend is not available
+)
+
+// Stops reports whether the operation takes the services down
+func (op Operation) Stops() bool {
+	return op == OpStop || op == OpDeepStop
+}
+
+// Callbacks connect an OperationRunner to a front-end. Nil callbacks are
+// skipped; a nil Confirm confirms and a nil Execute runs with ctx as is.
+type Callbacks struct {
+	Confirm  func(question string) bool // Asked before destructive operations
+	Progress func(message string)
+	Info     func(message string)
+	Success  func(message string)
+
+	// ConfirmDanger is asked instead of Confirm before operations that
+	// interrupt users, e.g. with a dialog that defaults to "No". If nil,
+	// Confirm is used.
+	ConfirmDanger func(question string) bool
+
+	// Execute runs fn for the operation, e.g. with interrupt handling and
+	// a progress indicator
+	Execute func(label string, fn func(ctx context.Context) error) error
+
+	// Started is called when an operation begins running
+	Started func(op Operation)
+
+	// Completed is called after an operation succeeded, e.g. to refresh
+	// the displayed status
+	Completed func(op Operation)
+
+	// Outcomes is called after a start, stop, restart or update, also a
+	// failed one, with the resulting state of each service, so a partial
+	// failure can be reported per service. It is skipped if the status is
+	// unavailable afterwards.
+	Outcomes func(op Operation, outcomes []ServiceOutcome)
+
+	// WebUI is called after a successful start when the web probe is
+	// enabled, with nil if the web UI answered in time
+	WebUI func(err error)
+}
+
+// operationSpec describes how an operation is confirmed and reported
+type operationSpec struct {
+	confirm  string // Confirmation question, empty for none
+	danger   bool   // Confirmed through ConfirmDanger
+	label    string // What is happening, e.g. for a spinner
+	progress string
+	info     string // Extra note shown before the operation runs
+	run      func(c *Controller, ctx context.Context) (success string, err error)
+
+	// settle, if set, waits after a successful run until DDALAB is usable
+	// again, reporting progress messages through progress
+	settle func(c *Controller, ctx context.Context, progress func(string)) error
+
+	// outcomes reports the state of each service afterwards
+	outcomes bool
+
+	// webProbe checks afterwards that the web UI answers, if enabled
+	webProbe bool
+}
+
+var operationSpecs = map[Operation]operationSpec{
+	OpStart: {
+		label:    "starting DDALAB",
+		progress: "Starting DDALAB services",
+		run: func(c *Controller, ctx context.Context) (string, error) {
+			return "DDALAB started successfully!", c.Start(ctx)
+		},
+		outcomes: true,
+		webProbe: true,
+	},
+	OpStop: {
+		confirm:  "stop DDALAB",
+		danger:   true,
+		label:    "stopping DDALAB",
+		progress: "Stopping DDALAB services",
+		run: func(c *Controller, ctx context.Context) (string, error) {
+			return "DDALAB stopped successfully!", c.Stop(ctx)
+		},
+		outcomes: true,
+	},
+	OpDeepStop: {
+		confirm:  "stop DDALAB and remove its containers, including orphans of renamed or removed services",
+		danger:   true,
+		label:    "deep stopping DDALAB",
+		progress: "Stopping DDALAB and removing its containers",
+		info:     "Unlike Stop, this removes the containers; volumes and data are kept",
+		run: func(c *Controller, ctx context.Context) (string, error) {
+			return "DDALAB stopped and its containers removed, including orphans", c.DeepStop(ctx)
+		},
+		outcomes: true,
+	},
+	OpRestart: {
+		confirm:  "restart DDALAB",
+		danger:   true,
+		label:    "restarting DDALAB",
+		progress: "Restarting DDALAB services",
+		run: func(c *Controller, ctx context.Context) (string, error) {
+			return "DDALAB restarted successfully!", c.Restart(ctx)
+		},
+		outcomes: true,
+	},
+	OpUpdate: {
+		confirm:  "update DDALAB to the latest version",
+		label:    "updating DDALAB",
+		progress: "Updating DDALAB",
+		info:     "This may take a few minutes...",
+		run: func(c *Controller, ctx context.Context) (string, error) {
+			return "DDALAB updated successfully!", c.Update(ctx)
+		},
+		settle:   settleAfterUpdate,
+		outcomes: true,
+	},
+	OpBackup: {
+		label:    "creating backup",
+		progress: "Creating database backup",
+		run: func(c *Controller, ctx context.Context) (string, error) {
+			result, err := c.Backup(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Database backup created successfully: %s", result.Filename), nil
+		},
+	},
+	OpBootstrap: {
+		confirm:  "bootstrap DDALAB services",
+		label:    "bootstrapping DDALAB",
+		progress: "Bootstrapping DDALAB services",
+		info:     "This may take a few minutes...",
+		run: func(c *Controller, ctx context.Context) (string, error) {
+			return "DDALAB bootstrap completed successfully!", c.Bootstrap(ctx)
+		},
+	},
+}
+
+// OperationRunner runs lifecycle operations with the confirmation,
+// progress and result reporting shared by all front-ends
+type OperationRunner struct {
+	controller *Controller
+	callbacks  Callbacks
+}
+
+// NewOperationRunner creates a runner for the controller's operations
+func NewOperationRunner(controller *Controller, callbacks Callbacks) *OperationRunner {
+	return &OperationRunner{controller: controller, callbacks: callbacks}
+}
+
+// Run asks for confirmation if the operation needs it and then runs it.
+// Declining is not an error.
+func (r *OperationRunner) Run(op Operation) error {
+	spec, ok := operationSpecs[op]
+	if !ok {
+		return fmt.Errorf("unknown operation '%s'", op)
+	}
+
+	if spec.confirm != "" {
+		if confirm := r.confirmFunc(spec); confirm != nil && !confirm(spec.confirm) {
+			return nil
+		}
+	}
+
+	return r.execute(op, spec)
+}
+
+// confirmFunc returns the callback that confirms the operation
+func (r *OperationRunner) confirmFunc(spec operationSpec) func(string) bool {
+	if spec.danger && r.callbacks.ConfirmDanger != nil {
+		return r.callbacks.ConfirmDanger
+	}
+	return r.callbacks.Confirm
+}
+
+// RunConfirmed runs the operation without asking for confirmation
+func (r *OperationRunner) RunConfirmed(op Operation) error {
+	spec, ok := operationSpecs[op]
+	if !ok {
+		return fmt.Errorf("unknown operation '%s'", op)
+	}
+
+	return r.execute(op, spec)
+}
+
+// execute runs the operation through the Execute callback and reports it.
+// The operation lock is held until it has settled, so no other lifecycle
+// operation can start in the meantime.
+func (r *OperationRunner) execute(op Operation, spec operationSpec) error {
+	execute := r.callbacks.Execute
+	if execute == nil {
+		execute = func(_ string, fn func(ctx context.Context) error) error {
+			return fn(context.Background())
+		}
+	}
+
+	return execute(spec.label, func(ctx context.Context) error {
+		ctx, release, err := oplock.Acquire(ctx, string(op))
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		notify(r.callbacks.Progress, spec.progress)
+		notify(r.callbacks.Info, spec.info)
+		if r.callbacks.Started != nil {
+			r.callbacks.Started(op)
+		}
+
+		var before *api.Status
+		if spec.outcomes && r.callbacks.Outcomes != nil {
+			before = r.controller.statusSnapshot(ctx)
+		}
+
+		success, err := spec.run(r.controller, ctx)
+		if err == nil && spec.settle != nil {
+			err = spec.settle(r.controller, ctx, r.callbacks.Progress)
+		}
+		if spec.outcomes {
+			r.reportOutcomes(ctx, op, before)
+		}
+		if err != nil {
+			return err
+		}
+
+		notify(r.callbacks.Success, success)
+		if r.callbacks.Completed != nil {
+			r.callbacks.Completed(op)
+		}
+		if spec.webProbe {
+			r.probeWebUI(ctx)
+		}
+		return nil
+	})
+}
+
+// probeWebUI passes the result of the web probe to the WebUI callback if
+// the probe is enabled. A cancelled probe is not reported.
+func (r *OperationRunner) probeWebUI(ctx context.Context) {
+	if r.callbacks.WebUI == nil || !r.controller.configManager.GetWebProbe().Enabled {
+		return
+	}
+
+	notify(r.callbacks.Progress, "Checking that the web UI at "+r.controller.WebProbeURL()+" responds")
+	err := r.controller.WaitWebUI(ctx)
+	if ctx.Err() != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return
+	}
+	r.callbacks.WebUI(err)
+}
+
+// reportOutcomes passes the state of each service after op to the
+// Outcomes callback. A cancelled operation is not reported.
+func (r *OperationRunner) reportOutcomes(ctx context.Context, op Operation, before *api.Status) {
+	if r.callbacks.Outcomes == nil || ctx.Err() != nil {
+		return
+	}
+
+	after := r.controller.statusSnapshot(ctx)
+	if after == nil || len(after.Services) == 0 {
+		return
+	}
+	r.callbacks.Outcomes(op, ServiceOutcomes(op, before, after))
+}
+
+// settleAfterUpdate waits for the backend restart that follows an update,
+// so success is not reported while the stack is still reloading
+func settleAfterUpdate(c *Controller, ctx context.Context, progress func(string)) error {
+	settleCtx, cancel := context.WithTimeout(ctx, settleTimeout)
+	defer cancel()
+
+	restarting := func() {
+		notify(progress, "Backend restarting after update...")
+	}
+	if _, err := c.WaitRestarted(settleCtx, restarting); err != nil {
+		if ctx.Err() != nil {
+			return err // Cancelled by the user
+		}
+		// Not wrapped, so the timeout is not mistaken for a cancellation
+		return fmt.Errorf("DDALAB was updated but did not come back: %v", err)
+	}
+	return nil
+}
+
+// notify calls fn with message if both are set
+func notify(fn func(string), message string) {
+	if fn != nil && message != "" {
+		fn(message)
+	}
+}
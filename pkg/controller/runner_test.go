@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ddalab/launcher/pkg/oplock"
+)
+
+func TestBootstrapWaitsForRunningOperation(t *testing.T) {
+	_, release, err := oplock.Acquire(context.Background(), "start")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	// The controller has no mode manager, so reaching the bootstrap panics
+	var started []Operation
+	runner := NewOperationRunner(&Controller{}, Callbacks{
+		Started: func(op Operation) { started = append(started, op) },
+	})
+	if err := runner.RunConfirmed(OpBootstrap); !errors.Is(err, oplock.ErrBusy) {
+		t.Errorf("RunConfirmed(OpBootstrap) error = %v, want oplock.ErrBusy", err)
+	}
+	if err := (&Controller{}).Bootstrap(context.Background()); !errors.Is(err, oplock.ErrBusy) {
+		t.Errorf("Bootstrap error = %v, want oplock.ErrBusy", err)
+	}
+	if len(started) != 0 {
+		t.Errorf("operations started = %v, want none", started)
+	}
+}
+
+func TestBootstrapAsksFirst(t *testing.T) {
+	var asked string
+	runner := NewOperationRunner(&Controller{}, Callbacks{
+		Confirm: func(question string) bool {
+			asked = question
+			return false
+		},
+	})
+	if err := runner.Run(OpBootstrap); err != nil {
+		t.Errorf("declined Run(OpBootstrap) error = %v, want nil", err)
+	}
+	if asked == "" {
+		t.Error("Run(OpBootstrap) did not ask for confirmation")
+	}
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/oplock"
+)
+
+// ServiceResult is the outcome of an operation on a single service
+type ServiceResult struct {
+	Service string
+	Elapsed time.Duration
+	Err     error
+}
+
+// RestartService restarts a single DDALAB service without touching the
+// rest of the stack
+func (c *Controller) RestartService(ctx context.Context, name string) error {
+	ctx, release, err := oplock.Acquire(ctx, "restart "+name)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	client, err := c.client(ctx)
+	if err != nil {
+		return err
+	}
+	if err := checkCircuit(client, "restart "+name); err != nil {
+		return err
+	}
+
+	if err := client.RestartService(ctx, name); err != nil {
+		return fmt.Errorf("failed to restart %s: %w", name, err)
+	}
+	return nil
+}
+
+// RestartServices restarts the named services one after another. Each
+// outcome is passed to report, if set, as soon as it is known. Services not
+// reached because ctx was cancelled are left out of the results. If another
+// operation is running, nothing is restarted and oplock.ErrBusy is returned.
+func (c *Controller) RestartServices(ctx context.Context, names []string, report func(ServiceResult)) ([]ServiceResult, error) {
+	ctx, release, err := oplock.Acquire(ctx, "restart-unhealthy")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	results := make([]ServiceResult, 0, len(names))
+	var failure error
+	for _, name := range names {
+		if ctx.Err() != nil {
+			break
+		}
+
+		start := time.Now()
+		result := ServiceResult{Service: name, Err: c.RestartService(ctx, name)}
+		result.Elapsed = time.Since(start)
+
+		results = append(results, result)
+		if failure == nil {
+			failure = result.Err
+		}
+		if report != nil {
+			report(result)
+		}
+	}
+
+	if len(results) > 0 {
+		c.recordOperation("restart-unhealthy", failure)
+	}
+	return results, nil
+}
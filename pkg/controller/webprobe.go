@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/httpx"
+)
+
+// Web probe pacing: one attempt may take webProbeAttempt, and a failed
+// one is repeated after webProbeInterval until the probe times out
+const (
+	webProbeAttempt  = 5 * time.Second
+	webProbeInterval = 2 * time.Second
+)
+
+// WebProbeURL returns the URL the web probe requests: the configured path
+// below the access URL
+func (c *Controller) WebProbeURL() string {
+	path := c.configManager.GetWebProbe().Path
+	return strings.TrimRight(DefaultAccessURL, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+// WaitWebUI requests the web UI until it answers with one of the expected
+// statuses or the probe timeout passes, so a stack whose containers are up
+// but whose app is not serving yet is noticed. Redirects are not followed,
+// as a redirect to the login already means the app is serving. The
+// certificate is verified against api_ca_cert if one is configured and not
+// at all otherwise, since local installations use self-signed ones.
+func (c *Controller) WaitWebUI(ctx context.Context) error {
+	probe := c.configManager.GetWebProbe()
+	url := c.WebProbeURL()
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(probe.TimeoutSeconds)*time.Second)
+	defer cancel()
+
+	client := c.webProbeClient()
+	for {
+		err := probeStatus(ctx, client, url, probe.ExpectedStatus)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(webProbeInterval):
+		}
+	}
+}
+
+// webProbeClient returns the client of the web probe, following the TLS
+// settings of the API client
+func (c *Controller) webProbeClient() *http.Client {
+	client := httpx.NewClient()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	if caCert := c.configManager.GetAPICACert(); caCert != "" {
+		err := httpx.TrustCACert(client, caCert)
+		if err == nil {
+			return client
+		}
+		log.Printf("Warning: web probe ignoring api_ca_cert: %v", err)
+	}
+	client.Transport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	return client
+}
+
+// probeStatus requests url once and returns an error unless it answers
+// with one of the expected statuses
+func probeStatus(ctx context.Context, client *http.Client, url string, expected []int) error {
+	ctx, cancel := context.WithTimeout(ctx, webProbeAttempt)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("invalid URL %s: %w", url, err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if !slices.Contains(expected, resp.StatusCode) {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return nil
+}
@@ -1,12 +1,22 @@
 package detector
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/compose"
 )
 
+// DefaultExpectedServices are the compose services every DDALAB
+// installation defines: the application, its database and its cache
+var DefaultExpectedServices = []string{"ddalab", "postgres", "redis"}
+
 // InstallationInfo contains details about a detected DDALAB installation
 type InstallationInfo struct {
 	Path            string
@@ -15,18 +25,120 @@ type InstallationInfo struct {
 	DockerCompose   bool
 	Scripts         bool
 	HasCertificates bool
+	Services        []string // Services defined in the compose file, sorted
+	MissingServices []string // Expected services the compose file lacks
+	Problem         string   // Why the installation is not valid, empty if it is
 }
 
 // Detector handles DDALAB installation detection
-type Detector struct{}
+type Detector struct {
+	cacheMu sync.Mutex
+	cache   map[string]*cachedFile // File contents read during detection, by path
+
+	expectedMu       sync.RWMutex
+	expectedServices []string // Compose services a valid installation defines
+}
+
+// cachedFile is a file's content together with the metadata it was read at
+type cachedFile struct {
+	modTime time.Time
+	size    int64
+	content []byte
+
+	parseOnce   sync.Once
+	services    []string // Compose services in content, parsed on first use
+	servicesErr error    // Why content is not a compose file
+}
 
 // NewDetector creates a new DDALAB detector
 func NewDetector() *Detector {
-	return &Detector{}
+	return &Detector{cache: make(map[string]*cachedFile), expectedServices: DefaultExpectedServices}
+}
+
+// SetExpectedServices sets the compose services an installation must
+// define to be valid. An empty list restores DefaultExpectedServices.
+func (d *Detector) SetExpectedServices(services []string) {
+	if len(services) == 0 {
+		services = DefaultExpectedServices
+	}
+
+	d.expectedMu.Lock()
+	defer d.expectedMu.Unlock()
+	d.expectedServices = services
+}
+
+// ExpectedServices returns the compose services an installation must define
+func (d *Detector) ExpectedServices() []string {
+	d.expectedMu.RLock()
+	defer d.expectedMu.RUnlock()
+	if len(d.expectedServices) == 0 {
+		return DefaultExpectedServices
+	}
+	return d.expectedServices
 }
 
-// FindInstallations searches for DDALAB installations in common locations
-func (d *Detector) FindInstallations() ([]*InstallationInfo, error) {
+// ClearCache drops all cached file contents so the next detection reads
+// every file again
+func (d *Detector) ClearCache() {
+	d.cacheMu.Lock()
+	defer d.cacheMu.Unlock()
+	d.cache = make(map[string]*cachedFile)
+}
+
+// readFile returns the content of path, reusing the cached content while
+// the file's modification time and size are unchanged
+func (d *Detector) readFile(path string) ([]byte, error) {
+	entry, err := d.lookup(path)
+	if err != nil {
+		return nil, err
+	}
+	return entry.content, nil
+}
+
+// composeServices returns the services of the cached compose file. Like
+// the content, they are reused while the file is unchanged, as parsing
+// takes far longer than reading.
+func (f *cachedFile) composeServices() ([]string, error) {
+	f.parseOnce.Do(func() {
+		f.services, f.servicesErr = compose.ServiceNames(f.content)
+	})
+	return f.services, f.servicesErr
+}
+
+// lookup returns the cache entry of path, reading the file again if its
+// modification time or size changed
+func (d *Detector) lookup(path string) (*cachedFile, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	d.cacheMu.Lock()
+	cached, ok := d.cache[path]
+	d.cacheMu.Unlock()
+	if ok && cached.modTime.Equal(stat.ModTime()) && cached.size == stat.Size() {
+		return cached, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &cachedFile{modTime: stat.ModTime(), size: stat.Size(), content: content}
+	d.cacheMu.Lock()
+	if d.cache == nil {
+		d.cache = make(map[string]*cachedFile)
+	}
+	d.cache[path] = entry
+	d.cacheMu.Unlock()
+
+	return entry, nil
+}
+
+// FindInstallations searches for DDALAB installations in common locations.
+// The search stops with ctx's error once ctx is done.
+func (d *Detector) FindInstallations(ctx context.Context) ([]*InstallationInfo, error) {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get home directory: %w", err)
@@ -45,6 +157,9 @@ func (d *Detector) FindInstallations() ([]*InstallationInfo, error) {
 	var installations []*InstallationInfo
 
 	for _, path := range searchPaths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if info := d.DetectInstallation(path); info.Valid {
 			installations = append(installations, info)
 		}
@@ -61,6 +176,7 @@ func (d *Detector) DetectInstallation(path string) *InstallationInfo {
 
 	// Check if directory exists
 	if _, err := os.Stat(path); os.IsNotExist(err) {
+		info.Problem = "the directory does not exist"
 		return info
 	}
 
@@ -73,6 +189,7 @@ func (d *Detector) DetectInstallation(path string) *InstallationInfo {
 	for _, file := range requiredFiles {
 		filePath := filepath.Join(path, file)
 		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+			info.Problem = fmt.Sprintf("%s is missing", file)
 			return info
 		}
 	}
@@ -103,16 +220,54 @@ func (d *Detector) DetectInstallation(path string) *InstallationInfo {
 	// Try to detect version from docker-compose.yml
 	info.Version = d.extractVersion(path)
 
-	// Installation is valid if it has docker-compose and scripts
+	// A docker-compose.yml of another project must not pass as DDALAB
+	if err := d.checkServices(info); err != nil {
+		info.Problem = err.Error()
+		return info
+	}
+
+	if !info.Scripts {
+		info.Problem = "no ddalab.sh, ddalab.ps1 or ddalab.bat script found"
+	}
+
+	// Installation is valid if it has docker-compose, the expected
+	// services and scripts
 	info.Valid = info.DockerCompose && info.Scripts
 
 	return info
 }
 
+// checkServices reads the services of the installation's compose file into
+// info and returns an error if it cannot be parsed or lacks expected
+// services
+func (d *Detector) checkServices(info *InstallationInfo) error {
+	composeFile, err := d.lookup(filepath.Join(info.Path, "docker-compose.yml"))
+	if err != nil {
+		return fmt.Errorf("docker-compose.yml cannot be read: %w", err)
+	}
+
+	services, err := composeFile.composeServices()
+	if err != nil {
+		return fmt.Errorf("docker-compose.yml is not a valid compose file: %w", err)
+	}
+	info.Services = services
+
+	for _, expected := range d.ExpectedServices() {
+		if !slices.Contains(services, expected) {
+			info.MissingServices = append(info.MissingServices, expected)
+		}
+	}
+	if len(info.MissingServices) > 0 {
+		return fmt.Errorf("docker-compose.yml does not define the DDALAB services %s - it may belong to another project",
+			strings.Join(info.MissingServices, ", "))
+	}
+	return nil
+}
+
 // extractVersion attempts to extract version information from the installation
 func (d *Detector) extractVersion(path string) string {
 	dockerComposePath := filepath.Join(path, "docker-compose.yml")
-	content, err := os.ReadFile(dockerComposePath)
+	content, err := d.readFile(dockerComposePath)
 	if err != nil {
 		return "unknown"
 	}
@@ -135,7 +290,7 @@ func (d *Detector) extractVersion(path string) string {
 
 	// Check if README has version info
 	readmePath := filepath.Join(path, "README.md")
-	if readmeContent, err := os.ReadFile(readmePath); err == nil {
+	if readmeContent, err := d.readFile(readmePath); err == nil {
 		readmeStr := string(readmeContent)
 		if strings.Contains(readmeStr, "DDALAB") {
 			return "detected"
@@ -145,12 +300,16 @@ func (d *Detector) extractVersion(path string) string {
 	return "unknown"
 }
 
-// ValidateInstallation performs comprehensive validation of an installation
-func (d *Detector) ValidateInstallation(path string) error {
+// ValidateInstallation performs comprehensive validation of an installation.
+// It returns ctx's error if ctx is done before all checks ran.
+func (d *Detector) ValidateInstallation(ctx context.Context, path string) error {
 	info := d.DetectInstallation(path)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
 	if !info.Valid {
-		return fmt.Errorf("invalid DDALAB installation at %s", path)
+		return fmt.Errorf("invalid DDALAB installation at %s: %s", path, info.Problem)
 	}
 
 	// Check if Docker is available
@@ -158,6 +317,10 @@ func (d *Detector) ValidateInstallation(path string) error {
 		return fmt.Errorf("docker is not available or not running")
 	}
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Check if docker-compose is available
 	if !d.isDockerComposeAvailable() {
 		return fmt.Errorf("docker-compose is not available")
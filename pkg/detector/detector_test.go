@@ -0,0 +1,118 @@
+package detector
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ddalabCompose is a minimal compose file of a DDALAB installation
+const ddalabCompose = `services:
+  ddalab:
+    image: sdraeger1/ddalab:1.2.3
+  postgres:
+    image: postgres:16
+  redis:
+    image: redis:7
+`
+
+// writeInstallation creates a DDALAB installation with the compose file
+// and a README padded to readmeSize bytes in dir
+func writeInstallation(tb testing.TB, dir, compose string, readmeSize int) {
+	tb.Helper()
+	readme := "# DDALAB\n" + strings.Repeat("Lorem ipsum dolor sit amet.\n", readmeSize/28)
+	files := map[string]string{
+		"docker-compose.yml": compose,
+		"README.md":          readme,
+		"ddalab.sh":          "#!/bin/sh\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+}
+
+func TestDetectInstallationFixtures(t *testing.T) {
+	d := NewDetector()
+
+	ddalab := d.DetectInstallation(filepath.Join("testdata", "ddalab"))
+	if !ddalab.Valid || ddalab.Problem != "" || ddalab.Version != "1.2.3" {
+		t.Errorf("ddalab fixture = %+v, want a valid 1.2.3 installation", ddalab)
+	}
+
+	// A compose project with the usual files and even a ddalab.sh, but
+	// none of the DDALAB services
+	other := d.DetectInstallation(filepath.Join("testdata", "wordpress"))
+	if other.Valid {
+		t.Errorf("wordpress fixture is valid, want it rejected")
+	}
+	if want := []string{"ddalab", "postgres", "redis"}; !slices.Equal(other.MissingServices, want) {
+		t.Errorf("MissingServices = %v, want %v", other.MissingServices, want)
+	}
+	if !strings.Contains(other.Problem, "another project") {
+		t.Errorf("Problem = %q, want it to mention another project", other.Problem)
+	}
+	if err := d.ValidateInstallation(context.Background(), filepath.Join("testdata", "wordpress")); err == nil {
+		t.Error("ValidateInstallation accepted the wordpress fixture")
+	}
+}
+
+func TestDetectInstallationRereadsChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeInstallation(t, dir, ddalabCompose, 0)
+	d := NewDetector()
+
+	if info := d.DetectInstallation(dir); !info.Valid || info.Version != "1.2.3" {
+		t.Fatalf("DetectInstallation = %+v, want a valid 1.2.3 installation", info)
+	}
+
+	// A newer modification time must invalidate the cached compose file
+	composePath := filepath.Join(dir, "docker-compose.yml")
+	updated := strings.Replace(ddalabCompose, "1.2.3", "1.3.0", 1)
+	if err := os.WriteFile(composePath, []byte(updated), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(composePath, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	if info := d.DetectInstallation(dir); info.Version != "1.3.0" {
+		t.Errorf("version after the compose file changed = %q, want 1.3.0", info.Version)
+	}
+}
+
+// BenchmarkDetectInstallation compares repeated scans of one installation,
+// as the menu and the status checks do, with and without the file cache.
+// The compose file and README are padded to the size of a real installation.
+func BenchmarkDetectInstallation(b *testing.B) {
+	dir := b.TempDir()
+	compose := ddalabCompose + strings.Repeat("# Comment padding the compose file to a realistic size\n", 550)
+	writeInstallation(b, dir, compose, 68*1024)
+
+	b.Run("cached", func(b *testing.B) {
+		d := NewDetector()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if !d.DetectInstallation(dir).Valid {
+				b.Fatal("installation not detected")
+			}
+		}
+	})
+
+	b.Run("uncached", func(b *testing.B) {
+		d := NewDetector()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			d.ClearCache()
+			if !d.DetectInstallation(dir).Valid {
+				b.Fatal("installation not detected")
+			}
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package diagnostics
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// secretPatterns find credentials that a service wrote to its logs
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)((?:password|passwd|secret|token|api[_-]?key)\w*"?\s*[=:]\s*"?)[^\s",]+`),
+	regexp.MustCompile(`(?i)(bearer\s+)\S+`),
+	regexp.MustCompile(`(://[^/\s:@]+:)[^@\s/]+(@)`),
+}
+
+// minSecretLength is the shortest secret value removed from logs verbatim
+const minSecretLength = 4
+
+// DefaultBundlePath returns the default location of a support bundle
+func DefaultBundlePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	name := fmt.Sprintf("ddalab-support-%s.zip", time.Now().Format("20060102-150405"))
+	return filepath.Join(homeDir, name), nil
+}
+
+// RedactLogs removes the report's secret values and anything that looks
+// like a credential from logs
+func (r *Report) RedactLogs(logs string) string {
+	for _, secret := range r.secrets {
+		// Replacing a very short value would mangle unrelated log text
+		if len(secret) >= minSecretLength {
+			logs = strings.ReplaceAll(logs, secret, redacted)
+		}
+	}
+	for _, pattern := range secretPatterns {
+		logs = pattern.ReplaceAllString(logs, "${1}"+redacted+"${2}")
+	}
+	return logs
+}
+
+// WriteBundle writes a support bundle to path: a zip with the markdown
+// report, the report as JSON and the logs, which must already be redacted.
+// If the logs could not be fetched, logsErr is recorded instead.
+func (r *Report) WriteBundle(path, logs string, logsErr error) (err error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create support bundle: %w", err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to write support bundle: %w", closeErr)
+		}
+		if err != nil {
+			os.Remove(path) // Never leave a truncated bundle behind
+		}
+	}()
+
+	reportJSON, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode diagnostics: %w", err)
+	}
+	if logsErr != nil {
+		logs = fmt.Sprintf("Logs unavailable: %v\n", logsErr)
+	}
+
+	archive := zip.NewWriter(file)
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{"diagnostics.md", []byte(r.Markdown())},
+		{"diagnostics.json", reportJSON},
+		{"logs.txt", []byte(logs)},
+	}
+	for _, f := range files {
+		writer, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     f.name,
+			Method:   zip.Deflate,
+			Modified: r.GeneratedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write support bundle: %w", err)
+		}
+		if _, err := writer.Write(f.content); err != nil {
+			return fmt.Errorf("failed to write support bundle: %w", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+	return nil
+}
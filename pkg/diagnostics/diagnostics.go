@@ -0,0 +1,300 @@
+package diagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/audit"
+	"github.com/ddalab/launcher/pkg/config"
+	"github.com/ddalab/launcher/pkg/detector"
+	"github.com/ddalab/launcher/pkg/mode"
+	"github.com/ddalab/launcher/pkg/updater"
+)
+
+// redacted replaces secret values in the report
+const redacted = "[REDACTED]"
+
+// auditEntries is how many recent operations the report includes
+const auditEntries = 10
+
+// Report gathers the information maintainers need to triage a bug report
+type Report struct {
+	GeneratedAt     time.Time
+	LauncherVersion string
+	GoVersion       string
+	Platform        string
+	ModeStatus      mode.ModeStatus
+	Circuit         *api.CircuitInfo // API client circuit breaker, nil without a client
+	Installation    *detector.InstallationInfo
+	Config          config.LauncherConfig
+	EnvFilePath     string
+	EnvLocalPath    string // The .env.local overlay, if any
+	EnvVariables    []config.EnvVar
+	EnvError        string
+	AuditLog        []audit.Entry // Recent operations, oldest first
+	AuditError      string
+
+	secrets []string // Redacted values, also removed from bundled logs
+}
+
+// Collect builds a diagnostics report from the current launcher state.
+// Secrets are redacted before they are stored in the report.
+func Collect(configManager *config.ConfigManager, modeManager *mode.Manager, det *detector.Detector) *Report {
+	report := &Report{
+		GeneratedAt:     time.Now(),
+		LauncherVersion: config.GetVersion(),
+		GoVersion:       runtime.Version(),
+		Platform:        updater.GetPlatformString(),
+		ModeStatus:      modeManager.GetModeStatus(),
+		Config:          sanitizeConfig(*configManager.GetConfig()),
+	}
+	if token := configManager.GetAPIToken(); token != "" {
+		report.secrets = append(report.secrets, token)
+	}
+	report.ModeStatus.APIEndpoint = sanitizeURL(report.ModeStatus.APIEndpoint)
+	if apiClient := modeManager.GetAPIClient(); apiClient != nil {
+		circuit := apiClient.Circuit()
+		report.Circuit = &circuit
+	}
+
+	report.collectInstallation(configManager, det)
+
+	// Read last, so the secrets found in the installation are known
+	if path, err := audit.DefaultPath(); err == nil {
+		report.collectAuditLog(path)
+	} else {
+		report.AuditError = err.Error()
+	}
+
+	return report
+}
+
+// collectInstallation adds the detected installation and its .env with
+// secret values redacted
+func (r *Report) collectInstallation(configManager *config.ConfigManager, det *detector.Detector) {
+	ddalabPath := configManager.GetDDALABPath()
+	if ddalabPath == "" {
+		return
+	}
+
+	r.Installation = det.DetectInstallation(ddalabPath)
+
+	envPath, err := configManager.EnvFilePath()
+	if err != nil {
+		r.EnvError = err.Error()
+		return
+	}
+	r.EnvFilePath = envPath
+
+	envConfig, err := config.LoadEnvFile(envPath)
+	if err != nil {
+		r.EnvError = err.Error()
+		return
+	}
+
+	r.EnvLocalPath = envConfig.LocalPath
+
+	for _, envVar := range envConfig.Variables {
+		if envVar.IsSecret && envVar.Value != "" {
+			r.secrets = append(r.secrets, envVar.Value)
+			envVar.Value = redacted
+		}
+		if envVar.IsSecret && envVar.BaseValue != "" {
+			r.secrets = append(r.secrets, envVar.BaseValue)
+			envVar.BaseValue = redacted
+		}
+		r.EnvVariables = append(r.EnvVariables, envVar)
+	}
+}
+
+// collectAuditLog adds the last auditEntries operations from the audit log
+// at path. Errors may quote secrets, so each entry is redacted like API
+// bodies and then like logs.
+func (r *Report) collectAuditLog(path string) {
+	lines, err := audit.Tail(path, auditEntries)
+	if err != nil {
+		r.AuditError = err.Error()
+		return
+	}
+
+	for _, line := range lines {
+		var entry audit.Entry
+		if err := json.Unmarshal(api.RedactSecrets(line), &entry); err != nil {
+			continue // A line cut short by a crash
+		}
+		entry.Error = r.RedactLogs(entry.Error)
+		r.AuditLog = append(r.AuditLog, entry)
+	}
+}
+
+// Markdown renders the report as a markdown document suitable for an issue
+func (r *Report) Markdown() string {
+	var b strings.Builder
+
+	b.WriteString("# DDALAB Launcher Diagnostics\n\n")
+	b.WriteString(fmt.Sprintf("Generated: %s\n\n", r.GeneratedAt.Format(time.RFC3339)))
+
+	b.WriteString("## Launcher\n\n")
+	b.WriteString(fmt.Sprintf("- Version: %s\n", r.LauncherVersion))
+	b.WriteString(fmt.Sprintf("- Go: %s\n", r.GoVersion))
+	b.WriteString(fmt.Sprintf("- Platform: %s (%s/%s)\n", r.Platform, runtime.GOOS, runtime.GOARCH))
+	b.WriteString(fmt.Sprintf("- Last operation: %s\n\n", lastOperation(r.Config)))
+
+	b.WriteString("## Mode\n\n")
+	b.WriteString(fmt.Sprintf("- Current mode: %s\n", r.ModeStatus.CurrentMode))
+	b.WriteString(fmt.Sprintf("- Configured mode: %s\n", r.ModeStatus.ConfiguredMode))
+	b.WriteString(fmt.Sprintf("- API available: %t\n", r.ModeStatus.APIAvailable))
+	if r.ModeStatus.APIEndpoint != "" {
+		b.WriteString(fmt.Sprintf("- API endpoint: %s\n", r.ModeStatus.APIEndpoint))
+	}
+	if r.ModeStatus.APIError != "" {
+		b.WriteString(fmt.Sprintf("- API error: %s\n", r.ModeStatus.APIError))
+	}
+	if r.ModeStatus.ServerVersion != "" {
+		b.WriteString(fmt.Sprintf("- Server version: %s\n", r.ModeStatus.ServerVersion))
+	}
+	if r.Circuit != nil {
+		b.WriteString(fmt.Sprintf("- Circuit breaker: %s (%d consecutive failures)\n", r.Circuit.State, r.Circuit.Failures))
+		if !r.Circuit.RetryAt.IsZero() {
+			b.WriteString(fmt.Sprintf("- Circuit retry at: %s\n", r.Circuit.RetryAt.Format(time.RFC3339)))
+		}
+	}
+	b.WriteString(fmt.Sprintf("- Bootstrap mode: %s\n", r.ModeStatus.BootstrapMode))
+	b.WriteString(fmt.Sprintf("- Can bootstrap: %t\n", r.ModeStatus.CanBootstrap))
+	b.WriteString(fmt.Sprintf("- Extension available: %t\n", r.ModeStatus.ExtensionAvailable))
+	if r.ModeStatus.DockerIssue != "" {
+		b.WriteString(fmt.Sprintf("- Docker issue: %s\n", r.ModeStatus.DockerIssue))
+		b.WriteString(fmt.Sprintf("- Remediation: %s\n", r.ModeStatus.Remediation))
+	}
+	b.WriteString("\n")
+
+	b.WriteString("## Installation\n\n")
+	if r.Installation == nil {
+		b.WriteString("No installation configured\n\n")
+	} else {
+		b.WriteString(fmt.Sprintf("- Path: %s\n", r.Installation.Path))
+		b.WriteString(fmt.Sprintf("- Valid: %t\n", r.Installation.Valid))
+		if r.Installation.Problem != "" {
+			b.WriteString(fmt.Sprintf("- Problem: %s\n", r.Installation.Problem))
+		}
+		if len(r.Installation.Services) > 0 {
+			b.WriteString(fmt.Sprintf("- Compose services: %s\n", strings.Join(r.Installation.Services, ", ")))
+		}
+		b.WriteString(fmt.Sprintf("- Version: %s\n", valueOrNone(r.Installation.Version)))
+		b.WriteString(fmt.Sprintf("- Docker Compose: %t\n", r.Installation.DockerCompose))
+		b.WriteString(fmt.Sprintf("- Scripts: %t\n", r.Installation.Scripts))
+		b.WriteString(fmt.Sprintf("- Certificates: %t\n\n", r.Installation.HasCertificates))
+	}
+
+	b.WriteString("## Launcher Configuration\n\n")
+	configJSON, err := json.MarshalIndent(r.Config, "", "  ")
+	if err != nil {
+		b.WriteString(fmt.Sprintf("Failed to encode configuration: %v\n\n", err))
+	} else {
+		b.WriteString("```json\n" + string(configJSON) + "\n```\n\n")
+	}
+
+	b.WriteString("## Recent Operations\n\n")
+	switch {
+	case r.AuditError != "":
+		b.WriteString(fmt.Sprintf("Unavailable: %s\n\n", r.AuditError))
+	case len(r.AuditLog) == 0:
+		b.WriteString("No operations recorded\n\n")
+	default:
+		for _, entry := range r.AuditLog {
+			outcome := "succeeded"
+			if entry.Error != "" {
+				outcome = "failed: " + entry.Error
+			}
+			b.WriteString(fmt.Sprintf("- %s %s %s\n", entry.Time.Format(time.RFC3339), entry.Operation, outcome))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Environment (.env)\n\n")
+	switch {
+	case r.EnvError != "":
+		b.WriteString(fmt.Sprintf("Unavailable: %s\n", r.EnvError))
+	case r.EnvFilePath == "":
+		b.WriteString("No installation configured\n")
+	default:
+		b.WriteString(fmt.Sprintf("File: %s\n", r.EnvFilePath))
+		if r.EnvLocalPath != "" {
+			b.WriteString(fmt.Sprintf("Overlay: %s (values marked # local)\n", r.EnvLocalPath))
+		}
+		b.WriteString("\n```\n")
+		for _, envVar := range r.EnvVariables {
+			if envVar.Local {
+				b.WriteString(fmt.Sprintf("%s=%s # local\n", envVar.Key, envVar.Value))
+				continue
+			}
+			b.WriteString(fmt.Sprintf("%s=%s\n", envVar.Key, envVar.Value))
+		}
+		b.WriteString("```\n")
+	}
+
+	return b.String()
+}
+
+// DefaultPath returns the default location of the exported report
+func DefaultPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, "ddalab-diagnostics.md"), nil
+}
+
+// WriteFile writes the markdown report to the given path
+func (r *Report) WriteFile(path string) error {
+	if err := os.WriteFile(path, []byte(r.Markdown()), 0600); err != nil {
+		return fmt.Errorf("failed to write diagnostics file: %w", err)
+	}
+	return nil
+}
+
+// sanitizeConfig strips credentials from the launcher configuration
+func sanitizeConfig(cfg config.LauncherConfig) config.LauncherConfig {
+	cfg.APIEndpoint = sanitizeURL(cfg.APIEndpoint)
+	cfg.TelemetryEndpoint = sanitizeURL(cfg.TelemetryEndpoint)
+	if cfg.APIToken != "" {
+		cfg.APIToken = redacted
+	}
+	return cfg
+}
+
+// sanitizeURL removes any user credentials embedded in a URL
+func sanitizeURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.User == nil {
+		return rawURL
+	}
+	parsed.User = url.User(redacted)
+	return parsed.String()
+}
+
+// lastOperation describes the last operation with its outcome and time
+func lastOperation(cfg config.LauncherConfig) string {
+	if cfg.LastOperation == "" || cfg.LastOperationTime.IsZero() {
+		return valueOrNone(cfg.LastOperation)
+	}
+	outcome := "succeeded"
+	if cfg.LastOperationFailed {
+		outcome = "failed"
+	}
+	return fmt.Sprintf("%s (%s at %s)", cfg.LastOperation, outcome, cfg.LastOperationTime.Format(time.RFC3339))
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
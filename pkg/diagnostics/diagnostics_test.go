@@ -0,0 +1,137 @@
+package diagnostics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ddalab/launcher/pkg/audit"
+	"github.com/ddalab/launcher/pkg/config"
+	"github.com/ddalab/launcher/pkg/detector"
+	"github.com/ddalab/launcher/pkg/mode"
+)
+
+// Secrets planted in the config, .env and audit log of a test installation
+const (
+	apiToken   = "launcher-api-token"
+	dbPassword = "hunter2-db-password"
+)
+
+// newTestSetup returns a config manager for an installation whose .env
+// holds a password and an API endpoint without a backend. The audit log
+// goes to a temporary cache directory.
+func newTestSetup(t *testing.T) *config.ConfigManager {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("XDG_CACHE_HOME is only honored on Linux")
+	}
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("DDALAB_API_TOKEN", "")
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+	endpoint := server.URL
+
+	ddalabPath := t.TempDir()
+	env := "DB_PASSWORD=" + dbPassword + "\nDB_HOST=postgres\n"
+	if err := os.WriteFile(filepath.Join(ddalabPath, ".env"), []byte(env), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	data := `{"api_endpoint": "` + endpoint + `", "api_token": "` + apiToken + `", "ddalab_path": "` + ddalabPath + `"}`
+	if err := os.WriteFile(configPath, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	configManager, err := config.NewConfigManagerWithPath(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return configManager
+}
+
+func collect(t *testing.T, configManager *config.ConfigManager) *Report {
+	t.Helper()
+	return Collect(configManager, mode.NewManager(configManager), detector.NewDetector())
+}
+
+func TestCollectRedactsSecrets(t *testing.T) {
+	configManager := newTestSetup(t)
+	report := collect(t, configManager)
+
+	markdown := report.Markdown()
+	for _, secret := range []string{apiToken, dbPassword} {
+		if strings.Contains(markdown, secret) {
+			t.Errorf("report contains the secret %q", secret)
+		}
+	}
+	if !strings.Contains(markdown, "DB_PASSWORD="+redacted) {
+		t.Errorf("report does not show DB_PASSWORD as redacted")
+	}
+	if !strings.Contains(markdown, "DB_HOST=postgres") {
+		t.Errorf("report lost the non-secret DB_HOST")
+	}
+}
+
+func TestCollectIncludesRedactedAuditLog(t *testing.T) {
+	configManager := newTestSetup(t)
+	if err := audit.Record("start", nil); err != nil {
+		t.Fatal(err)
+	}
+	failure := errors.New("login as postgres:" + dbPassword + " failed, Authorization: Bearer " + apiToken)
+	if err := audit.Record("restart", failure); err != nil {
+		t.Fatal(err)
+	}
+
+	report := collect(t, configManager)
+	if len(report.AuditLog) != 2 {
+		t.Fatalf("report has %d audit entries, want 2", len(report.AuditLog))
+	}
+
+	markdown := report.Markdown()
+	for _, want := range []string{"start succeeded", "restart failed: "} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("report lacks %q", want)
+		}
+	}
+	for _, secret := range []string{apiToken, dbPassword} {
+		if strings.Contains(markdown, secret) {
+			t.Errorf("audit entry leaks the secret %q", secret)
+		}
+	}
+}
+
+func TestCollectKeepsLastAuditEntries(t *testing.T) {
+	configManager := newTestSetup(t)
+	for i := 0; i < auditEntries+5; i++ {
+		if err := audit.Record("status", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := len(collect(t, configManager).AuditLog); got != auditEntries {
+		t.Errorf("report has %d audit entries, want the last %d", got, auditEntries)
+	}
+}
+
+func TestRedactLogs(t *testing.T) {
+	report := &Report{secrets: []string{"s3cr3t-value", "abc"}}
+	tests := map[string]string{
+		"connecting with s3cr3t-value":        "connecting with " + redacted,
+		"password=letmein user=bob":           "password=" + redacted + " user=bob",
+		`"api_key": "k-123"`:                  `"api_key": "` + redacted + `"`,
+		"Authorization: Bearer eyJhbGciOi":    "Authorization: Bearer " + redacted,
+		"postgres://ddalab:pw@db:5432/ddalab": "postgres://ddalab:" + redacted + "@db:5432/ddalab",
+		"abc is too short to replace":         "abc is too short to replace",
+	}
+	for input, want := range tests {
+		if got := report.RedactLogs(input); got != want {
+			t.Errorf("RedactLogs(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
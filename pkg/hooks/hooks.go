@@ -0,0 +1,182 @@
+// Package hooks runs user-configured commands around DDALAB lifecycle
+// operations, e.g. to seed a database after a start.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/ddalab/launcher/pkg/config"
+)
+
+// Event identifies when a hook runs
+type Event string
+
+const (
+	PreStart  Event = "pre_start"
+	PostStart Event = "post_start"
+	PreStop   Event = "pre_stop"
+	PostStop  Event = "post_stop"
+)
+
+// Context describes the installation a hook runs against. Its fields are
+// available in command templates and as DDALAB_* environment variables.
+type Context struct {
+	Event Event
+	Path  string // DDALAB installation path
+	URL   string // URL where DDALAB is accessible
+}
+
+// Runner executes the hooks from a HooksConfig
+type Runner struct {
+	config config.HooksConfig
+	output func(line string)
+}
+
+// NewRunner creates a hook runner. Each line the hook prints to stdout or
+// stderr is passed to output; a nil output discards it.
+func NewRunner(cfg config.HooksConfig, output func(line string)) *Runner {
+	if output == nil {
+		output = func(string) {}
+	}
+	return &Runner{config: cfg, output: output}
+}
+
+// AbortOnFailure reports whether a failing pre hook should abort the operation
+func (r *Runner) AbortOnFailure() bool {
+	return r.config.AbortOnFailure
+}
+
+// Run executes the hook for hctx.Event, if one is configured. The command
+// is killed when ctx is cancelled.
+func (r *Runner) Run(ctx context.Context, hctx Context) error {
+	command, err := r.command(hctx)
+	if err != nil || command == "" {
+		return err
+	}
+
+	r.output(fmt.Sprintf("Running %s hook: %s", hctx.Event, command))
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Dir = hctx.Path
+	cmd.Env = append(os.Environ(),
+		"DDALAB_PATH="+hctx.Path,
+		"DDALAB_URL="+hctx.URL,
+		"DDALAB_HOOK_EVENT="+string(hctx.Event),
+	)
+
+	out := &lineWriter{emit: r.output}
+	cmd.Stdout = out
+	cmd.Stderr = out
+	err = cmd.Run()
+	out.Flush()
+
+	if err != nil {
+		return fmt.Errorf("%s hook failed: %w", hctx.Event, err)
+	}
+	return nil
+}
+
+// command renders the command template configured for the event
+func (r *Runner) command(hctx Context) (string, error) {
+	var tmpl string
+	switch hctx.Event {
+	case PreStart:
+		tmpl = r.config.PreStart
+	case PostStart:
+		tmpl = r.config.PostStart
+	case PreStop:
+		tmpl = r.config.PreStop
+	case PostStop:
+		tmpl = r.config.PostStop
+	}
+
+	tmpl = strings.TrimSpace(tmpl)
+	if tmpl == "" {
+		return "", nil
+	}
+
+	parsed, err := template.New(string(hctx.Event)).Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s hook template: %w", hctx.Event, err)
+	}
+
+	// Quote the values so paths with spaces or shell metacharacters are
+	// passed as single words rather than run as part of the command
+	quoted := Context{
+		Event: Event(shellQuote(string(hctx.Event))),
+		Path:  shellQuote(hctx.Path),
+		URL:   shellQuote(hctx.URL),
+	}
+
+	var b strings.Builder
+	if err := parsed.Execute(&b, quoted); err != nil {
+		return "", fmt.Errorf("invalid %s hook template: %w", hctx.Event, err)
+	}
+
+	return b.String(), nil
+}
+
+var safeShellWord = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote quotes value as a single word for the shell hooks run in, if
+// it needs quoting. cmd cannot escape every character, so on Windows
+// values are only double-quoted; hooks there should prefer %DDALAB_PATH%.
+func shellQuote(value string) string {
+	if safeShellWord.MatchString(value) {
+		return value
+	}
+	if runtime.GOOS == "windows" {
+		return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// lineWriter forwards complete lines written by a process to emit
+type lineWriter struct {
+	mu   sync.Mutex
+	buf  bytes.Buffer
+	emit func(line string)
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Write(p)
+	for {
+		line, err := w.buf.ReadString('\n')
+		if err != nil {
+			// Keep the partial line until the rest arrives
+			w.buf.Reset()
+			w.buf.WriteString(line)
+			break
+		}
+		w.emit(strings.TrimRight(line, "\r\n"))
+	}
+	return len(p), nil
+}
+
+// Flush emits any trailing output without a final newline
+func (w *lineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.buf.Len() > 0 {
+		w.emit(strings.TrimRight(w.buf.String(), "\r\n"))
+		w.buf.Reset()
+	}
+}
@@ -0,0 +1,59 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ddalab/launcher/pkg/config"
+)
+
+func TestRunQuotesTemplateValues(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	root := t.TempDir()
+	path := filepath.Join(root, "lab's data; $(touch injected)")
+	if err := os.Mkdir(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []string
+	runner := NewRunner(config.HooksConfig{PostStart: `printf '%s\n' {{.Path}} "$DDALAB_PATH"`}, func(line string) {
+		lines = append(lines, line)
+	})
+	if err := runner.Run(context.Background(), Context{Event: PostStart, Path: path}); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(lines) != 3 || lines[1] != path || lines[2] != path {
+		t.Errorf("hook printed %q, want the path twice after the announcement", lines)
+	}
+	for _, dir := range []string{root, path} {
+		if _, err := os.Stat(filepath.Join(dir, "injected")); err == nil {
+			t.Errorf("template value was run as a command")
+		}
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("POSIX quoting")
+	}
+
+	tests := map[string]string{
+		"/opt/ddalab":            "/opt/ddalab",
+		"https://localhost:8443": "https://localhost:8443",
+		"/home/me/my lab":        "'/home/me/my lab'",
+		"it's":                   `'it'\''s'`,
+		"":                       "''",
+	}
+	for value, want := range tests {
+		if got := shellQuote(value); got != want {
+			t.Errorf("shellQuote(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
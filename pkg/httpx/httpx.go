@@ -0,0 +1,103 @@
+// Package httpx creates the HTTP clients the launcher uses for internet
+// requests, so proxy, TLS and connection settings live in one place.
+package httpx
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Transport limits. Overall deadlines are set per request through the
+// request context, since a release check and a binary download need very
+// different amounts of time.
+const (
+	dialTimeout         = 10 * time.Second
+	tlsHandshakeTimeout = 10 * time.Second
+	idleConnTimeout     = 90 * time.Second
+)
+
+// NewClient returns an HTTP client that honours HTTP_PROXY, HTTPS_PROXY and
+// NO_PROXY and reuses connections. It has no overall timeout; callers bound
+// each request with a context deadline.
+func NewClient() *http.Client {
+	return &http.Client{Transport: NewTransport()}
+}
+
+// NewTransport returns the transport used by NewClient
+func NewTransport() *http.Transport {
+	return newTransport(dialTimeout)
+}
+
+// connectTimeoutKey carries a connect timeout in a request context
+type connectTimeoutKey struct{}
+
+// WithConnectTimeout returns a context whose requests through a transport
+// of this package give up connecting after timeout rather than the default,
+// e.g. so a status check of a backend that is down fails fast while other
+// requests to it keep the usual limit
+func WithConnectTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, connectTimeoutKey{}, timeout)
+}
+
+// ConnectTimeout returns the connect timeout set by WithConnectTimeout
+func ConnectTimeout(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(connectTimeoutKey{}).(time.Duration)
+	return timeout, ok && timeout > 0
+}
+
+// newTransport returns a proxy-aware transport with the given connect
+// timeout, unless the request context sets another
+func newTransport(dialTimeout time.Duration) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		if timeout, ok := ConnectTimeout(ctx); ok {
+			custom := *dialer
+			custom.Timeout = timeout
+			return custom.DialContext(ctx, network, address)
+		}
+		return dialer.DialContext(ctx, network, address)
+	}
+	transport.TLSHandshakeTimeout = tlsHandshakeTimeout
+	transport.IdleConnTimeout = idleConnTimeout
+	return transport
+}
+
+// TrustCACert makes client also trust the CAs in the PEM file at path, e.g.
+// for a remote backend with a certificate from a lab-internal CA. The client
+// must use a transport created by this package.
+func TrustCACert(client *http.Client, path string) error {
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("unsupported transport %T", client.Transport)
+	}
+
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return fmt.Errorf("no certificates found in %s", path)
+	}
+
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	transport.TLSClientConfig.RootCAs = pool
+	return nil
+}
@@ -0,0 +1,49 @@
+package httpx
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestConnectTimeoutReachesDialer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	transport := NewTransport()
+	dial := transport.DialContext
+	var seen time.Duration
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		seen, _ = ConnectTimeout(ctx)
+		return dial(ctx, network, address)
+	}
+	client := &http.Client{Transport: transport}
+	t.Cleanup(transport.CloseIdleConnections)
+
+	ctx := WithConnectTimeout(context.Background(), 250*time.Millisecond)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if seen != 250*time.Millisecond {
+		t.Errorf("dialer saw connect timeout %s, want 250ms", seen)
+	}
+}
+
+func TestConnectTimeoutUnset(t *testing.T) {
+	if timeout, ok := ConnectTimeout(context.Background()); ok {
+		t.Errorf("ConnectTimeout = %s without WithConnectTimeout, want none", timeout)
+	}
+	if timeout, ok := ConnectTimeout(WithConnectTimeout(context.Background(), 0)); ok {
+		t.Errorf("ConnectTimeout = %s for zero, want none", timeout)
+	}
+}
@@ -0,0 +1,105 @@
+package i18n
+
+var german = &catalog{
+	emoji: true,
+	messages: map[string]string{
+		// Message prefixes
+		"ui.error":   "Fehler: %s",
+		"ui.warning": "Warnung: %s",
+
+		// Prompts
+		"ui.confirm":                  "Möchten Sie wirklich Folgendes tun: %s?",
+		"ui.confirmed_auto":           "Automatisch bestätigt (--yes): %s",
+		"ui.continue":                 "Weiter mit Enter...",
+		"ui.yes":                      "Ja",
+		"ui.no":                       "Nein",
+		"ui.help.menu":                "↑/↓: navigieren • Enter: auswählen • q: beenden",
+		"ui.help.prompt":              "Enter: bestätigen • Strg+U: leeren • Esc: abbrechen",
+		"ui.help.confirm":             "←/→: navigieren • Enter/Leertaste: auswählen • y/n: Schnellauswahl • Esc: abbrechen",
+		"ui.help.confirm_danger":      "←/→: navigieren • Enter/Leertaste: auswählen • n/Esc: abbrechen",
+		"ui.countdown":                "%s in %d… beliebige Taste zum Abbrechen",
+		"ui.status":                   "DDALAB-Status: %s",
+		"ui.simple.choose":            "Auswahl 1-%d (Enter: %d, q: beenden): ",
+		"ui.simple.invalid":           "Bitte eine Zahl zwischen 1 und %d eingeben",
+		"ui.simple.confirm":           "%s [J/n]: ",
+		"ui.simple.danger":            "%s Zur Bestätigung '%s' eingeben: ",
+		"ui.simple.countdown":         "%s in %d Sekunden… Enter drücken zum Abbrechen",
+		"ui.menu.title":               "Was möchten Sie tun?",
+		"ui.menu.services":            "Dienstverwaltung",
+		"ui.menu.management":          "Systemverwaltung",
+		"ui.role":                     "Rolle: %s",
+		"ui.environment":              "Umgebung: %s",
+		"ui.last_operation":           "Zuletzt: %s %s vor %s",
+		"ui.last_operation.ok":        "erfolgreich",
+		"ui.last_operation.failed":    "fehlgeschlagen",
+		"ui.select_environment":       "Deployment-Umgebung auswählen",
+		"ui.what_changed":             "Was ist neu in %s?",
+		"ui.major_upgrade":            "%s ist eine neue Hauptversion und kann inkompatible Änderungen enthalten - bitte die Hinweise unten lesen, bevor bestehende Einstellungen weiter verwendet werden",
+		"ui.full_changelog":           "Vollständiges Änderungsprotokoll: %s",
+		"ui.select_compose_operation": "Docker-Compose-Befehl anzeigen für",
+		"ui.select_env_backup":        "Wiederherzustellende .env-Sicherung auswählen",
+		"ui.select_api_endpoint":      "DDALAB-API-Endpunkt auswählen",
+		"ui.api_endpoint_other":       "Andere Adresse eingeben",
+		"ui.api_endpoint_keep":        "%s beibehalten",
+		"ui.api_endpoint_prompt":      "Adresse der DDALAB-API, z. B. http://server:8080",
+
+		// Menu entries, keyed by action
+		"menu.start":                            "DDALAB starten",
+		"menu.start.description":                "Alle DDALAB-Dienste starten",
+		"menu.stop":                             "DDALAB stoppen",
+		"menu.stop.description":                 "Alle DDALAB-Dienste stoppen",
+		"menu.deep-stop":                        "Tiefer Stopp (Waisen entfernen)",
+		"menu.deep-stop.description":            "DDALAB stoppen und seine Container entfernen, auch verwaiste umbenannter Dienste",
+		"menu.restart":                          "DDALAB neu starten",
+		"menu.restart.description":              "Alle DDALAB-Dienste neu starten",
+		"menu.restart-unhealthy":                "Fehlerhafte Dienste neu starten",
+		"menu.restart-unhealthy.description":    "Nur Dienste neu starten, die nicht gesund sind",
+		"menu.status":                           "Status prüfen",
+		"menu.status.description":               "Status und Zustand der Dienste prüfen",
+		"menu.dashboard":                        "Live-Dashboard",
+		"menu.dashboard.description":            "Dienstzustand beobachten, bis q gedrückt wird",
+		"menu.logs":                             "Logs anzeigen",
+		"menu.logs.description":                 "Aktuelle Dienst-Logs anzeigen",
+		"menu.events":                           "Ereignisse",
+		"menu.events.description":               "Anmeldungen, abgeschlossene Analysen und andere Backend-Ereignisse live verfolgen",
+		"menu.bootstrap":                        "DDALAB bootstrappen",
+		"menu.bootstrap.description":            "DDALAB-Dienste starten, wenn die API nicht erreichbar ist",
+		"menu.edit-config":                      "Konfiguration bearbeiten",
+		"menu.edit-config.description":          "Umgebungsvariablen und Einstellungen bearbeiten",
+		"menu.apply-env":                        ".env anwenden und neu starten",
+		"menu.apply-env.description":            "Laufende Dienste neu starten, damit .env-Änderungen wirksam werden",
+		"menu.restore-env":                      "Vorherige .env wiederherstellen",
+		"menu.restore-env.description":          "Die .env-Datei auf eine frühere Version zurücksetzen",
+		"menu.configure":                        "Installation konfigurieren",
+		"menu.configure.description":            "DDALAB-Installationspfad ändern",
+		"menu.environment":                      "Umgebung auswählen",
+		"menu.environment.description":          "Zu verwendendes Deployment-Verzeichnis wählen",
+		"menu.open-folder":                      "Installationsordner öffnen",
+		"menu.open-folder.description":          "DDALAB-Verzeichnis im Dateimanager öffnen",
+		"menu.compose-command":                  "Compose-Befehl anzeigen",
+		"menu.compose-command.description":      "Docker-Compose-Befehl für eine Aktion anzeigen und kopieren",
+		"menu.regenerate-certs":                 "Zertifikate neu erstellen",
+		"menu.regenerate-certs.description":     "Abgelaufene oder fehlende TLS-Zertifikate neu erstellen",
+		"menu.backup":                           "Datenbank sichern",
+		"menu.backup.description":               "Datenbank-Backup erstellen",
+		"menu.update":                           "DDALAB aktualisieren",
+		"menu.update.description":               "Neueste DDALAB-Images laden und die Dienste neu starten",
+		"menu.check-ddalab-updates":             "DDALAB-Updates suchen",
+		"menu.check-ddalab-updates.description": "Prüfen, ob eine neuere DDALAB-Version für die Installation verfügbar ist",
+		"menu.check-updates":                    "Launcher-Updates suchen",
+		"menu.check-updates.description":        "Nach einer neueren Version dieses Launcher-Programms suchen",
+		"menu.update-channel":                   "Update-Kanal: Stabil ⇄ Beta",
+		"menu.update-channel.description":       "Zwischen stabilen und Beta-Versionen des Launchers wechseln und nach Updates suchen",
+		"menu.diagnostics":                      "Diagnose exportieren",
+		"menu.diagnostics.description":          "Diagnosedaten für Fehlerberichte speichern",
+		"menu.telemetry":                        "Telemetrie-Einstellungen",
+		"menu.telemetry.description":            "Genau sehen, was Fehlerberichte enthalten, und sie ein- oder ausschalten",
+		"menu.reload-settings":                  "Einstellungen neu laden",
+		"menu.reload-settings.description":      "Die Konfigurationsdatei nach manuellen Änderungen erneut einlesen",
+		"menu.uninstall":                        "DDALAB deinstallieren",
+		"menu.uninstall.description":            "DDALAB vollständig entfernen",
+		"menu.exit":                             "Beenden",
+		"menu.exit.description":                 "Launcher beenden",
+		"menu.back":                             "Zurück zum Hauptmenü",
+	},
+}
@@ -0,0 +1,105 @@
+package i18n
+
+var english = &catalog{
+	emoji: true,
+	messages: map[string]string{
+		// Message prefixes
+		"ui.error":   "Error: %s",
+		"ui.warning": "Warning: %s",
+
+		// Prompts
+		"ui.confirm":                  "Are you sure you want to %s?",
+		"ui.confirmed_auto":           "Confirmed automatically (--yes): %s",
+		"ui.continue":                 "Press Enter to continue...",
+		"ui.yes":                      "Yes",
+		"ui.no":                       "No",
+		"ui.help.menu":                "↑/↓: navigate • Enter: select • q: quit",
+		"ui.help.prompt":              "Enter: confirm • Ctrl+U: clear • Esc: cancel",
+		"ui.help.confirm":             "←/→: navigate • Enter/Space: select • y/n: quick select • Esc: cancel",
+		"ui.help.confirm_danger":      "←/→: navigate • Enter/Space: select • n/Esc: cancel",
+		"ui.countdown":                "%s in %d… press any key to cancel",
+		"ui.status":                   "DDALAB Status: %s",
+		"ui.simple.choose":            "Choose 1-%d (Enter: %d, q: quit): ",
+		"ui.simple.invalid":           "Please enter a number between 1 and %d",
+		"ui.simple.confirm":           "%s [Y/n]: ",
+		"ui.simple.danger":            "%s Type '%s' to confirm: ",
+		"ui.simple.countdown":         "%s in %d seconds… press Enter to cancel",
+		"ui.menu.title":               "What would you like to do?",
+		"ui.menu.services":            "Service Management",
+		"ui.menu.management":          "System Management",
+		"ui.role":                     "Role: %s",
+		"ui.environment":              "Environment: %s",
+		"ui.last_operation":           "Last: %s %s %s ago",
+		"ui.last_operation.ok":        "succeeded",
+		"ui.last_operation.failed":    "failed",
+		"ui.select_environment":       "Select deployment environment",
+		"ui.what_changed":             "What changed in %s?",
+		"ui.major_upgrade":            "%s is a new major version and may contain breaking changes - check the notes below before relying on existing settings",
+		"ui.full_changelog":           "Full changelog: %s",
+		"ui.select_compose_operation": "Show the docker compose command for",
+		"ui.select_env_backup":        "Select the .env backup to restore",
+		"ui.select_api_endpoint":      "Select the DDALAB API endpoint",
+		"ui.api_endpoint_other":       "Enter another address",
+		"ui.api_endpoint_keep":        "Keep %s",
+		"ui.api_endpoint_prompt":      "DDALAB API address, e.g. http://server:8080",
+
+		// Menu entries, keyed by action
+		"menu.start":                            "Start DDALAB",
+		"menu.start.description":                "Start all DDALAB services",
+		"menu.stop":                             "Stop DDALAB",
+		"menu.stop.description":                 "Stop all DDALAB services",
+		"menu.deep-stop":                        "Deep Stop (remove orphans)",
+		"menu.deep-stop.description":            "Stop DDALAB and remove its containers, including orphans of renamed services",
+		"menu.restart":                          "Restart DDALAB",
+		"menu.restart.description":              "Restart all DDALAB services",
+		"menu.restart-unhealthy":                "Restart Unhealthy Services",
+		"menu.restart-unhealthy.description":    "Restart only services that are not healthy",
+		"menu.status":                           "Check Status",
+		"menu.status.description":               "Check service status and health",
+		"menu.dashboard":                        "Live Dashboard",
+		"menu.dashboard.description":            "Watch service health until you press q",
+		"menu.logs":                             "View Logs",
+		"menu.logs.description":                 "View recent service logs",
+		"menu.events":                           "Events",
+		"menu.events.description":               "Follow logins, finished analyses and other backend events live",
+		"menu.bootstrap":                        "Bootstrap DDALAB",
+		"menu.bootstrap.description":            "Bootstrap DDALAB services when API is unavailable",
+		"menu.edit-config":                      "Edit Configuration",
+		"menu.edit-config.description":          "Edit environment variables and settings",
+		"menu.apply-env":                        "Apply .env and Restart",
+		"menu.apply-env.description":            "Restart running services so .env changes take effect",
+		"menu.restore-env":                      "Restore Previous .env",
+		"menu.restore-env.description":          "Roll the .env file back to an earlier version",
+		"menu.configure":                        "Configure Installation",
+		"menu.configure.description":            "Change DDALAB installation path",
+		"menu.environment":                      "Select Environment",
+		"menu.environment.description":          "Choose which deployment directory to use",
+		"menu.open-folder":                      "Open Installation Folder",
+		"menu.open-folder.description":          "Open the DDALAB directory in your file manager",
+		"menu.compose-command":                  "Show Compose Command",
+		"menu.compose-command.description":      "Print and copy the docker compose command for an operation",
+		"menu.regenerate-certs":                 "Regenerate Certificates",
+		"menu.regenerate-certs.description":     "Recreate expired or missing TLS certificates",
+		"menu.backup":                           "Backup Database",
+		"menu.backup.description":               "Create database backup",
+		"menu.update":                           "Update DDALAB",
+		"menu.update.description":               "Pull the latest DDALAB images and restart the services",
+		"menu.check-ddalab-updates":             "Check for DDALAB Updates",
+		"menu.check-ddalab-updates.description": "See whether a newer DDALAB release is available for your installation",
+		"menu.check-updates":                    "Check for Launcher Updates",
+		"menu.check-updates.description":        "Check for a newer version of this launcher program",
+		"menu.update-channel":                   "Update Channel: Stable ⇄ Beta",
+		"menu.update-channel.description":       "Switch between stable and beta launcher builds and check for updates",
+		"menu.diagnostics":                      "Export Diagnostics",
+		"menu.diagnostics.description":          "Save diagnostics for bug reports",
+		"menu.telemetry":                        "Telemetry Settings",
+		"menu.telemetry.description":            "See exactly what failure reports contain and turn them on or off",
+		"menu.reload-settings":                  "Reload Settings",
+		"menu.reload-settings.description":      "Read the config file again after editing it by hand",
+		"menu.uninstall":                        "Uninstall DDALAB",
+		"menu.uninstall.description":            "Remove DDALAB completely",
+		"menu.exit":                             "Exit",
+		"menu.exit.description":                 "Exit the launcher",
+		"menu.back":                             "Back to Main Menu",
+	},
+}
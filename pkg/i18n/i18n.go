@@ -0,0 +1,115 @@
+// Package i18n provides translated UI strings looked up by message ID.
+// Messages never contain emoji; the UI adds icons separately so that a
+// locale can opt out of them.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is used when no supported locale is configured
+const DefaultLocale = "en"
+
+// catalog holds the messages of one locale
+type catalog struct {
+	emoji    bool              // Whether the UI should show emoji icons
+	messages map[string]string // Message ID -> format string
+}
+
+var catalogs = map[string]*catalog{
+	"en": english,
+	"de": german,
+}
+
+var (
+	mu      sync.RWMutex
+	current = english
+	locale  = DefaultLocale
+)
+
+// SetLocale selects the catalog for tag, e.g. "de", "de_DE.UTF-8" or
+// "de-AT". Unsupported locales fall back to English.
+func SetLocale(tag string) {
+	code := normalize(tag)
+	c, ok := catalogs[code]
+	if !ok {
+		code, c = DefaultLocale, english
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	locale, current = code, c
+}
+
+// Locale returns the active locale code
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return locale
+}
+
+// Detect returns the locale to use: the configured one if set, otherwise
+// the first of LC_ALL, LC_MESSAGES and LANG that is set
+func Detect(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return DefaultLocale
+}
+
+// Supported returns the supported locale codes
+func Supported() []string {
+	codes := make([]string, 0, len(catalogs))
+	for code := range catalogs {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Emoji returns true if the active locale shows emoji icons
+func Emoji() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current.emoji
+}
+
+// T returns the translation of the message ID, formatted with args. It
+// falls back to English and then to the ID itself.
+func T(id string, args ...any) string {
+	mu.RLock()
+	format, ok := current.messages[id]
+	mu.RUnlock()
+
+	if !ok {
+		if format, ok = english.messages[id]; !ok {
+			format = id
+		}
+	}
+
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// normalize reduces a locale tag such as "de_DE.UTF-8" to its language code
+func normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "_-.@"); i >= 0 {
+		tag = tag[:i]
+	}
+	if tag == "c" || tag == "posix" {
+		return DefaultLocale
+	}
+	return tag
+}
@@ -2,11 +2,14 @@ package interrupt
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"sync"
 	"syscall"
+
+	"github.com/ddalab/launcher/pkg/theme"
 )
 
 // Handler manages interrupt signals for graceful cancellation
@@ -59,7 +62,7 @@ func (h *Handler) handleSignals() {
 		h.mu.RUnlock()
 
 		if active && cancel != nil {
-			fmt.Println("\n⚠️  Operation interrupted by user")
+			fmt.Printf("\n%s Operation interrupted by user\n", theme.Warning)
 			cancel()
 
 			// Notify that interruption occurred
@@ -73,6 +76,13 @@ func (h *Handler) handleSignals() {
 	signal.Stop(sigCh)
 }
 
+// IsActive returns true while a cancellable operation is in progress
+func (h *Handler) IsActive() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.active
+}
+
 // WasInterrupted checks if the last operation was interrupted
 func (h *Handler) WasInterrupted() bool {
 	// Non-blocking receive to check if there's a notification
@@ -87,5 +97,5 @@ func (h *Handler) WasInterrupted() bool {
 
 // IsInterruptError checks if an error is due to context cancellation
 func IsInterruptError(err error) bool {
-	return err == context.Canceled || err == context.DeadlineExceeded
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
 }
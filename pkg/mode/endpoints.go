@@ -0,0 +1,162 @@
+package mode
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/config"
+)
+
+// endpointRaceTimeout bounds how long endpoint detection may take overall
+const endpointRaceTimeout = 5 * time.Second
+
+// fallbackEndpoints are tried alongside the configured endpoint
+var fallbackEndpoints = []string{
+	config.DefaultAPIEndpoint,
+	"http://127.0.0.1:8080",
+	"http://[::1]:8080",
+}
+
+// candidateEndpoints returns the configured endpoint followed by the
+// fallback endpoints, without duplicates
+func (m *Manager) candidateEndpoints() []string {
+	seen := make(map[string]bool)
+	var endpoints []string
+
+	for _, endpoint := range append([]string{m.configManager.GetAPIEndpoint()}, fallbackEndpoints...) {
+		normalized, err := config.NormalizeAPIEndpoint(endpoint)
+		if err != nil || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		endpoints = append(endpoints, normalized)
+	}
+
+	return endpoints
+}
+
+// raceEndpoints health-checks all endpoints concurrently, each with a client
+// from newClient, and returns the first one that responds. The remaining
+// checks are cancelled.
+func raceEndpoints(ctx context.Context, endpoints []string, newClient func(endpoint string) *api.Client) (string, error) {
+	if len(endpoints) == 0 {
+		return "", fmt.Errorf("no API endpoints to check")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		endpoint string
+		err      error
+	}
+	// Buffered so losing checks never block after the winner is chosen
+	results := make(chan result, len(endpoints))
+
+	for _, endpoint := range endpoints {
+		go func(endpoint string) {
+			err := newClient(endpoint).HealthCheck(ctx)
+			results <- result{endpoint: endpoint, err: err}
+		}(endpoint)
+	}
+
+	var firstErr error
+	for range endpoints {
+		r := <-results
+		if r.err == nil {
+			return r.endpoint, nil
+		}
+		if firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w", r.endpoint, r.err)
+		}
+	}
+
+	return "", fmt.Errorf("no API endpoint responded: %w", firstErr)
+}
+
+// newProbeClient returns a client for checking endpoint, configured and
+// connected like the manager's API client
+func (m *Manager) newProbeClient(endpoint string) *api.Client {
+	return newAPIClient(m.configManager, m.httpClient, endpoint)
+}
+
+// EndpointProbe is the result of checking one candidate API endpoint
+type EndpointProbe struct {
+	Endpoint string
+	Latency  time.Duration // Round trip of the health check, if it answered
+	Err      error         // Why the endpoint did not answer
+}
+
+// ProbeEndpoints health-checks the configured endpoint and the usual local
+// addresses concurrently and returns every result: the endpoints that
+// answered first, fastest first, then the others in candidate order.
+func (m *Manager) ProbeEndpoints(ctx context.Context) []EndpointProbe {
+	ctx, cancel := context.WithTimeout(ctx, endpointRaceTimeout)
+	defer cancel()
+
+	endpoints := m.candidateEndpoints()
+	probes := make([]EndpointProbe, len(endpoints))
+
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			latency, err := m.newProbeClient(endpoint).Ping(ctx)
+			probes[i] = EndpointProbe{Endpoint: endpoint, Latency: latency, Err: err}
+		}()
+	}
+	wg.Wait()
+
+	sort.SliceStable(probes, func(i, j int) bool {
+		if (probes[i].Err == nil) != (probes[j].Err == nil) {
+			return probes[i].Err == nil
+		}
+		return probes[i].Err == nil && probes[i].Latency < probes[j].Latency
+	})
+	return probes
+}
+
+// detectAPIEndpoint keeps the configured endpoint whenever it answers.
+// Only if it does not are the fallback endpoints raced, and the API client
+// is pointed at the winner. The winner is saved after telling the user,
+// which heals a slightly wrong configuration.
+func (m *Manager) detectAPIEndpoint() error {
+	configured := apiEndpoint(m.configManager)
+	m.apiClient.SetBaseURL(configured)
+	err := m.verifyAPIMode()
+	if err == nil {
+		return nil
+	}
+
+	var fallbacks []string
+	for _, endpoint := range m.candidateEndpoints() {
+		if endpoint != configured {
+			fallbacks = append(fallbacks, endpoint)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), endpointRaceTimeout)
+	defer cancel()
+
+	endpoint, raceErr := raceEndpoints(ctx, fallbacks, m.newProbeClient)
+	if raceErr != nil {
+		return fmt.Errorf("%s: %w", configured, err)
+	}
+
+	log.Printf("Warning: API endpoint %s is not reachable, switching to %s and saving it as api_endpoint", configured, endpoint)
+	m.apiClient.SetBaseURL(endpoint)
+	if err := m.configManager.SetAPIEndpoint(endpoint); err != nil {
+		log.Printf("Warning: failed to set API endpoint: %v", err)
+	} else if err := m.configManager.Save(); err != nil {
+		log.Printf("Warning: failed to save API endpoint: %v", err)
+	}
+
+	// The racing clients are discarded, so fetch version info on ours
+	return m.verifyAPIMode()
+}
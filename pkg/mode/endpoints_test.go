@@ -0,0 +1,193 @@
+package mode
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/config"
+)
+
+// newBackend starts a fake backend that answers the health route after
+// delay, or fails every request if healthy is false
+func newBackend(t *testing.T, delay time.Duration, healthy bool) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		if !healthy || r.URL.Path != api.DefaultHealthPath {
+			http.Error(w, "unavailable", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestRaceEndpointsPicksFastestHealthy(t *testing.T) {
+	failing := newBackend(t, 0, false)
+	slow := newBackend(t, 300*time.Millisecond, true)
+	fast := newBackend(t, 20*time.Millisecond, true)
+
+	endpoint, err := raceEndpoints(context.Background(), []string{failing, slow, fast}, api.NewClient)
+	if err != nil {
+		t.Fatalf("raceEndpoints failed: %v", err)
+	}
+	if endpoint != fast {
+		t.Errorf("raceEndpoints = %s, want the fast backend %s", endpoint, fast)
+	}
+}
+
+func TestRaceEndpointsIgnoresFailing(t *testing.T) {
+	failing := newBackend(t, 0, false)
+	slow := newBackend(t, 100*time.Millisecond, true)
+
+	endpoint, err := raceEndpoints(context.Background(), []string{failing, slow}, api.NewClient)
+	if err != nil {
+		t.Fatalf("raceEndpoints failed: %v", err)
+	}
+	if endpoint != slow {
+		t.Errorf("raceEndpoints = %s, want the only healthy backend %s", endpoint, slow)
+	}
+}
+
+func TestRaceEndpointsAllFailing(t *testing.T) {
+	endpoints := []string{newBackend(t, 0, false), newBackend(t, 0, false)}
+	if endpoint, err := raceEndpoints(context.Background(), endpoints, api.NewClient); err == nil {
+		t.Errorf("raceEndpoints = %s, want an error when no backend is healthy", endpoint)
+	}
+}
+
+func TestProbeEndpointsOrdersByLatency(t *testing.T) {
+	failing := newBackend(t, 0, false)
+	slow := newBackend(t, 200*time.Millisecond, true)
+	fast := newBackend(t, 10*time.Millisecond, true)
+
+	saved := fallbackEndpoints
+	fallbackEndpoints = []string{slow, fast}
+	t.Cleanup(func() { fallbackEndpoints = saved })
+
+	configManager, err := config.NewConfigManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := configManager.SetAPIEndpoint(failing); err != nil {
+		t.Fatal(err)
+	}
+
+	probes := NewManager(configManager).ProbeEndpoints(context.Background())
+	var order []string
+	for _, probe := range probes {
+		order = append(order, probe.Endpoint)
+	}
+	want := []string{fast, slow, failing}
+	if len(order) != len(want) {
+		t.Fatalf("probed %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("probe order %v, want %v", order, want)
+		}
+	}
+	if probes[2].Err == nil {
+		t.Errorf("failing backend probed without an error")
+	}
+}
+
+func TestProbeEndpointsSendsConfiguredToken(t *testing.T) {
+	t.Setenv(api.AuthTokenEnvVar, "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	saved := fallbackEndpoints
+	fallbackEndpoints = nil
+	t.Cleanup(func() { fallbackEndpoints = saved })
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"api_endpoint": "` + server.URL + `", "api_token": "secret"}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	configManager, err := config.NewConfigManagerWithPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	probes := NewManager(configManager).ProbeEndpoints(context.Background())
+	if len(probes) != 1 || probes[0].Err != nil {
+		t.Errorf("probes = %+v, want the backend to accept the configured token", probes)
+	}
+}
+
+// newDetectManager returns a manager configured for endpoint, with the
+// given fallback endpoints and its config file in a temporary directory
+func newDetectManager(t *testing.T, endpoint string, fallbacks ...string) (*Manager, string) {
+	t.Helper()
+	saved := fallbackEndpoints
+	fallbackEndpoints = fallbacks
+	t.Cleanup(func() { fallbackEndpoints = saved })
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	configManager, err := config.NewConfigManagerWithPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := configManager.SetAPIEndpoint(endpoint); err != nil {
+		t.Fatal(err)
+	}
+	return NewManager(configManager), path
+}
+
+func TestDetectAPIEndpointKeepsHealthyConfigured(t *testing.T) {
+	configured := newBackend(t, 200*time.Millisecond, true)
+	fast := newBackend(t, 0, true)
+	manager, path := newDetectManager(t, configured, fast)
+
+	if err := manager.detectAPIEndpoint(); err != nil {
+		t.Fatalf("detectAPIEndpoint failed: %v", err)
+	}
+	if got := manager.apiClient.BaseURL(); got != configured {
+		t.Errorf("API client at %s, want the configured %s although %s is faster", got, configured, fast)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("config file saved (%v), want it untouched", err)
+	}
+}
+
+func TestDetectAPIEndpointSavesFallback(t *testing.T) {
+	configured := newBackend(t, 0, false)
+	fallback := newBackend(t, 0, true)
+	manager, path := newDetectManager(t, configured, fallback)
+
+	if err := manager.detectAPIEndpoint(); err != nil {
+		t.Fatalf("detectAPIEndpoint failed: %v", err)
+	}
+	if got := manager.apiClient.BaseURL(); got != fallback {
+		t.Errorf("API client at %s, want the fallback %s", got, fallback)
+	}
+
+	saved, err := config.NewConfigManagerWithPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := saved.GetAPIEndpoint(); got != fallback {
+		t.Errorf("saved api_endpoint = %s, want %s", got, fallback)
+	}
+}
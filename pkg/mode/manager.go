@@ -3,36 +3,127 @@ package mode
 import (
 	"context"
 	"fmt"
+	"log"
+	"net/http"
+	"os"
 	"time"
 
 	"github.com/ddalab/launcher/pkg/api"
 	"github.com/ddalab/launcher/pkg/bootstrap"
 	"github.com/ddalab/launcher/pkg/config"
+	"github.com/ddalab/launcher/pkg/httpx"
 )
 
 // Manager handles operation mode detection and switching
 type Manager struct {
 	configManager *config.ConfigManager
+	httpClient    *http.Client // Shared by the API client and endpoint probes
 	apiClient     *api.Client
 	currentMode   config.OperationMode
 	bootstrapper  *bootstrap.Bootstrap
+	progress      func(message string)
+	forcedAPI     bool // API mode without verification, see ForceAPIMode
 }
 
+// Backend readiness polling after a bootstrap
+const (
+	healthPollInterval     = time.Second
+	healthProgressInterval = 5 * time.Second
+)
+
 // NewManager creates a new mode manager
 func NewManager(configManager *config.ConfigManager) *Manager {
-	apiClient := api.NewClient(configManager.GetAPIEndpoint())
+	if token := os.Getenv(api.AuthTokenEnvVar); token != "" {
+		configManager.Override("api_token", token, api.AuthTokenEnvVar+" environment variable")
+	}
+
+	httpClient := newHTTPClient(configManager)
 	bootstrapper := bootstrap.NewBootstrap()
 
 	return &Manager{
 		configManager: configManager,
-		apiClient:     apiClient,
+		httpClient:    httpClient,
+		apiClient:     newAPIClient(configManager, httpClient, apiEndpoint(configManager)),
 		currentMode:   config.ModeLocal, // Start with local mode as fallback
 		bootstrapper:  bootstrapper,
 	}
 }
 
+// NewAPIClient returns a client for the API at endpoint with the configured
+// connect timeout, CA certificate, token and health routes, e.g. to check an
+// endpoint before a manager exists
+func NewAPIClient(configManager *config.ConfigManager, endpoint string) *api.Client {
+	return newAPIClient(configManager, newHTTPClient(configManager), endpoint)
+}
+
+// newHTTPClient returns the HTTP client for API requests, trusting the
+// configured CA certificate
+func newHTTPClient(configManager *config.ConfigManager) *http.Client {
+	httpClient := httpx.NewClient()
+	httpClient.Timeout = api.DefaultTimeout
+	if caCert := configManager.GetAPICACert(); caCert != "" {
+		if err := httpx.TrustCACert(httpClient, caCert); err != nil {
+			log.Printf("Warning: ignoring api_ca_cert: %v", err)
+		}
+	}
+	return httpClient
+}
+
+// newAPIClient returns a client for the API at endpoint that sends its
+// requests through httpClient, with the configured health routes, token
+// and probe connect timeout. A token from the environment wins over the
+// configured one.
+func newAPIClient(configManager *config.ConfigManager, httpClient *http.Client, endpoint string) *api.Client {
+	client := api.NewClientWithHTTPClient(endpoint, httpClient)
+	// A short connect timeout lets status checks of a stopped backend fail
+	// fast; slow but reachable backends are bounded by the request context
+	client.SetProbeConnectTimeout(configManager.GetConnectTimeout())
+	if token := configManager.GetAPIToken(); token != "" && os.Getenv(api.AuthTokenEnvVar) == "" {
+		client.SetAuthToken(token)
+	}
+	client.SetHealthPaths(configManager.GetAPIHealthPaths())
+	return client
+}
+
+// ApplyConfig re-applies the endpoint, token, health routes and probe
+// connect timeout to the API client after the settings were reloaded. The
+// CA certificate only takes effect on the next launch. Call Initialize to
+// detect the mode again.
+func (m *Manager) ApplyConfig() {
+	m.apiClient.SetBaseURL(apiEndpoint(m.configManager))
+	m.apiClient.SetProbeConnectTimeout(m.configManager.GetConnectTimeout())
+	if os.Getenv(api.AuthTokenEnvVar) == "" {
+		m.apiClient.SetAuthToken(m.configManager.GetAPIToken())
+	}
+	m.apiClient.SetHealthPaths(api.DefaultHealthPath, api.DefaultVersionPath)
+	m.apiClient.SetHealthPaths(m.configManager.GetAPIHealthPaths())
+}
+
+// SetProgressReporter sets a callback that receives progress messages while
+// waiting for a bootstrapped backend
+func (m *Manager) SetProgressReporter(progress func(message string)) {
+	m.progress = progress
+}
+
+// apiEndpoint returns the configured API endpoint or the default
+func apiEndpoint(configManager *config.ConfigManager) string {
+	if endpoint := configManager.GetAPIEndpoint(); endpoint != "" {
+		return endpoint
+	}
+	return config.DefaultAPIEndpoint
+}
+
 // Initialize determines and sets the appropriate operation mode
 func (m *Manager) Initialize() error {
+	// Pick up endpoint overrides applied after the manager was created
+	m.apiClient.SetBaseURL(apiEndpoint(m.configManager))
+
+	if m.forcedAPI {
+		m.currentMode = config.ModeAPI
+		log.Printf("Warning: API mode forced - %s was NOT verified, operations fail if the backend is not reachable", m.apiClient.BaseURL())
+		return nil
+	}
+
 	// First, check Docker extension availability
 	if err := m.bootstrapper.CheckDockerExtension(); err != nil {
 		// Log the bootstrap check result but don't fail initialization
@@ -51,9 +142,11 @@ func (m *Manager) Initialize() error {
 		if err := m.verifyAPIMode(); err != nil {
 			// If API mode fails but bootstrap is available, try bootstrap
 			if m.bootstrapper.CanBootstrap() {
-				if bootstrapErr := m.tryBootstrapAPI(); bootstrapErr == nil {
-					m.currentMode = config.ModeAPI
-					return nil
+				if bootstrapErr := m.tryBootstrapAPI(context.Background()); bootstrapErr == nil {
+					if waitErr := m.waitForAPI(context.Background()); waitErr == nil {
+						m.currentMode = config.ModeAPI
+						return nil
+					}
 				}
 			}
 			return fmt.Errorf("API mode configured but not available: %w", err)
@@ -72,16 +165,16 @@ func (m *Manager) Initialize() error {
 
 // detectBestMode automatically detects the best operation mode
 func (m *Manager) detectBestMode() config.OperationMode {
-	// Try API mode first (preferred if available)
-	if err := m.verifyAPIMode(); err == nil {
+	// Try API mode first (preferred if available) on every candidate endpoint
+	if err := m.detectAPIEndpoint(); err == nil {
 		return config.ModeAPI
 	}
 
 	// If API is not available but we can bootstrap, try that
 	if m.bootstrapper.CanBootstrap() {
-		if err := m.tryBootstrapAPI(); err == nil {
-			// Re-verify API mode after bootstrap attempt
-			if verifyErr := m.verifyAPIMode(); verifyErr == nil {
+		if err := m.tryBootstrapAPI(context.Background()); err == nil {
+			// Wait for the bootstrapped backend to come up
+			if waitErr := m.waitForAPI(context.Background()); waitErr == nil {
 				return config.ModeAPI
 			}
 		}
@@ -92,8 +185,8 @@ func (m *Manager) detectBestMode() config.OperationMode {
 }
 
 // tryBootstrapAPI attempts to bootstrap the API backend
-func (m *Manager) tryBootstrapAPI() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+func (m *Manager) tryBootstrapAPI(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	// First try to start the extension backend if available
@@ -109,7 +202,7 @@ func (m *Manager) tryBootstrapAPI() error {
 		return fmt.Errorf("DDALAB path not configured")
 	}
 
-	return m.bootstrapper.StartMinimalServices(ctx, ddalabPath)
+	return m.bootstrapper.StartMinimalServices(ctx, m.configManager.ComposeProject())
 }
 
 // verifyAPIMode checks if the API mode is available
@@ -120,6 +213,53 @@ func (m *Manager) verifyAPIMode() error {
 	return m.apiClient.HealthCheck(ctx)
 }
 
+// waitForAPI polls the API health endpoint until it responds, the
+// configured bootstrap timeout expires or ctx is done
+func (m *Manager) waitForAPI(ctx context.Context) error {
+	timeout := m.configManager.GetBootstrapTimeout()
+	start := time.Now()
+	lastReport := start
+
+	for {
+		err := m.verifyAPIMode()
+		if err == nil {
+			return nil
+		}
+
+		elapsed := time.Since(start)
+		if elapsed >= timeout {
+			// Wrap DeadlineExceeded so callers can tell a timeout from a failure
+			return fmt.Errorf("backend did not become healthy within %s: %w (%w)", timeout, err, context.DeadlineExceeded)
+		}
+
+		if m.progress != nil && time.Since(lastReport) >= healthProgressInterval {
+			m.progress(fmt.Sprintf("Waiting for backend (%ds elapsed)", int(elapsed.Seconds())))
+			lastReport = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(healthPollInterval):
+		}
+	}
+}
+
+// ForceAPIMode uses API mode for this session without checking the health
+// endpoint first, for backends whose health route is down while the other
+// routes work. Neither detection nor the bootstrap fallback runs, and the
+// configured mode is not changed. Initialize logs that verification was
+// skipped.
+func (m *Manager) ForceAPIMode() {
+	m.forcedAPI = true
+	m.currentMode = config.ModeAPI
+}
+
+// IsAPIModeForced reports whether API mode was forced without verification
+func (m *Manager) IsAPIModeForced() bool {
+	return m.forcedAPI
+}
+
 // GetCurrentMode returns the current operation mode
 func (m *Manager) GetCurrentMode() config.OperationMode {
 	return m.currentMode
@@ -136,6 +276,12 @@ func (m *Manager) IsLocalMode() bool {
 	return false
 }
 
+// APIClient returns the API client regardless of the current mode. It
+// follows endpoint changes made during mode detection.
+func (m *Manager) APIClient() *api.Client {
+	return m.apiClient
+}
+
 // GetAPIClient returns the API client (only valid in API mode)
 func (m *Manager) GetAPIClient() *api.Client {
 	if !m.IsAPIMode() {
@@ -177,10 +323,16 @@ func (m *Manager) GetModeStatus() ModeStatus {
 		ExtensionAvailable: m.bootstrapper.IsExtensionAvailable(),
 	}
 
+	if issue := m.bootstrapper.Issue(); issue != nil {
+		status.DockerIssue = issue.Error()
+		status.Remediation = m.bootstrapper.Remediation(issue)
+	}
+
 	// Check API availability
 	if err := m.verifyAPIMode(); err == nil {
 		status.APIAvailable = true
 		status.APIEndpoint = m.configManager.GetAPIEndpoint()
+		status.ServerVersion = m.apiClient.ServerVersion()
 	} else {
 		status.APIAvailable = false
 		status.APIError = err.Error()
@@ -196,15 +348,21 @@ type ModeStatus struct {
 	APIAvailable       bool                 `json:"api_available"`
 	APIEndpoint        string               `json:"api_endpoint,omitempty"`
 	APIError           string               `json:"api_error,omitempty"`
+	ServerVersion      string               `json:"server_version,omitempty"`
 	BootstrapMode      string               `json:"bootstrap_mode"`
 	CanBootstrap       bool                 `json:"can_bootstrap"`
 	ExtensionAvailable bool                 `json:"extension_available"`
+	DockerIssue        string               `json:"docker_issue,omitempty"`
+	Remediation        string               `json:"remediation,omitempty"`
 }
 
 // GetModeDescription returns a human-readable description of the mode
 func (m *Manager) GetModeDescription() string {
 	switch m.currentMode {
 	case config.ModeAPI:
+		if m.forcedAPI {
+			return "Using Docker Extension API for DDALAB management (forced, not verified)"
+		}
 		return "Using Docker Extension API for DDALAB management"
 	case config.ModeLocal:
 		return "Deprecated local mode - switching to API with bootstrap fallback"
@@ -229,22 +387,21 @@ func (m *Manager) GetBootstrapper() *bootstrap.Bootstrap {
 	return m.bootstrapper
 }
 
-// PerformBootstrap attempts to bootstrap DDALAB services and switch to API mode
-func (m *Manager) PerformBootstrap() error {
+// PerformBootstrap attempts to bootstrap DDALAB services and switch to API
+// mode, giving up when ctx is done. It does not take the operation lock;
+// run it through controller.Bootstrap, which does.
+func (m *Manager) PerformBootstrap(ctx context.Context) error {
 	if !m.bootstrapper.CanBootstrap() {
 		return fmt.Errorf("bootstrap not available - Docker is not running")
 	}
 
 	// Try to bootstrap the API
-	if err := m.tryBootstrapAPI(); err != nil {
+	if err := m.tryBootstrapAPI(ctx); err != nil {
 		return fmt.Errorf("bootstrap failed: %w", err)
 	}
 
 	// Wait for services to be ready
-	time.Sleep(5 * time.Second)
-
-	// Verify API is now available
-	if err := m.verifyAPIMode(); err != nil {
+	if err := m.waitForAPI(ctx); err != nil {
 		return fmt.Errorf("bootstrap appeared to succeed but API is not available: %w", err)
 	}
 
@@ -0,0 +1,56 @@
+// Package oplock makes sure only one DDALAB lifecycle operation runs at a
+// time in the launcher process, whichever front-end started it.
+package oplock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrBusy is returned when another lifecycle operation is still running
+var ErrBusy = errors.New("another operation is in progress")
+
+var (
+	mu      sync.Mutex
+	running string // Operation holding the lock, empty if none
+)
+
+// heldKey marks a context whose operation holds the lock
+type heldKey struct{}
+
+// Acquire takes the lock for operation and returns a context that marks it
+// as held, together with the function releasing it. Steps of an operation
+// run with that context acquire again without blocking, e.g. the controller
+// call inside a runner operation. While another operation holds the lock,
+// Acquire fails with ErrBusy rather than waiting.
+func Acquire(ctx context.Context, operation string) (context.Context, func(), error) {
+	if ctx.Value(heldKey{}) != nil {
+		return ctx, func() {}, nil
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if running != "" {
+		return ctx, nil, fmt.Errorf("%w (%s) - wait for it to finish", ErrBusy, running)
+	}
+	running = operation
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			mu.Lock()
+			running = ""
+			mu.Unlock()
+		})
+	}
+	return context.WithValue(ctx, heldKey{}, operation), release, nil
+}
+
+// Running returns the operation holding the lock, or "" if none does
+func Running() string {
+	mu.Lock()
+	defer mu.Unlock()
+	return running
+}
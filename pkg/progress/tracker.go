@@ -0,0 +1,165 @@
+// Package progress tracks the progress of long-running operations and
+// estimates transfer rates and remaining time.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tracker records how much of an operation has completed. The total may be
+// unknown (zero), in which case only elapsed time and rate are available.
+type Tracker struct {
+	mu      sync.Mutex
+	start   time.Time
+	total   int64
+	current int64
+}
+
+// NewTracker creates a tracker for an operation of total bytes (0 if unknown)
+func NewTracker(total int64) *Tracker {
+	return &Tracker{start: time.Now(), total: total}
+}
+
+// SetTotal sets the expected total, e.g. once a download's size is known
+func (t *Tracker) SetTotal(total int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.total = total
+}
+
+// Add records n more completed bytes
+func (t *Tracker) Add(n int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.current += n
+}
+
+// Current returns the number of completed bytes
+func (t *Tracker) Current() int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.current
+}
+
+// Elapsed returns the time since the tracker was created
+func (t *Tracker) Elapsed() time.Duration {
+	return time.Since(t.start)
+}
+
+// Rate returns the average throughput in bytes per second
+func (t *Tracker) Rate() float64 {
+	t.mu.Lock()
+	current := t.current
+	t.mu.Unlock()
+
+	seconds := t.Elapsed().Seconds()
+	if seconds <= 0 {
+		return 0
+	}
+	return float64(current) / seconds
+}
+
+// Percent returns the completed percentage, if the total is known
+func (t *Tracker) Percent() (float64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.total <= 0 {
+		return 0, false
+	}
+	return float64(t.current) / float64(t.total) * 100, true
+}
+
+// ETA estimates the remaining time, if the total is known and progress
+// has been made
+func (t *Tracker) ETA() (time.Duration, bool) {
+	t.mu.Lock()
+	total, current := t.total, t.current
+	t.mu.Unlock()
+
+	rate := t.Rate()
+	if total <= 0 || rate <= 0 {
+		return 0, false
+	}
+	if current >= total {
+		return 0, true
+	}
+	return time.Duration(float64(total-current) / rate * float64(time.Second)), true
+}
+
+// String summarizes the progress, e.g.
+// "3.2 MB / 10.0 MB (32%) · 1.5 MB/s · ETA 5s". Without byte progress it
+// only reports the elapsed time.
+func (t *Tracker) String() string {
+	t.mu.Lock()
+	total, current := t.total, t.current
+	t.mu.Unlock()
+
+	if current == 0 {
+		return "elapsed " + FormatDuration(t.Elapsed())
+	}
+
+	var parts []string
+	if percent, ok := t.Percent(); ok {
+		parts = append(parts, fmt.Sprintf("%s / %s (%.0f%%)", FormatBytes(current), FormatBytes(total), percent))
+	} else {
+		parts = append(parts, FormatBytes(current))
+	}
+
+	parts = append(parts, FormatBytes(int64(t.Rate()))+"/s")
+
+	if eta, ok := t.ETA(); ok {
+		parts = append(parts, "ETA "+FormatDuration(eta))
+	} else {
+		parts = append(parts, "elapsed "+FormatDuration(t.Elapsed()))
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// Reader wraps r so that bytes read from it are recorded on the tracker
+func (t *Tracker) Reader(r io.Reader) io.Reader {
+	return &trackingReader{reader: r, tracker: t}
+}
+
+type trackingReader struct {
+	reader  io.Reader
+	tracker *Tracker
+}
+
+func (r *trackingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.tracker.Add(int64(n))
+	return n, err
+}
+
+// FormatBytes formats a byte count in human readable form
+func FormatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// FormatDuration formats a duration as "4s", "2m05s" or "1h02m"
+func FormatDuration(d time.Duration) string {
+	seconds := int(d.Round(time.Second).Seconds())
+	switch {
+	case seconds < 60:
+		return fmt.Sprintf("%ds", seconds)
+	case seconds < 3600:
+		return fmt.Sprintf("%dm%02ds", seconds/60, seconds%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", seconds/3600, (seconds%3600)/60)
+	}
+}
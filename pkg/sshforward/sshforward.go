@@ -0,0 +1,228 @@
+// Package sshforward forwards the ports of a remote DDALAB to localhost over
+// SSH, so a host that is only reachable by SSH can be managed as if it ran
+// locally.
+package sshforward
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// readyTimeout bounds how long Start waits for the forwarded ports
+const readyTimeout = 15 * time.Second
+
+// reconnectDelay is the pause before Supervise restarts a dropped forward
+const reconnectDelay = 5 * time.Second
+
+// ErrSSHNotFound is returned when no ssh client is installed
+var ErrSSHNotFound = errors.New("ssh client not found")
+
+// Forward is an "ssh -L" process forwarding ports of host to the same
+// ports on 127.0.0.1
+type Forward struct {
+	host  string
+	ports []int
+
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	done   chan struct{} // Closed when the ssh process exits
+	err    error         // Why the ssh process exited
+	closed bool          // Stop was called, so a drop is expected
+	stderr bytes.Buffer
+}
+
+// New creates a forward of ports on host, e.g. "user@server". It does not
+// connect until Start is called.
+func New(host string, ports []int) *Forward {
+	return &Forward{host: host, ports: ports}
+}
+
+// Host returns the SSH target
+func (f *Forward) Host() string {
+	return f.host
+}
+
+// Start runs ssh and waits until every forwarded port accepts connections.
+// ssh runs in batch mode, so authentication must not need a prompt, e.g. a
+// key loaded into the agent. The forward ends when ctx is cancelled or Stop
+// is called.
+func (f *Forward) Start(ctx context.Context) error {
+	path, err := exec.LookPath("ssh")
+	if err != nil {
+		return ErrSSHNotFound
+	}
+
+	// A port that is already open would look like a ready forward
+	for _, port := range f.ports {
+		if portOpen(port) {
+			return fmt.Errorf("cannot forward port %d from %s: it is already in use on this machine", port, f.host)
+		}
+	}
+
+	args := []string{
+		"-N",
+		"-o", "BatchMode=yes",
+		"-o", "ExitOnForwardFailure=yes",
+		"-o", "ServerAliveInterval=15",
+		"-o", "ServerAliveCountMax=3",
+	}
+	for _, port := range f.ports {
+		args = append(args, "-L", fmt.Sprintf("127.0.0.1:%d:localhost:%d", port, port))
+	}
+	args = append(args, f.host)
+
+	f.mu.Lock()
+	f.stderr.Reset()
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stderr = &f.stderr
+	if err := cmd.Start(); err != nil {
+		f.mu.Unlock()
+		return fmt.Errorf("failed to start ssh: %w", err)
+	}
+	done := make(chan struct{})
+	f.cmd, f.done, f.err, f.closed = cmd, done, nil, false
+	f.mu.Unlock()
+
+	go func() {
+		err := cmd.Wait()
+		f.mu.Lock()
+		f.err = f.exitError(err)
+		f.mu.Unlock()
+		close(done)
+	}()
+
+	if err := f.waitReady(ctx, done); err != nil {
+		f.Stop()
+		return err
+	}
+	return nil
+}
+
+// waitReady polls the local ports until all accept connections, ssh exits
+// or readyTimeout passes
+func (f *Forward) waitReady(ctx context.Context, done <-chan struct{}) error {
+	deadline := time.Now().Add(readyTimeout)
+	for {
+		if f.portsOpen() {
+			return nil
+		}
+
+		select {
+		case <-done:
+			return fmt.Errorf("ssh port forward to %s failed: %w", f.host, f.Err())
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("ssh port forward to %s not ready after %s", f.host, readyTimeout)
+		}
+	}
+}
+
+// portsOpen reports whether every forwarded port accepts connections
+func (f *Forward) portsOpen() bool {
+	for _, port := range f.ports {
+		if !portOpen(port) {
+			return false
+		}
+	}
+	return true
+}
+
+// portOpen reports whether port accepts connections on 127.0.0.1
+func portOpen(port int) bool {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", port), time.Second)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// exitError describes why ssh exited, using its last error line
+func (f *Forward) exitError(err error) error {
+	message := strings.TrimSpace(f.stderr.String())
+	if idx := strings.LastIndex(message, "\n"); idx >= 0 {
+		message = message[idx+1:]
+	}
+	if message == "" {
+		if err == nil {
+			return errors.New("ssh exited")
+		}
+		return err
+	}
+	return errors.New(message)
+}
+
+// Done returns a channel closed when the forward drops or is stopped
+func (f *Forward) Done() <-chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.done
+}
+
+// Err returns why the forward ended, or nil while it is running
+func (f *Forward) Err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+// isClosed reports whether Stop was called since the last Start
+func (f *Forward) isClosed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.closed
+}
+
+// Stop ends the forward and waits for ssh to exit
+func (f *Forward) Stop() {
+	f.mu.Lock()
+	cmd, done := f.cmd, f.done
+	f.closed = true
+	f.mu.Unlock()
+	if cmd == nil {
+		return
+	}
+
+	_ = cmd.Process.Kill()
+	<-done
+}
+
+// Supervise restarts the forward whenever it drops, until ctx is done.
+// notify is called with the reason when the forward drops and with nil
+// once it is up again. The forward must have been started.
+func (f *Forward) Supervise(ctx context.Context, notify func(err error)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.Done():
+		}
+		if ctx.Err() != nil || f.isClosed() {
+			return // Ended on purpose
+		}
+		notify(f.Err())
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(reconnectDelay):
+			}
+			if err := f.Start(ctx); err == nil {
+				notify(nil)
+				break
+			}
+		}
+	}
+}
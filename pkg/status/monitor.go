@@ -2,12 +2,15 @@ package status
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/theme"
 )
 
 // Status represents the current DDALAB status
@@ -40,24 +43,50 @@ func (s Status) String() string {
 	}
 }
 
-// GetColoredDot returns a colored dot for the status
+// GetColoredDot returns a colored dot for the status, or an ASCII marker
+// with the plain theme
 func (s Status) GetColoredDot() string {
+	return s.Icon().String()
+}
+
+// Icon returns the theme icon for the status
+func (s Status) Icon() theme.Icon {
 	switch s {
 	case StatusUp:
-		return "🟢" // Green dot
+		return theme.StatusUp
 	case StatusDown:
-		return "🔴" // Red dot
-	case StatusStarting:
-		return "🟡" // Yellow dot
-	case StatusStopping:
-		return "🟡" // Yellow dot
+		return theme.StatusDown
+	case StatusStarting, StatusStopping:
+		return theme.StatusTransition
 	case StatusError:
-		return "🔴" // Red dot
+		return theme.StatusError
 	default:
-		return "⚪" // White dot
+		return theme.StatusUnknown
 	}
 }
 
+// After a start the backend often reloads and briefly answers with 503 or
+// drops connections. Within this window such errors mean "starting".
+const (
+	startupGracePeriod = 30 * time.Second
+	startupRetries     = 3
+	startupRetryDelay  = time.Second
+)
+
+// StatusEvent describes a status transition
+type StatusEvent struct {
+	Previous Status
+	Current  Status
+	Time     time.Time
+}
+
+// defaultTimeout bounds a status check unless SetTimeout is used
+const defaultTimeout = 5 * time.Second
+
+// subscriberBuffer is how many events a subscriber may fall behind before
+// further events are dropped for it
+const subscriberBuffer = 16
+
 // Monitor continuously monitors DDALAB status via API
 type Monitor struct {
 	apiClient     *api.Client
@@ -65,8 +94,15 @@ type Monitor struct {
 	lastCheck     time.Time
 	mutex         sync.RWMutex
 	refreshRate   time.Duration
-	stopChan      chan bool
+	timeout       time.Duration // Overall timeout of a status check
+	stopChan      chan struct{}
 	running       bool
+	lastStart     time.Time           // When DDALAB was last started by the launcher
+	lastDetails   *api.Status         // Service details from the last successful check
+	validator     api.StatusValidator // Identifies lastDetails for conditional requests
+
+	subscribers      map[int]chan StatusEvent
+	nextSubscriberID int
 }
 
 // NewMonitor creates a new status monitor that uses the API client
@@ -75,7 +111,7 @@ func NewMonitor(apiClient *api.Client) *Monitor {
 		apiClient:     apiClient,
 		currentStatus: StatusUnknown,
 		refreshRate:   1 * time.Second, // Check every 1 second for real-time updates
-		stopChan:      make(chan bool),
+		timeout:       defaultTimeout,
 	}
 }
 
@@ -87,9 +123,11 @@ func (m *Monitor) Start() {
 		return
 	}
 	m.running = true
+	m.stopChan = make(chan struct{})
+	stopChan := m.stopChan
 	m.mutex.Unlock()
 
-	go m.monitorLoop()
+	go m.monitorLoop(stopChan)
 }
 
 // Stop stops the background monitoring
@@ -100,12 +138,10 @@ func (m *Monitor) Stop() {
 		return
 	}
 	m.running = false
+	// Closing (rather than sending) guarantees the loop sees the stop
+	// even if it is in the middle of a status check
+	close(m.stopChan)
 	m.mutex.Unlock()
-
-	select {
-	case m.stopChan <- true:
-	default:
-	}
 }
 
 // IsRunning returns true if the monitor is currently running
@@ -129,18 +165,108 @@ func (m *Monitor) GetLastCheck() time.Time {
 	return m.lastCheck
 }
 
+// GetDetails returns the service details from the last check, or nil if
+// the last check did not reach the API
+func (m *Monitor) GetDetails() *api.Status {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.lastDetails
+}
+
+// GetRefreshRate returns how often the status is checked
+func (m *Monitor) GetRefreshRate() time.Duration {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.refreshRate
+}
+
+// MarkStarted records that DDALAB was just started so transient errors
+// during the startup grace period are reported as starting, not as errors
+func (m *Monitor) MarkStarted() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.lastStart = time.Now()
+}
+
+// inStartupWindow returns true if DDALAB was started recently
+func (m *Monitor) inStartupWindow() bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return !m.lastStart.IsZero() && time.Since(m.lastStart) < startupGracePeriod
+}
+
+// Reset forgets the last status and service details, e.g. after switching
+// to another installation, so the next check fetches the full status
+// instead of reusing details of the previous one
+func (m *Monitor) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.currentStatus = StatusUnknown
+	m.lastDetails = nil
+	m.validator = api.StatusValidator{}
+	m.lastCheck = time.Time{}
+	m.lastStart = time.Time{}
+}
+
 // CheckNow forces an immediate status check
 func (m *Monitor) CheckNow() Status {
-	status := m.checkStatus()
+	status, details := m.checkStatus()
 
 	m.mutex.Lock()
+	previous := m.currentStatus
 	m.currentStatus = status
+	m.lastDetails = details
 	m.lastCheck = time.Now()
+	if previous != status {
+		m.publish(StatusEvent{Previous: previous, Current: status, Time: m.lastCheck})
+	}
 	m.mutex.Unlock()
 
 	return status
 }
 
+// Subscribe returns a channel that receives an event for every status
+// transition, and a function that unsubscribes and closes the channel.
+// Events are dropped for subscribers that fall too far behind, so a slow
+// subscriber never blocks monitoring.
+func (m *Monitor) Subscribe() (<-chan StatusEvent, func()) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.subscribers == nil {
+		m.subscribers = make(map[int]chan StatusEvent)
+	}
+
+	id := m.nextSubscriberID
+	m.nextSubscriberID++
+	ch := make(chan StatusEvent, subscriberBuffer)
+	m.subscribers[id] = ch
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			m.mutex.Lock()
+			defer m.mutex.Unlock()
+			delete(m.subscribers, id)
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// publish delivers an event to all subscribers without blocking. The
+// caller must hold the mutex.
+func (m *Monitor) publish(event StatusEvent) {
+	for _, ch := range m.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Subscriber is not keeping up; drop the event for it
+		}
+	}
+}
+
 // FormatStatus returns a formatted status string for display
 func (m *Monitor) FormatStatus() string {
 	status := m.GetStatus()
@@ -162,7 +288,7 @@ func (m *Monitor) FormatStatus() string {
 }
 
 // monitorLoop runs the background monitoring
-func (m *Monitor) monitorLoop() {
+func (m *Monitor) monitorLoop(stopChan <-chan struct{}) {
 	ticker := time.NewTicker(m.refreshRate)
 	defer ticker.Stop()
 
@@ -173,32 +299,95 @@ func (m *Monitor) monitorLoop() {
 		select {
 		case <-ticker.C:
 			m.CheckNow()
-		case <-m.stopChan:
+		case <-stopChan:
 			return
 		}
 	}
 }
 
-// checkStatus performs the actual status check using the API
-func (m *Monitor) checkStatus() Status {
-	// Use a timeout context for status checks
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// checkStatus performs the actual status check using the API. The API
+// status is returned alongside when the check succeeded.
+func (m *Monitor) checkStatus() (Status, *api.Status) {
+	status, unchanged, err := m.fetchStatus()
+	if err != nil && api.IsTransientError(err) && m.inStartupWindow() {
+		// The backend is likely reloading after a start; give it a moment,
+		// unless the monitor is stopped meanwhile
+		m.mutex.RLock()
+		stopChan := m.stopChan
+		m.mutex.RUnlock()
+
+	retry:
+		for attempt := 0; attempt < startupRetries && err != nil; attempt++ {
+			select {
+			case <-time.After(startupRetryDelay):
+			case <-stopChan:
+				break retry
+			}
+			status, unchanged, err = m.fetchStatus()
+		}
+		if err != nil && api.IsTransientError(err) {
+			return StatusStarting, nil
+		}
+	}
+
+	if errors.Is(err, api.ErrCircuitOpen) {
+		// The client backs off after repeated failures; report the backend
+		// as unreachable until the breaker lets a probe through
+		if m.inStartupWindow() {
+			return StatusStarting, nil
+		}
+		return StatusUnknown, nil
+	}
 
-	// Try to get status from the API
-	status, err := m.apiClient.GetStatus(ctx)
 	if err != nil {
 		// Check if it's a connection error (backend not available)
 		if strings.Contains(err.Error(), "connection refused") ||
 			strings.Contains(err.Error(), "no such host") ||
-			strings.Contains(err.Error(), "connection timeout") {
-			return StatusUnknown // Backend not available
+			strings.Contains(err.Error(), "connection timeout") ||
+			isDialError(err) {
+			return StatusUnknown, nil // Backend not available
 		}
-		return StatusError
+		return StatusError, nil
+	}
+
+	if unchanged {
+		// Nothing to parse or analyze, the last check still applies
+		m.mutex.RLock()
+		defer m.mutex.RUnlock()
+		return m.currentStatus, m.lastDetails
 	}
 
 	// Convert API status to local status
-	return m.convertAPIStatus(status)
+	return m.convertAPIStatus(status), status
+}
+
+// fetchStatus requests the current status from the API. After a
+// successful check the request is conditional: if the backend supports
+// ETag or Last-Modified and nothing changed, unchanged is true and the
+// last details still apply.
+func (m *Monitor) fetchStatus() (status *api.Status, unchanged bool, err error) {
+	m.mutex.RLock()
+	timeout := m.timeout
+	since := m.validator
+	if m.lastDetails == nil {
+		since = api.StatusValidator{} // Nothing to fall back on
+	}
+	m.mutex.RUnlock()
+
+	// The connect timeout of the client's transport makes a stopped backend
+	// fail fast; this bounds the whole request for a slow one
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	status, validator, err := m.apiClient.GetStatusIfChanged(ctx, since)
+	if errors.Is(err, api.ErrNotModified) {
+		return nil, true, nil
+	}
+
+	m.mutex.Lock()
+	m.validator = validator
+	m.mutex.Unlock()
+	return status, false, err
 }
 
 // convertAPIStatus converts API status response to local Status enum
@@ -294,6 +483,23 @@ func isErrorServiceStatus(status string) bool {
 	return false
 }
 
+// SetTimeout changes how long a single status check may take
+func (m *Monitor) SetTimeout(timeout time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if timeout > 0 {
+		m.timeout = timeout
+	}
+}
+
+// isDialError reports whether err happened while connecting, e.g. because
+// the connect timeout expired
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
 // SetRefreshRate changes how often the status is checked
 func (m *Monitor) SetRefreshRate(rate time.Duration) {
 	m.mutex.Lock()
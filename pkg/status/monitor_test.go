@@ -0,0 +1,148 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/api"
+)
+
+// fakeStatusBackend serves a status with the given number of services. With
+// conditional set it sends an ETag and answers a matching If-None-Match
+// with 304, like a backend supporting conditional requests.
+type fakeStatusBackend struct {
+	body        []byte
+	conditional bool
+	notModified atomic.Int32 // 304 responses sent
+}
+
+const fakeETag = `"status-1"`
+
+func newFakeStatusBackend(services int, conditional bool) *fakeStatusBackend {
+	status := api.Status{Running: true, State: "running"}
+	for i := 0; i < services; i++ {
+		status.Services = append(status.Services, api.Service{
+			Name:   fmt.Sprintf("service-%d", i),
+			Status: "running",
+			Health: "healthy",
+			Uptime: "3 hours",
+		})
+	}
+	body, _ := json.Marshal(map[string]any{"success": true, "data": status})
+	return &fakeStatusBackend{body: body, conditional: conditional}
+}
+
+func (b *fakeStatusBackend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if b.conditional {
+		w.Header().Set("ETag", fakeETag)
+		if r.Header.Get("If-None-Match") == fakeETag {
+			b.notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b.body)
+}
+
+func newTestMonitor(t testing.TB, backend *fakeStatusBackend) *Monitor {
+	server := httptest.NewServer(backend)
+	t.Cleanup(server.Close)
+	return NewMonitor(api.NewClient(server.URL))
+}
+
+func TestCheckNowKeepsDetailsWhenNotModified(t *testing.T) {
+	backend := newFakeStatusBackend(3, true)
+	monitor := newTestMonitor(t, backend)
+
+	if got := monitor.CheckNow(); got != StatusUp {
+		t.Fatalf("first check = %s, want %s", got, StatusUp)
+	}
+	details := monitor.GetDetails()
+	if details == nil || len(details.Services) != 3 {
+		t.Fatalf("details after the first check = %+v, want 3 services", details)
+	}
+
+	if got := monitor.CheckNow(); got != StatusUp {
+		t.Errorf("check after a 304 = %s, want %s", got, StatusUp)
+	}
+	if backend.notModified.Load() != 1 {
+		t.Errorf("backend sent %d 304 responses, want 1", backend.notModified.Load())
+	}
+	if monitor.GetDetails() != details {
+		t.Errorf("details were replaced although the backend reported no change")
+	}
+}
+
+func TestCheckNowWithoutConditionalSupport(t *testing.T) {
+	backend := newFakeStatusBackend(3, false)
+	monitor := newTestMonitor(t, backend)
+
+	monitor.CheckNow()
+	first := monitor.GetDetails()
+	monitor.CheckNow()
+	if second := monitor.GetDetails(); second == first || second == nil {
+		t.Errorf("details were not fetched again from a backend without ETags")
+	}
+}
+
+// BenchmarkCheckNow compares the per-tick work of the monitor on a large,
+// unchanged service list with and without conditional requests
+func TestStartupRetriesEndOnStop(t *testing.T) {
+	var requests atomic.Int32
+	firstRequest := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			close(firstRequest)
+		}
+		http.Error(w, "reloading", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	monitor := NewMonitor(api.NewClient(server.URL))
+	monitor.MarkStarted()
+	monitor.mutex.Lock()
+	monitor.running = true
+	monitor.stopChan = make(chan struct{})
+	monitor.mutex.Unlock()
+
+	go func() {
+		<-firstRequest
+		monitor.Stop()
+	}()
+
+	start := time.Now()
+	status, _ := monitor.checkStatus()
+	if elapsed := time.Since(start); elapsed >= startupRetries*startupRetryDelay {
+		t.Errorf("checkStatus took %s after Stop, want the remaining retries skipped", elapsed)
+	}
+	if status != StatusStarting {
+		t.Errorf("status = %v, want %v", status, StatusStarting)
+	}
+}
+
+func BenchmarkCheckNow(b *testing.B) {
+	for _, conditional := range []bool{false, true} {
+		name := "full"
+		if conditional {
+			name = "not-modified"
+		}
+		b.Run(name, func(b *testing.B) {
+			monitor := newTestMonitor(b, newFakeStatusBackend(200, conditional))
+			monitor.CheckNow()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if monitor.CheckNow() != StatusUp {
+					b.Fatal("status check failed")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/api"
+)
+
+// Reachability pings the API in the background and tracks whether it
+// answers and how fast. Unlike Monitor it says nothing about the services,
+// only whether the launcher can talk to the backend, which matters when
+// the backend runs on another machine.
+type Reachability struct {
+	apiClient *api.Client
+	interval  time.Duration
+	timeout   time.Duration
+
+	mutex     sync.RWMutex
+	checked   bool
+	reachable bool
+	latency   time.Duration
+	stopChan  chan struct{}
+	running   bool
+}
+
+// NewReachability creates a monitor pinging the API every interval
+func NewReachability(apiClient *api.Client, interval time.Duration) *Reachability {
+	return &Reachability{
+		apiClient: apiClient,
+		interval:  interval,
+		timeout:   defaultTimeout,
+	}
+}
+
+// Start begins pinging the API in the background
+func (r *Reachability) Start() {
+	r.mutex.Lock()
+	if r.running {
+		r.mutex.Unlock()
+		return
+	}
+	r.running = true
+	r.stopChan = make(chan struct{})
+	stopChan := r.stopChan
+	r.mutex.Unlock()
+
+	go r.loop(stopChan)
+}
+
+// Stop stops pinging the API
+func (r *Reachability) Stop() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.running {
+		return
+	}
+	r.running = false
+	close(r.stopChan)
+}
+
+// SetInterval changes how often the API is pinged. A running monitor
+// restarts with the new interval.
+func (r *Reachability) SetInterval(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	r.mutex.Lock()
+	changed := interval != r.interval
+	r.interval = interval
+	running := r.running
+	r.mutex.Unlock()
+
+	if changed && running {
+		r.Stop()
+		r.Start()
+	}
+}
+
+// PingNow pings the API immediately and returns whether it answered
+func (r *Reachability) PingNow() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	latency, err := r.apiClient.Ping(ctx)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.checked = true
+	r.reachable = err == nil
+	r.latency = latency
+	return r.reachable
+}
+
+// FormatStatus returns e.g. "API: connected (23ms)" or "API: unreachable"
+func (r *Reachability) FormatStatus() string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	switch {
+	case !r.checked:
+		return "API: checking..."
+	case !r.reachable:
+		return "API: unreachable"
+	default:
+		return fmt.Sprintf("API: connected (%dms)", r.latency.Milliseconds())
+	}
+}
+
+// loop pings the API until stopChan is closed
+func (r *Reachability) loop(stopChan <-chan struct{}) {
+	r.mutex.RLock()
+	interval := r.interval
+	r.mutex.RUnlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	r.PingNow()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.PingNow()
+		case <-stopChan:
+			return
+		}
+	}
+}
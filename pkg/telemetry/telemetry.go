@@ -0,0 +1,188 @@
+// Package telemetry sends anonymous reports of failed operations, if the
+// user opted in. An event holds only the operation, an error category, the
+// platform, the operation mode and the launcher version - never error
+// messages, paths or configuration values.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/httpx"
+)
+
+// Error categories
+const (
+	CategoryCancelled   = "cancelled"
+	CategoryTimeout     = "timeout"
+	CategoryCircuitOpen = "circuit-open"
+	CategoryUnsupported = "unsupported"
+	CategoryPermission  = "permission"
+	CategoryNotFound    = "not-found"
+	CategoryConnection  = "connection"
+	CategoryHTTP4xx     = "http-4xx"
+	CategoryHTTP5xx     = "http-5xx"
+	CategoryOther       = "other"
+)
+
+// batchSize is how many events are collected before they are sent
+const batchSize = 10
+
+// sendTimeout bounds a single upload
+const sendTimeout = 5 * time.Second
+
+// Event is an anonymous report of a failed operation
+type Event struct {
+	Time            time.Time `json:"time"`
+	Operation       string    `json:"operation"`
+	Category        string    `json:"category"`
+	Platform        string    `json:"platform"`
+	Mode            string    `json:"mode"`
+	LauncherVersion string    `json:"launcher_version"`
+}
+
+// Client batches events and posts them to an endpoint. Sending fails
+// silently: telemetry never gets in the way of the launcher.
+type Client struct {
+	endpoint   string
+	version    string
+	enabled    func() bool // Consulted before recording and sending
+	httpClient *http.Client
+
+	mu     sync.Mutex
+	events []Event
+}
+
+// New creates a client posting to endpoint. Nothing is recorded or sent
+// while enabled returns false or endpoint is empty.
+func New(endpoint, version string, enabled func() bool) *Client {
+	return &Client{
+		endpoint:   endpoint,
+		version:    version,
+		enabled:    enabled,
+		httpClient: httpx.NewClient(),
+	}
+}
+
+// active reports whether events are collected
+func (c *Client) active() bool {
+	return c.endpoint != "" && c.enabled()
+}
+
+// Record queues an event for a failed operation. A full batch is sent in
+// the background.
+func (c *Client) Record(operation string, err error, mode string) {
+	if err == nil || !c.active() {
+		return
+	}
+
+	event := c.NewEvent(operation, err, mode)
+
+	c.mu.Lock()
+	c.events = append(c.events, event)
+	full := len(c.events) >= batchSize
+	c.mu.Unlock()
+
+	if full {
+		go c.Flush(context.Background())
+	}
+}
+
+// NewEvent builds the event that Record would queue for the failure
+func (c *Client) NewEvent(operation string, err error, mode string) Event {
+	return Event{
+		Time:            time.Now().UTC().Truncate(time.Second),
+		Operation:       operation,
+		Category:        Categorize(err),
+		Platform:        runtime.GOOS + "/" + runtime.GOARCH,
+		Mode:            mode,
+		LauncherVersion: c.version,
+	}
+}
+
+// Pending returns the events that have not been sent yet
+func (c *Client) Pending() []Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Event(nil), c.events...)
+}
+
+// Flush sends the pending events. Events are dropped if sending fails.
+func (c *Client) Flush(ctx context.Context) {
+	c.mu.Lock()
+	events := c.events
+	c.events = nil
+	c.mu.Unlock()
+
+	if len(events) == 0 || !c.active() {
+		return
+	}
+
+	payload, err := Payload(events)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
+
+// Payload returns the request body that sends events. It is also used to
+// show users exactly what would be sent.
+func Payload(events []Event) ([]byte, error) {
+	if events == nil {
+		events = []Event{}
+	}
+	return json.MarshalIndent(struct {
+		Events []Event `json:"events"`
+	}{events}, "", "  ")
+}
+
+// Categorize maps an error to a coarse category that reveals nothing about
+// the user's system
+func Categorize(err error) string {
+	var statusErr *api.StatusError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return CategoryCancelled
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded):
+		return CategoryTimeout
+	case errors.Is(err, api.ErrCircuitOpen):
+		return CategoryCircuitOpen
+	case errors.Is(err, api.ErrUnsupported):
+		return CategoryUnsupported
+	case errors.Is(err, os.ErrPermission):
+		return CategoryPermission
+	case errors.Is(err, os.ErrNotExist):
+		return CategoryNotFound
+	case errors.As(err, &statusErr):
+		if statusErr.StatusCode >= 500 {
+			return CategoryHTTP5xx
+		}
+		return CategoryHTTP4xx
+	case api.IsTransientError(err):
+		return CategoryConnection
+	default:
+		return CategoryOther
+	}
+}
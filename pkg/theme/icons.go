@@ -0,0 +1,169 @@
+// Package theme holds the icons used across the terminal UI. Every icon
+// has an emoji form and a plain ASCII form for terminals that render emoji
+// as boxes and for screen readers.
+package theme
+
+import "sync/atomic"
+
+// Icon identifies a UI icon independent of how it is rendered
+type Icon int
+
+const (
+	// None renders as an empty string
+	None Icon = iota
+
+	// Message prefixes
+	Success
+	Error
+	Warning
+	Info
+	Progress
+	Skipped
+	Done
+	Failed
+
+	// Service status dots
+	StatusUp
+	StatusDown
+	StatusTransition
+	StatusError
+	StatusUnknown
+
+	// Menu entries and headings
+	Start
+	Stop
+	Restart
+	Status
+	Dashboard
+	Logs
+	Events
+	Bootstrap
+	EditConfig
+	RestoreEnv
+	Configure
+	Environment
+	Folder
+	Compose
+	Backup
+	Update
+	CheckUpdates
+	CheckStackUpdates
+	Diagnostics
+	Telemetry
+	Certificate
+	Uninstall
+	Exit
+	Back
+	Add
+	Role
+	Package
+	Lines
+)
+
+// emojiIcons is the default icon set
+var emojiIcons = map[Icon]string{
+	Success:  "✅",
+	Error:    "❌",
+	Warning:  "⚠️ ",
+	Info:     "ℹ️ ",
+	Progress: "🔄",
+	Skipped:  "⏭️ ",
+	Done:     "✓",
+	Failed:   "✗",
+
+	StatusUp:         "🟢",
+	StatusDown:       "🔴",
+	StatusTransition: "🟡",
+	StatusError:      "🔴",
+	StatusUnknown:    "⚪",
+
+	Start:             "🚀",
+	Stop:              "🛑",
+	Restart:           "🔄",
+	Status:            "📊",
+	Dashboard:         "📈",
+	Logs:              "📋",
+	Events:            "🔔",
+	Bootstrap:         "🔧",
+	EditConfig:        "📝",
+	RestoreEnv:        "⏪",
+	Configure:         "⚙️",
+	Environment:       "🗂️ ",
+	Folder:            "📂",
+	Compose:           "🐳",
+	Backup:            "💾",
+	Update:            "⬆️",
+	CheckUpdates:      "🔄",
+	CheckStackUpdates: "🔎",
+	Diagnostics:       "🩺",
+	Telemetry:         "📡",
+	Certificate:       "🔐",
+	Uninstall:         "🗑️",
+	Exit:              "👋",
+	Back:              "⬅️",
+	Add:               "➕",
+	Role:              "👤",
+	Package:           "📦",
+	Lines:             "📏",
+}
+
+// shapeIcons replace the colored status dots for palettes that must not
+// rely on color alone
+var shapeIcons = map[Icon]string{
+	StatusUp:         "✅",
+	StatusDown:       "⏹️ ",
+	StatusTransition: "⏳",
+	StatusError:      "❌",
+	StatusUnknown:    "❔",
+}
+
+// plainIcons replaces every emoji with ASCII. Icons missing here fall back
+// to "*".
+var plainIcons = map[Icon]string{
+	Success:  "[OK]",
+	Error:    "[ERR]",
+	Warning:  "[WARN]",
+	Info:     "[INFO]",
+	Progress: "[..]",
+	Skipped:  "[SKIP]",
+	Done:     "[OK]",
+	Failed:   "[ERR]",
+
+	StatusUp:         "[UP]",
+	StatusDown:       "[DOWN]",
+	StatusTransition: "[..]",
+	StatusError:      "[ERR]",
+	StatusUnknown:    "[?]",
+
+	Back: "<",
+	Add:  "+",
+}
+
+var plain atomic.Bool
+
+// SetPlain switches between emoji (false) and plain ASCII (true) icons
+func SetPlain(enabled bool) {
+	plain.Store(enabled)
+}
+
+// IsPlain reports whether plain ASCII icons are in use
+func IsPlain() bool {
+	return plain.Load()
+}
+
+// String renders the icon for the active icon set
+func (i Icon) String() string {
+	if i == None {
+		return ""
+	}
+	if !IsPlain() {
+		if icon, ok := shapeIcons[i]; ok && CurrentPalette().ShapeStatus {
+			return icon
+		}
+		return emojiIcons[i]
+	}
+	if icon, ok := plainIcons[i]; ok {
+		return icon
+	}
+	return "*"
+}
@@ -0,0 +1,129 @@
+package theme
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Palette names
+const (
+	PaletteDefault      = "default"
+	PaletteHighContrast = "high-contrast"
+	PaletteColorblind   = "colorblind"
+)
+
+// Palette holds the colors of the terminal UI
+type Palette struct {
+	Title       lipgloss.Color // Screen titles
+	Accent      lipgloss.Color // Headers, spinner and section names
+	Text        lipgloss.Color // Regular items
+	Muted       lipgloss.Color // Help text
+	Border      lipgloss.Color // Borders and the editor table header
+	SelectedFg  lipgloss.Color
+	SelectedBg  lipgloss.Color
+	BannerFg    lipgloss.Color
+	BannerBg    lipgloss.Color
+	Good        lipgloss.Color // Healthy, finished
+	Bad         lipgloss.Color // Errors, unhealthy, required
+	Pending     lipgloss.Color // Warnings, starting, live status
+	Secret      lipgloss.Color
+	ShapeStatus bool // Status icons differ in shape, not only in color
+}
+
+var palettes = map[string]Palette{
+	PaletteDefault: {
+		Title:      "205",
+		Accent:     "99",
+		Text:       "252",
+		Muted:      "241",
+		Border:     "62",
+		SelectedFg: "230",
+		SelectedBg: "57",
+		BannerFg:   "230",
+		BannerBg:   "28",
+		Good:       "42",
+		Bad:        "196",
+		Pending:    "214",
+		Secret:     "208",
+	},
+	// Bright colors on the terminal background, black on white for the
+	// selection
+	PaletteHighContrast: {
+		Title:       "15",
+		Accent:      "14",
+		Text:        "15",
+		Muted:       "250",
+		Border:      "15",
+		SelectedFg:  "0",
+		SelectedBg:  "15",
+		BannerFg:    "0",
+		BannerBg:    "11",
+		Good:        "10",
+		Bad:         "9",
+		Pending:     "11",
+		Secret:      "13",
+		ShapeStatus: true,
+	},
+	// Blue for good and orange for bad instead of green and red, which are
+	// hard to tell apart with the common forms of color blindness
+	PaletteColorblind: {
+		Title:       "205",
+		Accent:      "99",
+		Text:        "252",
+		Muted:       "245",
+		Border:      "62",
+		SelectedFg:  "230",
+		SelectedBg:  "25",
+		BannerFg:    "230",
+		BannerBg:    "25",
+		Good:        "33",
+		Bad:         "208",
+		Pending:     "220",
+		Secret:      "177",
+		ShapeStatus: true,
+	},
+}
+
+var (
+	mu            sync.RWMutex
+	activePalette = palettes[PaletteDefault]
+	activeStyles  = newStyles(activePalette)
+)
+
+// SetPalette selects the named palette. An empty name selects the default.
+func SetPalette(name string) error {
+	if name == "" {
+		name = PaletteDefault
+	}
+
+	palette, ok := palettes[name]
+	if !ok {
+		return fmt.Errorf("unknown palette '%s' (available: %v)", name, PaletteNames())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	activePalette = palette
+	activeStyles = newStyles(palette)
+	return nil
+}
+
+// PaletteNames returns the names of all palettes
+func PaletteNames() []string {
+	names := make([]string, 0, len(palettes))
+	for name := range palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CurrentPalette returns the active palette
+func CurrentPalette() Palette {
+	mu.RLock()
+	defer mu.RUnlock()
+	return activePalette
+}
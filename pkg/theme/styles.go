@@ -0,0 +1,116 @@
+package theme
+
+import "github.com/charmbracelet/lipgloss"
+
+// StyleSet holds the lipgloss styles of the terminal UI, derived from a
+// palette
+type StyleSet struct {
+	Title    lipgloss.Style
+	Header   lipgloss.Style // Menu, prompt and table headers
+	Selected lipgloss.Style
+	Item     lipgloss.Style // Unselected menu items and table cells
+	Prompt   lipgloss.Style // Bordered input boxes
+	Error    lipgloss.Style
+	Warning  lipgloss.Style
+	Danger   lipgloss.Style // Selected "Yes" of a destructive confirmation
+	Help     lipgloss.Style
+	Banner   lipgloss.Style
+	Status   lipgloss.Style // Live status line above the menu
+
+	Spinner     lipgloss.Style
+	SpinnerDone lipgloss.Style
+
+	TableBorder lipgloss.Style
+	Healthy     lipgloss.Style
+	Unhealthy   lipgloss.Style
+	Pending     lipgloss.Style
+
+	// Configuration editor
+	EditorHeader   lipgloss.Style
+	EditorSelected lipgloss.Style
+	EditorItem     lipgloss.Style
+	EditorHelp     lipgloss.Style
+	Section        lipgloss.Style
+	Secret         lipgloss.Style
+}
+
+// CompactWidth is the terminal width below which views switch to a compact
+// layout with fewer columns and less padding, e.g. in tmux panes
+const CompactWidth = 100
+
+// IsCompact reports whether a terminal of the given width needs the
+// compact layout. An unknown width (0) does not.
+func IsCompact(width int) bool {
+	return width > 0 && width < CompactWidth
+}
+
+// newStyles builds the style set for a palette
+func newStyles(p Palette) *StyleSet {
+	styles := &StyleSet{
+		Title: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(p.Title).
+			Padding(1, 2),
+		Header: lipgloss.NewStyle().
+			Bold(true).
+			Foreground(p.Accent).
+			Padding(0, 1),
+		Selected: lipgloss.NewStyle().
+			Background(p.SelectedBg).
+			Foreground(p.SelectedFg).
+			Padding(0, 1),
+		Item: lipgloss.NewStyle().
+			Foreground(p.Text).
+			Padding(0, 1),
+		Prompt: lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(p.Border).
+			Padding(0, 1),
+		Error: lipgloss.NewStyle().
+			Foreground(p.Bad).
+			Bold(true),
+		Warning: lipgloss.NewStyle().
+			Foreground(p.Pending).
+			Bold(true),
+		Help: lipgloss.NewStyle().
+			Foreground(p.Muted).
+			Italic(true),
+		Banner: lipgloss.NewStyle().
+			Bold(true).
+			Background(p.BannerBg).
+			Foreground(p.BannerFg).
+			Padding(0, 1),
+
+		TableBorder: lipgloss.NewStyle().Foreground(p.Border),
+		Healthy:     lipgloss.NewStyle().Foreground(p.Good),
+		Unhealthy:   lipgloss.NewStyle().Foreground(p.Bad),
+		Pending:     lipgloss.NewStyle().Foreground(p.Pending),
+		Secret:      lipgloss.NewStyle().Foreground(p.Secret),
+	}
+
+	// Variants share the colors of the canonical styles and only differ in
+	// layout
+	styles.Status = styles.Pending.Padding(0, 1)
+	styles.Danger = styles.Selected.
+		Background(p.Bad).
+		Foreground(p.SelectedFg).
+		Bold(true)
+	styles.Spinner = styles.Header.UnsetBold().UnsetPadding()
+	styles.SpinnerDone = styles.Healthy
+	styles.EditorHeader = styles.Header.
+		Background(p.Border).
+		Foreground(p.SelectedFg)
+	styles.EditorSelected = styles.Selected.UnsetPadding()
+	styles.EditorItem = styles.Item.UnsetPadding()
+	styles.EditorHelp = styles.Help.UnsetItalic().Margin(1, 0)
+	styles.Section = styles.Header.UnsetPadding().Margin(1, 0, 0, 0)
+
+	return styles
+}
+
+// Styles returns the styles of the active palette
+func Styles() *StyleSet {
+	mu.RLock()
+	defer mu.RUnlock()
+	return activeStyles
+}
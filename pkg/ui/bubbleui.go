@@ -7,41 +7,9 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-)
 
-// Common styles for consistent UI
-var (
-	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("205")).
-			Padding(1, 2)
-
-	menuHeaderStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("99")).
-			Padding(0, 1)
-
-	selectedItemStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("57")).
-				Foreground(lipgloss.Color("230")).
-				Padding(0, 1)
-
-	normalItemStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252")).
-			Padding(0, 1)
-
-	promptStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62")).
-			Padding(0, 1)
-
-	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
-			Bold(true)
-
-	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
-			Italic(true)
+	"github.com/ddalab/launcher/pkg/i18n"
+	"github.com/ddalab/launcher/pkg/theme"
 )
 
 // StatusRefreshMsg is sent when the status should be refreshed
@@ -51,11 +19,12 @@ type StatusRefreshMsg struct{}
 type MenuModel struct {
 	title         string
 	items         []string
+	details       []string // Descriptions shown after the items unless compact
 	cursor        int
 	selected      int
 	choice        string
 	cancelled     bool
-	width         int
+	width         int // Terminal size, 0 until the first WindowSizeMsg
 	height        int
 	statusMonitor interface{ FormatStatus() string } // Status monitor interface
 	statusText    string                             // Cached status text
@@ -67,8 +36,6 @@ func NewMenuModel(title string, items []string) *MenuModel {
 		title:    title,
 		items:    items,
 		selected: -1,
-		width:    80,
-		height:   20,
 	}
 }
 
@@ -78,8 +45,6 @@ func NewMenuModelWithStatus(title string, items []string, statusMonitor interfac
 		title:         title,
 		items:         items,
 		selected:      -1,
-		width:         80,
-		height:        20,
 		statusMonitor: statusMonitor,
 	}
 
@@ -91,6 +56,20 @@ func NewMenuModelWithStatus(title string, items []string, statusMonitor interfac
 	return model
 }
 
+// SetDetails sets a description per item, shown after it when the terminal
+// is wide enough
+func (m *MenuModel) SetDetails(details []string) {
+	m.details = details
+}
+
+// SetCursor moves the cursor to the item at index, e.g. to preselect the
+// last choice. Out of range indexes are ignored.
+func (m *MenuModel) SetCursor(index int) {
+	if index >= 0 && index < len(m.items) {
+		m.cursor = index
+	}
+}
+
 // tickCmd returns a command that sends a tick message after 1 second
 func tickCmd() tea.Cmd {
 	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
@@ -153,41 +132,69 @@ func (m *MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *MenuModel) View() string {
+	styles := theme.Styles()
+	compact := theme.IsCompact(m.width)
+
+	// Small terminals get less padding and lines cut at the terminal width
+	// instead of wrapped
+	titleStyle, clip := styles.Title, lipgloss.NewStyle()
+	if compact {
+		titleStyle = titleStyle.Padding(0, 1)
+		clip = clip.MaxWidth(m.width)
+	}
+
 	var b strings.Builder
+	rows := m.height
 
 	// Title
 	if m.title != "" {
-		b.WriteString(titleStyle.Render(m.title) + "\n")
+		title := titleStyle.Render(m.title)
+		b.WriteString(clip.Render(title) + "\n")
+		rows -= lipgloss.Height(title)
 	}
 
 	// Status display
 	if m.statusText != "" {
-		statusStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("214")).
-			Padding(0, 1)
-		b.WriteString(statusStyle.Render("📊 DDALAB Status: "+m.statusText) + "\n\n")
+		b.WriteString(clip.Render(styles.Status.Render(withIcon(theme.Status, i18n.T("ui.status", m.statusText)))) + "\n")
+		rows--
+		if !compact {
+			b.WriteString("\n")
+			rows--
+		}
+	}
+
+	// Scroll the items when they do not fit above the help line
+	start, end := 0, len(m.items)
+	if rows -= 2; m.height > 0 && rows > 0 && rows < len(m.items) {
+		start = max(0, min(m.cursor-rows/2, len(m.items)-rows))
+		end = start + rows
 	}
 
 	// Menu items
-	for i, item := range m.items {
+	for i := start; i < end; i++ {
+		item := m.items[i]
 		cursor := " "
 		if m.cursor == i {
 			cursor = ">"
 		}
 
 		line := fmt.Sprintf("%s %s", cursor, item)
+		if !compact && i < len(m.details) && m.details[i] != "" {
+			line += " - " + m.details[i]
+		}
 
+		// Clip after styling, which pads the line
 		if m.cursor == i {
-			line = selectedItemStyle.Render(line)
+			line = styles.Selected.Render(line)
 		} else {
-			line = normalItemStyle.Render(line)
+			line = styles.Item.Render(line)
 		}
 
-		b.WriteString(line + "\n")
+		b.WriteString(clip.Render(line) + "\n")
 	}
 
 	// Help text
-	b.WriteString("\n" + helpStyle.Render("↑/↓: navigate • Enter: select • q: quit"))
+	b.WriteString("\n" + styles.Help.Render(i18n.T("ui.help.menu")))
 
 	return b.String()
 }
@@ -283,11 +290,13 @@ func (m *PromptModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *PromptModel) View() string {
+	styles := theme.Styles()
+
 	var b strings.Builder
 
 	// Title
 	if m.title != "" {
-		b.WriteString(menuHeaderStyle.Render(m.title) + "\n\n")
+		b.WriteString(styles.Header.Render(m.title) + "\n\n")
 	}
 
 	// Input field
@@ -301,16 +310,16 @@ func (m *PromptModel) View() string {
 		displayValue = displayValue[:m.cursorPos] + "█" + displayValue[m.cursorPos:]
 	}
 
-	inputField := promptStyle.Render(displayValue)
+	inputField := styles.Prompt.Render(displayValue)
 	b.WriteString(inputField + "\n")
 
 	// Error message
 	if m.errorMsg != "" {
-		b.WriteString("\n" + errorStyle.Render("Error: "+m.errorMsg) + "\n")
+		b.WriteString("\n" + styles.Error.Render(i18n.T("ui.error", m.errorMsg)) + "\n")
 	}
 
 	// Help text
-	b.WriteString("\n" + helpStyle.Render("Enter: confirm • Ctrl+U: clear • Esc: cancel"))
+	b.WriteString("\n" + styles.Help.Render(i18n.T("ui.help.prompt")))
 
 	return b.String()
 }
@@ -323,6 +332,7 @@ type ConfirmModel struct {
 	cursor    int
 	width     int
 	height    int
+	danger    bool // Defaults to No and has no y shortcut
 }
 
 // NewConfirmModel creates a new confirmation model
@@ -334,6 +344,16 @@ func NewConfirmModel(message string) *ConfirmModel {
 	}
 }
 
+// NewDangerConfirmModel creates a confirmation for destructive operations.
+// It starts on "No", is shown in warning colors and can only be confirmed
+// by moving to "Yes" and pressing Enter.
+func NewDangerConfirmModel(message string) *ConfirmModel {
+	model := NewConfirmModel(message)
+	model.cursor = 1
+	model.danger = true
+	return model
+}
+
 func (m *ConfirmModel) Init() tea.Cmd {
 	return nil
 }
@@ -357,6 +377,9 @@ func (m *ConfirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.cursor = 1
 
 		case "y":
+			if m.danger {
+				break
+			}
 			m.choice = true
 			return m, tea.Quit
 
@@ -370,13 +393,19 @@ func (m *ConfirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *ConfirmModel) View() string {
+	styles := theme.Styles()
+
 	var b strings.Builder
 
 	// Message
-	b.WriteString(menuHeaderStyle.Render(m.message) + "\n\n")
+	if m.danger {
+		b.WriteString(styles.Warning.Render(withIcon(theme.Warning, m.message)) + "\n\n")
+	} else {
+		b.WriteString(styles.Header.Render(m.message) + "\n\n")
+	}
 
 	// Options
-	options := []string{"Yes", "No"}
+	options := []string{i18n.T("ui.yes"), i18n.T("ui.no")}
 	for i, option := range options {
 		cursor := " "
 		if m.cursor == i {
@@ -385,21 +414,88 @@ func (m *ConfirmModel) View() string {
 
 		line := fmt.Sprintf("%s %s", cursor, option)
 
-		if m.cursor == i {
-			line = selectedItemStyle.Render(line)
-		} else {
-			line = normalItemStyle.Render(line)
+		switch {
+		case m.cursor == i && m.danger && i == 0:
+			line = styles.Danger.Render(line)
+		case m.cursor == i:
+			line = styles.Selected.Render(line)
+		default:
+			line = styles.Item.Render(line)
 		}
 
 		b.WriteString(line + "  ")
 	}
 
 	// Help text
-	b.WriteString("\n\n" + helpStyle.Render("←/→: navigate • Enter/Space: select • y/n: quick select • Esc: cancel"))
+	help := i18n.T("ui.help.confirm")
+	if m.danger {
+		help = i18n.T("ui.help.confirm_danger")
+	}
+	b.WriteString("\n\n" + styles.Help.Render(help))
 
 	return b.String()
 }
 
+// countdownTickMsg advances a CountdownModel by one second
+type countdownTickMsg struct{}
+
+// countdownTick returns a command that sends a countdown tick after 1 second
+func countdownTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return countdownTickMsg{}
+	})
+}
+
+// CountdownModel announces an automatic action and proceeds when the
+// countdown runs out. Any key press cancels it.
+type CountdownModel struct {
+	action    string
+	remaining int
+	proceed   bool
+}
+
+// NewCountdownModel creates a countdown that proceeds with action after the
+// given number of seconds
+func NewCountdownModel(action string, seconds int) *CountdownModel {
+	return &CountdownModel{
+		action:    action,
+		remaining: seconds,
+	}
+}
+
+func (m *CountdownModel) Init() tea.Cmd {
+	if m.remaining <= 0 {
+		m.proceed = true
+		return tea.Quit
+	}
+	return countdownTick()
+}
+
+func (m *CountdownModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg.(type) {
+	case countdownTickMsg:
+		m.remaining--
+		if m.remaining <= 0 {
+			m.proceed = true
+			return m, tea.Quit
+		}
+		return m, countdownTick()
+
+	case tea.KeyMsg:
+		m.proceed = false
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+func (m *CountdownModel) View() string {
+	if m.remaining <= 0 {
+		return ""
+	}
+	return theme.Styles().Header.Render(i18n.T("ui.countdown", m.action, m.remaining)) + "\n"
+}
+
 // WaitModel represents a simple "press enter to continue" prompt
 type WaitModel struct {
 	message   string
@@ -411,7 +507,7 @@ type WaitModel struct {
 // NewWaitModel creates a new wait model
 func NewWaitModel(message string) *WaitModel {
 	if message == "" {
-		message = "Press Enter to continue..."
+		message = i18n.T("ui.continue")
 	}
 	return &WaitModel{
 		message: message,
@@ -442,35 +538,27 @@ func (m *WaitModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *WaitModel) View() string {
-	return menuHeaderStyle.Render(m.message)
+	return theme.Styles().Header.Render(m.message)
 }
 
 // UI Helper functions to run these models
 
 // RunMenu displays a menu and returns the selected choice
 func RunMenu(title string, items []string) (string, error) {
-	model := NewMenuModel(title, items)
-	p := tea.NewProgram(model)
-
-	finalModel, err := p.Run()
-	if err != nil {
-		return "", err
-	}
-
-	menuModel := finalModel.(*MenuModel)
-	if menuModel.cancelled {
-		return "", fmt.Errorf("cancelled")
-	}
-
-	return menuModel.choice, nil
+	return RunMenuModel(NewMenuModel(title, items))
 }
 
 // RunMenuWithStatus displays a menu with live status updates
 func RunMenuWithStatus(title string, items []string, statusMonitor interface{ FormatStatus() string }) (string, error) {
-	model := NewMenuModelWithStatus(title, items, statusMonitor)
-	p := tea.NewProgram(model)
+	return RunMenuModel(NewMenuModelWithStatus(title, items, statusMonitor))
+}
 
-	finalModel, err := p.Run()
+// RunMenuModel displays a prepared menu and returns the selected choice
+func RunMenuModel(model *MenuModel) (string, error) {
+	finalModel, ok, err := runProgram(model)
+	if !ok {
+		return simpleMenu(model)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -486,9 +574,10 @@ func RunMenuWithStatus(title string, items []string, statusMonitor interface{ Fo
 // RunPrompt displays a text input prompt and returns the entered value
 func RunPrompt(title, placeholder string, validate func(string) error) (string, error) {
 	model := NewPromptModel(title, placeholder, validate)
-	p := tea.NewProgram(model)
-
-	finalModel, err := p.Run()
+	finalModel, ok, err := runProgram(model)
+	if !ok {
+		return simplePrompt(model)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -504,9 +593,30 @@ func RunPrompt(title, placeholder string, validate func(string) error) (string,
 // RunConfirm displays a yes/no confirmation and returns the choice
 func RunConfirm(message string) (bool, error) {
 	model := NewConfirmModel(message)
-	p := tea.NewProgram(model)
+	finalModel, ok, err := runProgram(model)
+	if !ok {
+		return simpleConfirm(model), nil
+	}
+	if err != nil {
+		return false, err
+	}
 
-	finalModel, err := p.Run()
+	confirmModel := finalModel.(*ConfirmModel)
+	if confirmModel.cancelled {
+		return false, nil
+	}
+
+	return confirmModel.choice, nil
+}
+
+// RunDangerConfirm displays a confirmation for a destructive operation that
+// defaults to "No" and returns the choice
+func RunDangerConfirm(message string) (bool, error) {
+	model := NewDangerConfirmModel(message)
+	finalModel, ok, err := runProgram(model)
+	if !ok {
+		return simpleConfirm(model), nil
+	}
 	if err != nil {
 		return false, err
 	}
@@ -519,11 +629,27 @@ func RunConfirm(message string) (bool, error) {
 	return confirmModel.choice, nil
 }
 
+// RunCountdown announces action and returns true once the countdown of
+// the given length has run out, or false if a key was pressed before
+func RunCountdown(action string, countdown time.Duration) (bool, error) {
+	model := NewCountdownModel(action, int(countdown.Round(time.Second).Seconds()))
+	finalModel, ok, err := runProgram(model)
+	if !ok {
+		return simpleCountdown(model), nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return finalModel.(*CountdownModel).proceed, nil
+}
+
 // RunWait displays a "press enter to continue" message
 func RunWait(message string) error {
 	model := NewWaitModel(message)
-	p := tea.NewProgram(model)
-
-	_, err := p.Run()
-	return err
+	if _, ok, err := runProgram(model); ok {
+		return err
+	}
+	simpleWait(model)
+	return nil
 }
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fakeStatus is a status monitor with a fixed status line
+type fakeStatus string
+
+func (s fakeStatus) FormatStatus() string { return string(s) }
+
+func TestMenuModelCompactFitsTerminal(t *testing.T) {
+	const width, height = 60, 20
+
+	var items, details []string
+	for i := range 30 {
+		items = append(items, strings.Repeat("Start DDALAB services ", 3)+string(rune('A'+i%26)))
+		details = append(details, "Starts every DDALAB container and waits until all of them report healthy")
+	}
+	model := NewMenuModelWithStatus("DDALAB Launcher - a rather long title for a small terminal pane", items,
+		fakeStatus("Running - 5 of 5 services healthy, API at http://localhost:8000, last checked just now"))
+	model.SetDetails(details)
+	model.SetCursor(15)
+	model.Update(tea.WindowSizeMsg{Width: width, Height: height})
+
+	assertFits(t, model.View(), width, height)
+}
+
+// assertFits fails the test if view has a line wider than width or more
+// than height lines
+func assertFits(t *testing.T, view string, width, height int) {
+	t.Helper()
+	lines := strings.Split(view, "\n")
+	if len(lines) > height {
+		t.Errorf("view has %d lines, want at most %d:\n%s", len(lines), height, view)
+	}
+	for i, line := range lines {
+		if w := lipgloss.Width(line); w > width {
+			t.Errorf("line %d is %d columns wide, want at most %d: %q", i+1, w, width, line)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+
+	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/status"
+	"github.com/ddalab/launcher/pkg/theme"
+)
+
+// dashboardRefreshMsg redraws the dashboard from the monitor's last check
+type dashboardRefreshMsg struct{}
+
+// dashboardEventMsg carries a status transition from the monitor
+type dashboardEventMsg status.StatusEvent
+
+// DashboardModel is a full-screen, auto-refreshing status view
+type DashboardModel struct {
+	monitor   *status.Monitor
+	events    <-chan status.StatusEvent
+	accessURL string
+	logSource LogSource // Nil if logs cannot be shown
+
+	current   status.Status
+	details   *api.Status
+	lastCheck time.Time
+	lastEvent *status.StatusEvent
+	cursor    int // Selected service row
+	height    int // Terminal height, for fitting the log view
+
+	logs *logView // Open log view of the selected service, nil on the table
+
+	// The rendered services table and what it was rendered from. While the
+	// backend reports no changes the monitor keeps the same details, so
+	// the table is not rendered again every tick.
+	table       string
+	tableFor    *api.Status
+	tableCursor int
+}
+
+// NewDashboardModel creates a dashboard fed by the monitor's subscription
+// channel. The monitor must be running for the view to change. With a
+// logSource, Enter opens the logs of the selected service.
+func NewDashboardModel(monitor *status.Monitor, events <-chan status.StatusEvent, accessURL string, logSource LogSource) *DashboardModel {
+	model := &DashboardModel{
+		monitor:   monitor,
+		events:    events,
+		accessURL: accessURL,
+		logSource: logSource,
+	}
+	model.refresh()
+	return model
+}
+
+// waitForEvent returns a command that delivers the next status transition
+func (m *DashboardModel) waitForEvent() tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-m.events
+		if !ok {
+			return nil
+		}
+		return dashboardEventMsg(event)
+	}
+}
+
+// refreshCmd schedules the next redraw on the monitor's interval
+func (m *DashboardModel) refreshCmd() tea.Cmd {
+	return tea.Tick(m.monitor.GetRefreshRate(), func(time.Time) tea.Msg {
+		return dashboardRefreshMsg{}
+	})
+}
+
+// refresh copies the latest check results from the monitor
+func (m *DashboardModel) refresh() {
+	m.current = m.monitor.GetStatus()
+	m.details = m.monitor.GetDetails()
+	m.lastCheck = m.monitor.GetLastCheck()
+
+	if m.details == nil || m.cursor >= len(m.details.Services) {
+		m.cursor = 0
+	}
+}
+
+func (m *DashboardModel) Init() tea.Cmd {
+	return tea.Batch(m.waitForEvent(), m.refreshCmd())
+}
+
+func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case dashboardRefreshMsg:
+		m.refresh()
+		// Follow the open log stream on the same interval
+		return m, tea.Batch(m.refreshCmd(), m.fetchLogs())
+
+	case logsMsg:
+		m.applyLogs(msg)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		return m, nil
+
+	case dashboardEventMsg:
+		event := status.StatusEvent(msg)
+		m.lastEvent = &event
+		m.refresh()
+		return m, m.waitForEvent()
+
+	case tea.KeyMsg:
+		if m.logs != nil {
+			return m.updateLogView(msg)
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.details != nil && m.cursor < len(m.details.Services)-1 {
+				m.cursor++
+			}
+		case "enter":
+			return m, m.openLogView()
+		}
+	}
+
+	return m, nil
+}
+
+func (m *DashboardModel) View() string {
+	if m.logs != nil {
+		return m.logViewView()
+	}
+
+	styles := theme.Styles()
+
+	var b strings.Builder
+
+	b.WriteString(styles.Title.Render(withIcon(theme.Status, "DDALAB Dashboard")) + "\n")
+	b.WriteString(styles.Header.Render(fmt.Sprintf("Status: %s %s", m.current.GetColoredDot(), m.current)) + "\n")
+	b.WriteString(styles.Header.Render("Access: "+m.accessURL) + "\n")
+
+	if !m.lastCheck.IsZero() {
+		b.WriteString(styles.Item.Render("Last check: "+m.lastCheck.Format("15:04:05")) + "\n")
+	}
+	if m.lastEvent != nil {
+		b.WriteString(styles.Item.Render(fmt.Sprintf("Last change: %s → %s at %s",
+			m.lastEvent.Previous, m.lastEvent.Current, m.lastEvent.Time.Format("15:04:05"))) + "\n")
+	}
+	b.WriteString("\n")
+
+	if m.details == nil || len(m.details.Services) == 0 {
+		b.WriteString(styles.Help.Render("No service information available") + "\n")
+	} else {
+		b.WriteString(m.cachedServicesTable() + "\n")
+	}
+
+	help := fmt.Sprintf("Refreshing every %s • q: quit", m.monitor.GetRefreshRate())
+	if m.logSource != nil {
+		help = fmt.Sprintf("Refreshing every %s • ↑/↓: select • enter: logs • q: quit", m.monitor.GetRefreshRate())
+	}
+	b.WriteString("\n" + styles.Help.Render(help))
+
+	return b.String()
+}
+
+// cachedServicesTable returns the services table, rendering it only when
+// the details or the selection changed
+func (m *DashboardModel) cachedServicesTable() string {
+	if m.details != m.tableFor || m.cursor != m.tableCursor || m.table == "" {
+		m.table = m.servicesTable()
+		m.tableFor, m.tableCursor = m.details, m.cursor
+	}
+	return m.table
+}
+
+// servicesTable renders one row per service with a colored health cell
+func (m *DashboardModel) servicesTable() string {
+	styles := theme.Styles()
+
+	const healthColumn = 2
+
+	services := m.details.Services
+	rows := make([][]string, 0, len(services))
+	for _, service := range services {
+		uptime := service.Uptime
+		if uptime == "" {
+			uptime = "-"
+		}
+		rows = append(rows, []string{
+			service.Name,
+			service.Status,
+			service.Health,
+			uptime,
+			strconv.Itoa(service.Restarts),
+		})
+	}
+
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(styles.TableBorder).
+		Headers("SERVICE", "STATUS", "HEALTH", "UPTIME", "RESTARTS").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return styles.Header
+			}
+			if col == healthColumn && row < len(services) {
+				return healthStyle(services[row].Health).Padding(0, 1)
+			}
+			if m.logSource != nil && row == m.cursor {
+				return styles.Selected
+			}
+			return styles.Item
+		})
+
+	return t.Render()
+}
+
+// healthStyle picks the color for a service health value
+func healthStyle(health string) lipgloss.Style {
+	styles := theme.Styles()
+
+	switch strings.ToLower(health) {
+	case "healthy":
+		return styles.Healthy
+	case "unhealthy":
+		return styles.Unhealthy
+	default:
+		return styles.Pending
+	}
+}
+
+// RunDashboard shows the dashboard until the user presses q. logSource may
+// be nil if service logs are unavailable.
+func RunDashboard(monitor *status.Monitor, accessURL string, logSource LogSource) error {
+	events, unsubscribe := monitor.Subscribe()
+	defer unsubscribe()
+
+	_, ok, err := runProgram(NewDashboardModel(monitor, events, accessURL, logSource), tea.WithAltScreen())
+	if !ok {
+		return fmt.Errorf("the dashboard needs the full-screen terminal UI")
+	}
+	return err
+}
@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ddalab/launcher/pkg/theme"
+)
+
+// LogSource fetches the last tail lines of a service's logs at level or
+// above; an empty level means all lines
+type LogSource func(service string, tail int, level string) (string, error)
+
+// logTails are the tail lengths the log view steps through with +/-
+var logTails = []int{50, 100, 200, 500, 1000}
+
+// logLevels are the minimum levels the log view cycles through, "" for all
+var logLevels = []string{"", "debug", "info", "warn", "error"}
+
+// logsMsg carries fetched logs for the dashboard's log view
+type logsMsg struct {
+	fetch int // logView.fetch when requested
+	text  string
+	err   error
+}
+
+// logView is the dashboard's drill-down into one service's logs
+type logView struct {
+	service string
+	fetch   int // Counts fetches, so results of superseded ones are dropped
+	tail    int // Index into logTails
+	level   int // Index into logLevels
+	text    string
+	err     error
+	loading bool
+	scroll  int // Lines scrolled up from the newest
+}
+
+// openLogView shows the logs of the selected service
+func (m *DashboardModel) openLogView() tea.Cmd {
+	if m.logSource == nil || m.details == nil || m.cursor >= len(m.details.Services) {
+		return nil
+	}
+
+	m.logs = &logView{service: m.details.Services[m.cursor].Name, tail: 1}
+	return m.fetchLogs()
+}
+
+// fetchLogs loads the open log view in the background, unless no view is
+// open or a fetch is still running
+func (m *DashboardModel) fetchLogs() tea.Cmd {
+	view := m.logs
+	if view == nil || view.loading {
+		return nil
+	}
+	view.loading = true
+	view.fetch++
+
+	source := m.logSource
+	fetch, service, tail, level := view.fetch, view.service, logTails[view.tail], logLevels[view.level]
+	return func() tea.Msg {
+		text, err := source(service, tail, level)
+		return logsMsg{fetch: fetch, text: text, err: err}
+	}
+}
+
+// applyLogs shows fetched logs unless their view was closed or a newer
+// fetch was started since
+func (m *DashboardModel) applyLogs(msg logsMsg) {
+	view := m.logs
+	if view == nil || view.fetch != msg.fetch {
+		return
+	}
+	view.loading = false
+	view.text, view.err = msg.text, msg.err
+	m.clampScroll()
+}
+
+// clampScroll keeps the log view from scrolling past the oldest line
+func (m *DashboardModel) clampScroll() {
+	view := m.logs
+	lines := strings.Count(view.text, "\n") + 1
+	view.scroll = min(view.scroll, max(lines-m.logLines(), 0))
+}
+
+// reloadLogs fetches the logs again after the filter changed. A fetch that
+// is still running is superseded, its result is ignored.
+func (m *DashboardModel) reloadLogs() tea.Cmd {
+	m.logs.loading = false
+	m.logs.scroll = 0
+	return m.fetchLogs()
+}
+
+// updateLogView handles keys while the log view is open
+func (m *DashboardModel) updateLogView(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	view := m.logs
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "esc":
+		m.logs = nil
+	case "+", "=":
+		if view.tail < len(logTails)-1 {
+			view.tail++
+			return m, m.reloadLogs()
+		}
+	case "-":
+		if view.tail > 0 {
+			view.tail--
+			return m, m.reloadLogs()
+		}
+	case "l":
+		view.level = (view.level + 1) % len(logLevels)
+		return m, m.reloadLogs()
+	case "r":
+		return m, m.reloadLogs()
+	case "up", "k":
+		view.scroll++
+	case "down", "j":
+		if view.scroll > 0 {
+			view.scroll--
+		}
+	case "pgup":
+		view.scroll += m.logLines()
+	case "pgdown":
+		view.scroll = max(view.scroll-m.logLines(), 0)
+	case "end":
+		view.scroll = 0
+	}
+
+	if m.logs != nil {
+		m.clampScroll()
+	}
+	return m, nil
+}
+
+// logLines is how many log lines fit below the header and above the help
+func (m *DashboardModel) logLines() int {
+	const chrome = 9 // Title with padding, filter line, blank lines and help
+	if m.height <= chrome {
+		return 20
+	}
+	return m.height - chrome
+}
+
+func (m *DashboardModel) logViewView() string {
+	styles := theme.Styles()
+	view := m.logs
+
+	var b strings.Builder
+
+	b.WriteString(styles.Title.Render(withIcon(theme.Logs, "Logs: "+view.service)) + "\n")
+
+	level := logLevels[view.level]
+	if level == "" {
+		level = "all"
+	}
+	filter := fmt.Sprintf("Last %d lines • Level: %s", logTails[view.tail], level)
+	if view.loading {
+		filter += " • loading..."
+	}
+	b.WriteString(styles.Header.Render(filter) + "\n\n")
+
+	switch {
+	case view.err != nil:
+		b.WriteString(styles.Error.Render(fmt.Sprintf("Could not fetch logs: %v", view.err)) + "\n")
+	case view.text == "" && !view.loading:
+		b.WriteString(styles.Help.Render("No log lines match the filter") + "\n")
+	default:
+		lines := strings.Split(view.text, "\n")
+		end := max(len(lines)-view.scroll, 0)
+		start := max(end-m.logLines(), 0)
+		for _, line := range lines[start:end] {
+			b.WriteString(styles.Item.Render(line) + "\n")
+		}
+	}
+
+	b.WriteString("\n" + styles.Help.Render("+/-: lines • l: level • ↑/↓/pgup/pgdown: scroll • r: reload • esc: back • q: quit"))
+
+	return b.String()
+}
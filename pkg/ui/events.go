@@ -0,0 +1,275 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ddalab/launcher/pkg/api"
+	"github.com/ddalab/launcher/pkg/theme"
+)
+
+// EventStream sends backend events to out until ctx is done or it fails
+type EventStream func(ctx context.Context, out chan<- api.Event) error
+
+// maxEvents is how many events the events view keeps
+const maxEvents = 1000
+
+// eventMsg carries an event from the stream
+type eventMsg api.Event
+
+// eventStreamEndedMsg reports that the stream stopped
+type eventStreamEndedMsg struct{ err error }
+
+// EventsModel shows backend events live as they arrive
+type EventsModel struct {
+	events <-chan api.Event
+	ended  <-chan error
+
+	received  []api.Event
+	filter    string
+	filtering bool // Typing the filter
+	scroll    int  // Lines scrolled up from the newest
+	height    int
+	streamErr error
+	streamEnd bool
+}
+
+// NewEventsModel creates a view of the events arriving on events. ended
+// delivers the stream's result once it stops.
+func NewEventsModel(events <-chan api.Event, ended <-chan error) *EventsModel {
+	return &EventsModel{events: events, ended: ended}
+}
+
+// waitForEvent returns a command that delivers the next event, or the end
+// of the stream
+func (m *EventsModel) waitForEvent() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case event := <-m.events:
+			return eventMsg(event)
+		case err := <-m.ended:
+			return eventStreamEndedMsg{err}
+		}
+	}
+}
+
+func (m *EventsModel) Init() tea.Cmd {
+	return m.waitForEvent()
+}
+
+func (m *EventsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case eventMsg:
+		m.received = append(m.received, api.Event(msg))
+		if len(m.received) > maxEvents {
+			m.received = m.received[len(m.received)-maxEvents:]
+		}
+		if m.scroll > 0 && m.matches(api.Event(msg)) {
+			m.scroll++ // Keep the lines in view while scrolled up
+		}
+		return m, m.waitForEvent()
+
+	case eventStreamEndedMsg:
+		m.streamEnd, m.streamErr = true, msg.err
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.filtering {
+			return m.updateFilter(msg)
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "/":
+			m.filtering = true
+		case "up", "k":
+			m.scroll++
+		case "down", "j":
+			m.scroll = max(m.scroll-1, 0)
+		case "pgup":
+			m.scroll += m.visibleLines()
+		case "pgdown":
+			m.scroll = max(m.scroll-m.visibleLines(), 0)
+		case "end":
+			m.scroll = 0
+		}
+		m.scroll = min(m.scroll, max(len(m.filtered())-m.visibleLines(), 0))
+	}
+
+	return m, nil
+}
+
+// updateFilter handles keys while the filter is typed
+func (m *EventsModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEnter:
+		m.filtering = false
+	case tea.KeyEsc:
+		m.filtering = false
+		m.filter = ""
+	case tea.KeyCtrlU:
+		m.filter = ""
+	case tea.KeyBackspace:
+		if m.filter != "" {
+			runes := []rune(m.filter)
+			m.filter = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.filter += string(msg.Runes)
+	}
+	m.scroll = 0
+	return m, nil
+}
+
+// matches reports whether event passes the filter, which is matched
+// case-insensitively against its type, actor and message
+func (m *EventsModel) matches(event api.Event) bool {
+	if m.filter == "" {
+		return true
+	}
+	filter := strings.ToLower(m.filter)
+	for _, field := range []string{event.Type, event.Actor, event.Message} {
+		if strings.Contains(strings.ToLower(field), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// filtered returns the received events that pass the filter
+func (m *EventsModel) filtered() []api.Event {
+	if m.filter == "" {
+		return m.received
+	}
+	var events []api.Event
+	for _, event := range m.received {
+		if m.matches(event) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// visibleLines is how many events fit below the header and above the help
+func (m *EventsModel) visibleLines() int {
+	const chrome = 8 // Title with padding, filter line, blank lines and help
+	if m.height <= chrome {
+		return 20
+	}
+	return m.height - chrome
+}
+
+func (m *EventsModel) View() string {
+	styles := theme.Styles()
+
+	var b strings.Builder
+
+	b.WriteString(styles.Title.Render(withIcon(theme.Events, "DDALAB Events")) + "\n")
+
+	events := m.filtered()
+	switch {
+	case m.filtering:
+		b.WriteString(styles.Header.Render(fmt.Sprintf("Filter: %s█", m.filter)) + "\n\n")
+	case m.filter != "":
+		b.WriteString(styles.Header.Render(fmt.Sprintf("Filter: '%s' (%d/%d events)", m.filter, len(events), len(m.received))) + "\n\n")
+	default:
+		b.WriteString(styles.Header.Render(fmt.Sprintf("%d events", len(m.received))) + "\n\n")
+	}
+
+	if len(events) == 0 {
+		b.WriteString(styles.Help.Render("Waiting for events...") + "\n")
+	} else {
+		end := max(len(events)-m.scroll, 0)
+		start := max(end-m.visibleLines(), 0)
+		for _, event := range events[start:end] {
+			b.WriteString(FormatEvent(event, eventStyle(event.Type)) + "\n")
+		}
+	}
+
+	if m.streamEnd {
+		message := "The event stream ended"
+		if m.streamErr != nil {
+			message = fmt.Sprintf("The event stream ended: %v", m.streamErr)
+		}
+		b.WriteString("\n" + styles.Error.Render(message))
+	}
+
+	help := "/: filter • ↑/↓/pgup/pgdown: scroll • q: quit"
+	if m.filtering {
+		help = "Type to filter • Enter: apply • Esc: clear • Ctrl+U: clear"
+	}
+	b.WriteString("\n" + styles.Help.Render(help))
+
+	return b.String()
+}
+
+// FormatEvent renders an event as one line with its type in style
+func FormatEvent(event api.Event, style lipgloss.Style) string {
+	line := fmt.Sprintf("%s %s", event.Time.Local().Format("15:04:05"), style.Render(event.Type))
+	if event.Actor != "" {
+		line += " [" + event.Actor + "]"
+	}
+	if event.Message != "" {
+		line += " " + event.Message
+	}
+	return line
+}
+
+// eventStyle colors an event by its type: failures red, warnings yellow,
+// completions green and user activity as headers
+func eventStyle(eventType string) lipgloss.Style {
+	styles := theme.Styles()
+	lower := strings.ToLower(eventType)
+
+	switch {
+	case containsAny(lower, "fail", "error", "denied"):
+		return styles.Unhealthy
+	case containsAny(lower, "warn"):
+		return styles.Warning
+	case containsAny(lower, "complete", "success", "finish", "created"):
+		return styles.Healthy
+	case containsAny(lower, "login", "logout", "user", "auth"):
+		return styles.Header.UnsetPadding()
+	default:
+		return styles.Item.UnsetPadding()
+	}
+}
+
+// containsAny reports whether s contains any of the substrings
+func containsAny(s string, substrings ...string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+// RunEvents shows the events of stream live until the user presses q
+func RunEvents(stream EventStream) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan api.Event, 64)
+	ended := make(chan error, 1)
+	go func() {
+		ended <- stream(ctx, events)
+	}()
+
+	_, ok, err := runProgram(NewEventsModel(events, ended), tea.WithAltScreen())
+	if !ok {
+		return fmt.Errorf("the events view needs the full-screen terminal UI")
+	}
+	return err
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ddalab/launcher/pkg/theme"
+)
+
+// markdownWidth is the width release notes are wrapped at
+const markdownWidth = 80
+
+var (
+	markdownLink   = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+	markdownBold   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	markdownCode   = regexp.MustCompile("`([^`]+)`")
+	markdownBullet = regexp.MustCompile(`^(\s*)[-*+]\s+`)
+)
+
+// RenderMarkdown renders the subset of markdown used in release notes -
+// headings, bullet lists, bold, inline code, links and code blocks - for
+// the terminal
+func RenderMarkdown(text string) string {
+	styles := theme.Styles()
+	heading := styles.Header.UnsetPadding()
+	code := styles.Pending
+	block := styles.Help.UnsetItalic()
+
+	var lines []string
+	inCodeBlock := false
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+		if inCodeBlock {
+			lines = append(lines, block.Render("    "+line))
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			title := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+			if len(lines) > 0 && lines[len(lines)-1] != "" {
+				lines = append(lines, "")
+			}
+			lines = append(lines, heading.Render(renderInline(title, code)))
+		case markdownBullet.MatchString(line):
+			indent := markdownBullet.FindStringSubmatch(line)[1]
+			item := renderInline(markdownBullet.ReplaceAllString(line, ""), code)
+			prefix := indent + "  • "
+			wrapped := wrapText(item, markdownWidth-len(prefix))
+			lines = append(lines, prefix+strings.ReplaceAll(wrapped, "\n", "\n"+strings.Repeat(" ", len(prefix))))
+		case trimmed == "":
+			lines = append(lines, "")
+		default:
+			lines = append(lines, wrapText(renderInline(trimmed, code), markdownWidth))
+		}
+	}
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// wrapText wraps text at width without padding the lines to it
+func wrapText(text string, width int) string {
+	wrapped := strings.Split(lipgloss.NewStyle().Width(width).Render(text), "\n")
+	for i, line := range wrapped {
+		wrapped[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(wrapped, "\n")
+}
+
+// renderInline renders links, bold text and inline code within a line
+func renderInline(text string, code lipgloss.Style) string {
+	text = markdownLink.ReplaceAllString(text, "$1 ($2)")
+	text = markdownCode.ReplaceAllStringFunc(text, func(match string) string {
+		return code.Render(strings.Trim(match, "`"))
+	})
+	return markdownBold.ReplaceAllStringFunc(text, func(match string) string {
+		return lipgloss.NewStyle().Bold(true).Render(match[2 : len(match)-2])
+	})
+}
@@ -2,6 +2,9 @@ package ui
 
 import (
 	"fmt"
+
+	"github.com/ddalab/launcher/pkg/i18n"
+	"github.com/ddalab/launcher/pkg/theme"
 )
 
 // MenuOption represents a menu choice with associated data
@@ -9,7 +12,23 @@ type MenuOption struct {
 	Label       string
 	Description string
 	Action      string
-	Icon        string
+	Icon        theme.Icon
+}
+
+// menuOption returns the translated menu entry for action
+func menuOption(action string, icon theme.Icon) MenuOption {
+	return MenuOption{
+		Label:       i18n.T("menu." + action),
+		Description: i18n.T("menu." + action + ".description"),
+		Action:      action,
+		Icon:        icon,
+	}
+}
+
+// shortMenuOption returns the translated menu entry for action without a
+// description
+func shortMenuOption(action string, icon theme.Icon) MenuOption {
+	return MenuOption{Label: i18n.T("menu." + action), Action: action, Icon: icon}
 }
 
 // MenuManager handles menu navigation and display
@@ -24,20 +43,11 @@ func NewMenuManager(ui *UI) *MenuManager {
 
 // ShowMenu displays a menu with the given options and returns the selected action
 func (m *MenuManager) ShowMenu(title string, options []MenuOption) (string, error) {
-	items := make([]string, len(options))
-	for i, option := range options {
-		if option.Icon != "" {
-			items[i] = fmt.Sprintf("%s %s", option.Icon, option.Label)
-		} else {
-			items[i] = option.Label
-		}
-
-		if option.Description != "" {
-			items[i] += fmt.Sprintf(" - %s", option.Description)
-		}
-	}
+	items, details := menuItems(options)
 
-	selectedItem, err := RunMenu(title, items)
+	model := NewMenuModel(title, items)
+	model.SetDetails(details)
+	selectedItem, err := RunMenuModel(model)
 	if err != nil {
 		return "", err
 	}
@@ -52,22 +62,37 @@ func (m *MenuManager) ShowMenu(title string, options []MenuOption) (string, erro
 	return "", fmt.Errorf("action not found for selected item")
 }
 
+// menuItems returns the menu lines and descriptions of the options
+func menuItems(options []MenuOption) (items, details []string) {
+	items = make([]string, len(options))
+	details = make([]string, len(options))
+	for i, option := range options {
+		items[i] = withIcon(option.Icon, option.Label)
+		details[i] = option.Description
+	}
+	return items, details
+}
+
 // ShowMenuWithStatus displays a menu with live status updates
 func (m *MenuManager) ShowMenuWithStatus(title string, options []MenuOption, statusMonitor interface{ FormatStatus() string }) (string, error) {
-	items := make([]string, len(options))
-	for i, option := range options {
-		if option.Icon != "" {
-			items[i] = fmt.Sprintf("%s %s", option.Icon, option.Label)
-		} else {
-			items[i] = option.Label
-		}
+	return m.ShowMenuFrom(title, options, statusMonitor, "")
+}
 
-		if option.Description != "" {
-			items[i] += fmt.Sprintf(" - %s", option.Description)
+// ShowMenuFrom displays a menu with the cursor on the option for the given
+// action, e.g. the one chosen last time. A nil statusMonitor shows no live
+// status.
+func (m *MenuManager) ShowMenuFrom(title string, options []MenuOption, statusMonitor interface{ FormatStatus() string }, action string) (string, error) {
+	items, details := menuItems(options)
+
+	model := NewMenuModelWithStatus(title, items, statusMonitor)
+	model.SetDetails(details)
+	for i, option := range options {
+		if option.Action == action {
+			model.SetCursor(i)
 		}
 	}
 
-	selectedItem, err := RunMenuWithStatus(title, items, statusMonitor)
+	selectedItem, err := RunMenuModel(model)
 	if err != nil {
 		return "", err
 	}
@@ -85,51 +110,76 @@ func (m *MenuManager) ShowMenuWithStatus(title string, options []MenuOption, sta
 // GetMainMenuOptions returns the standard main menu options
 func (m *MenuManager) GetMainMenuOptions() []MenuOption {
 	return []MenuOption{
-		{Label: "Start DDALAB", Action: "start", Icon: "🚀", Description: "Start all DDALAB services"},
-		{Label: "Stop DDALAB", Action: "stop", Icon: "🛑", Description: "Stop all DDALAB services"},
-		{Label: "Restart DDALAB", Action: "restart", Icon: "🔄", Description: "Restart all DDALAB services"},
-		{Label: "Check Status", Action: "status", Icon: "📊", Description: "Check service status and health"},
-		{Label: "View Logs", Action: "logs", Icon: "📋", Description: "View recent service logs"},
-		{Label: "Bootstrap DDALAB", Action: "bootstrap", Icon: "🔧", Description: "Bootstrap DDALAB services when API is unavailable"},
-		{Label: "Edit Configuration", Action: "edit-config", Icon: "📝", Description: "Edit environment variables and settings"},
-		{Label: "Configure Installation", Action: "configure", Icon: "⚙️", Description: "Change DDALAB installation path"},
-		{Label: "Backup Database", Action: "backup", Icon: "💾", Description: "Create database backup"},
-		{Label: "Update DDALAB", Action: "update", Icon: "⬆️", Description: "Update to latest version"},
-		{Label: "Check for Launcher Updates", Action: "check-updates", Icon: "🔄", Description: "Check for launcher updates"},
-		{Label: "Uninstall DDALAB", Action: "uninstall", Icon: "🗑️", Description: "Remove DDALAB completely"},
-		{Label: "Exit", Action: "exit", Icon: "👋", Description: "Exit the launcher"},
+		menuOption("start", theme.Start),
+		menuOption("stop", theme.Stop),
+		menuOption("deep-stop", theme.Stop),
+		menuOption("restart", theme.Restart),
+		menuOption("restart-unhealthy", theme.Diagnostics),
+		menuOption("status", theme.Status),
+		menuOption("dashboard", theme.Dashboard),
+		menuOption("logs", theme.Logs),
+		menuOption("events", theme.Events),
+		menuOption("bootstrap", theme.Bootstrap),
+		menuOption("edit-config", theme.EditConfig),
+		menuOption("apply-env", theme.Restart),
+		menuOption("restore-env", theme.RestoreEnv),
+		menuOption("configure", theme.Configure),
+		menuOption("environment", theme.Environment),
+		menuOption("open-folder", theme.Folder),
+		menuOption("compose-command", theme.Compose),
+		menuOption("regenerate-certs", theme.Certificate),
+		menuOption("backup", theme.Backup),
+		menuOption("update", theme.Update),
+		menuOption("check-ddalab-updates", theme.CheckStackUpdates),
+		menuOption("check-updates", theme.CheckUpdates),
+		menuOption("update-channel", theme.CheckUpdates),
+		menuOption("diagnostics", theme.Diagnostics),
+		menuOption("telemetry", theme.Telemetry),
+		menuOption("reload-settings", theme.Configure),
+		menuOption("uninstall", theme.Uninstall),
+		menuOption("exit", theme.Exit),
 	}
 }
 
 // GetMainMenuOptionsWithBootstrapContext returns menu options adapted for bootstrap context
 func (m *MenuManager) GetMainMenuOptionsWithBootstrapContext(canBootstrap bool, isAPIMode bool) []MenuOption {
 	options := []MenuOption{
-		{Label: "Start DDALAB", Action: "start", Icon: "🚀", Description: "Start all DDALAB services"},
-		{Label: "Stop DDALAB", Action: "stop", Icon: "🛑", Description: "Stop all DDALAB services"},
-		{Label: "Restart DDALAB", Action: "restart", Icon: "🔄", Description: "Restart all DDALAB services"},
-		{Label: "Check Status", Action: "status", Icon: "📊", Description: "Check service status and health"},
-		{Label: "View Logs", Action: "logs", Icon: "📋", Description: "View recent service logs"},
+		menuOption("start", theme.Start),
+		menuOption("stop", theme.Stop),
+		menuOption("deep-stop", theme.Stop),
+		menuOption("restart", theme.Restart),
+		menuOption("restart-unhealthy", theme.Diagnostics),
+		menuOption("status", theme.Status),
+		menuOption("dashboard", theme.Dashboard),
+		menuOption("logs", theme.Logs),
+		menuOption("events", theme.Events),
 	}
 
 	// Add bootstrap option only if not in API mode and bootstrap is available
 	if !isAPIMode && canBootstrap {
-		options = append(options, MenuOption{
-			Label:       "Bootstrap DDALAB",
-			Action:      "bootstrap",
-			Icon:        "🔧",
-			Description: "Bootstrap DDALAB services (minimal setup)",
-		})
+		options = append(options, menuOption("bootstrap", theme.Bootstrap))
 	}
 
 	// Add common options
 	options = append(options, []MenuOption{
-		{Label: "Edit Configuration", Action: "edit-config", Icon: "📝", Description: "Edit environment variables and settings"},
-		{Label: "Configure Installation", Action: "configure", Icon: "⚙️", Description: "Change DDALAB installation path"},
-		{Label: "Backup Database", Action: "backup", Icon: "💾", Description: "Create database backup"},
-		{Label: "Update DDALAB", Action: "update", Icon: "⬆️", Description: "Update to latest version"},
-		{Label: "Check for Launcher Updates", Action: "check-updates", Icon: "🔄", Description: "Check for launcher updates"},
-		{Label: "Uninstall DDALAB", Action: "uninstall", Icon: "🗑️", Description: "Remove DDALAB completely"},
-		{Label: "Exit", Action: "exit", Icon: "👋", Description: "Exit the launcher"},
+		menuOption("edit-config", theme.EditConfig),
+		menuOption("apply-env", theme.Restart),
+		menuOption("restore-env", theme.RestoreEnv),
+		menuOption("configure", theme.Configure),
+		menuOption("environment", theme.Environment),
+		menuOption("open-folder", theme.Folder),
+		menuOption("compose-command", theme.Compose),
+		menuOption("regenerate-certs", theme.Certificate),
+		menuOption("backup", theme.Backup),
+		menuOption("update", theme.Update),
+		menuOption("check-ddalab-updates", theme.CheckStackUpdates),
+		menuOption("check-updates", theme.CheckUpdates),
+		menuOption("update-channel", theme.CheckUpdates),
+		menuOption("diagnostics", theme.Diagnostics),
+		menuOption("telemetry", theme.Telemetry),
+		menuOption("reload-settings", theme.Configure),
+		menuOption("uninstall", theme.Uninstall),
+		menuOption("exit", theme.Exit),
 	}...)
 
 	return options
@@ -138,23 +188,25 @@ func (m *MenuManager) GetMainMenuOptionsWithBootstrapContext(canBootstrap bool,
 // GetManagementMenuOptions returns management-specific menu options
 func (m *MenuManager) GetManagementMenuOptions() []MenuOption {
 	return []MenuOption{
-		{Label: "Configure Installation", Action: "configure", Icon: "⚙️"},
-		{Label: "Backup Database", Action: "backup", Icon: "💾"},
-		{Label: "Update DDALAB", Action: "update", Icon: "⬆️"},
-		{Label: "Uninstall DDALAB", Action: "uninstall", Icon: "🗑️"},
-		{Label: "Back to Main Menu", Action: "back", Icon: "⬅️"},
+		shortMenuOption("configure", theme.Configure),
+		shortMenuOption("backup", theme.Backup),
+		shortMenuOption("update", theme.Update),
+		shortMenuOption("uninstall", theme.Uninstall),
+		shortMenuOption("back", theme.Back),
 	}
 }
 
 // GetServiceMenuOptions returns service control menu options
 func (m *MenuManager) GetServiceMenuOptions() []MenuOption {
 	return []MenuOption{
-		{Label: "Start DDALAB", Action: "start", Icon: "🚀"},
-		{Label: "Stop DDALAB", Action: "stop", Icon: "🛑"},
-		{Label: "Restart DDALAB", Action: "restart", Icon: "🔄"},
-		{Label: "Check Status", Action: "status", Icon: "📊"},
-		{Label: "View Logs", Action: "logs", Icon: "📋"},
-		{Label: "Back to Main Menu", Action: "back", Icon: "⬅️"},
+		shortMenuOption("start", theme.Start),
+		shortMenuOption("stop", theme.Stop),
+		shortMenuOption("deep-stop", theme.Stop),
+		shortMenuOption("restart", theme.Restart),
+		shortMenuOption("restart-unhealthy", theme.Diagnostics),
+		shortMenuOption("status", theme.Status),
+		shortMenuOption("logs", theme.Logs),
+		shortMenuOption("back", theme.Back),
 	}
 }
 
@@ -168,6 +220,17 @@ func (m *MenuManager) ShowConfirmation(message string) bool {
 	return result
 }
 
+// ShowDangerConfirmation displays a confirmation for a destructive
+// operation that defaults to "No"
+func (m *MenuManager) ShowDangerConfirmation(message string) bool {
+	result, err := RunDangerConfirm(message)
+	if err != nil {
+		return false
+	}
+
+	return result
+}
+
 // ShowSubMenu displays a submenu and handles navigation
 func (m *MenuManager) ShowSubMenu(title string, options []MenuOption, handler func(string) error) error {
 	for {
@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ddalab/launcher/pkg/i18n"
+)
+
+// simple is set when plain numbered menus and line prompts replace the
+// bubbletea UI, because --simple-ui was given or the terminal could not
+// be set up for it
+var simple atomic.Bool
+
+// SetSimple switches to plain numbered menus and line prompts
+func SetSimple(enabled bool) {
+	simple.Store(enabled)
+}
+
+// IsSimple reports whether the plain UI is used instead of bubbletea
+func IsSimple() bool {
+	return simple.Load()
+}
+
+// runProgram runs model as a bubbletea program. ok is false if the plain
+// UI has to be used instead: because it is forced, or because the
+// terminal could not be set up, which switches to the plain UI for the rest
+// of the session.
+func runProgram(model tea.Model, opts ...tea.ProgramOption) (final tea.Model, ok bool, err error) {
+	if IsSimple() {
+		return nil, false, nil
+	}
+
+	final, err = tea.NewProgram(model, opts...).Run()
+	if err != nil && !errors.Is(err, tea.ErrInterrupted) && !errors.Is(err, tea.ErrProgramKilled) && !errors.Is(err, tea.ErrProgramPanic) {
+		log.Printf("Warning: the terminal UI could not start, using plain prompts instead: %v", err)
+		SetSimple(true)
+		return nil, false, nil
+	}
+	return final, true, err
+}
+
+var (
+	linesOnce sync.Once
+	lines     chan string // Lines read from stdin, closed at the end of input
+)
+
+// inputLines returns the lines read from stdin. A single reader feeds all
+// prompts, so a countdown can stop waiting for input without losing the
+// next line.
+func inputLines() <-chan string {
+	linesOnce.Do(func() {
+		lines = make(chan string)
+		go func() {
+			scanner := bufio.NewScanner(os.Stdin)
+			for scanner.Scan() {
+				lines <- scanner.Text()
+			}
+			close(lines)
+		}()
+	})
+	return lines
+}
+
+// readLine returns the next line of input without surrounding spaces
+func readLine() (string, error) {
+	line, ok := <-inputLines()
+	if !ok {
+		return "", io.EOF
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// simpleMenu shows a numbered menu; Enter alone picks the item under the
+// cursor
+func simpleMenu(m *MenuModel) (string, error) {
+	if m.title != "" {
+		fmt.Println("\n" + m.title)
+	}
+	if m.statusMonitor != nil {
+		fmt.Println(i18n.T("ui.status", m.statusMonitor.FormatStatus()))
+	}
+	fmt.Println()
+
+	for i, item := range m.items {
+		line := fmt.Sprintf("%3d) %s", i+1, item)
+		if i < len(m.details) && m.details[i] != "" {
+			line += " - " + m.details[i]
+		}
+		fmt.Println(line)
+	}
+
+	for {
+		fmt.Print(i18n.T("ui.simple.choose", len(m.items), m.cursor+1))
+		answer, err := readLine()
+		if err != nil || answer == "q" {
+			return "", fmt.Errorf("cancelled")
+		}
+		if answer == "" {
+			return m.items[m.cursor], nil
+		}
+		if index, err := strconv.Atoi(answer); err == nil && index >= 1 && index <= len(m.items) {
+			return m.items[index-1], nil
+		}
+		fmt.Println(i18n.T("ui.simple.invalid", len(m.items)))
+	}
+}
+
+// simplePrompt reads a line until it passes validation
+func simplePrompt(m *PromptModel) (string, error) {
+	if m.title != "" {
+		fmt.Println(m.title)
+	}
+
+	for {
+		if m.placeholder != "" {
+			fmt.Printf("(%s) ", m.placeholder)
+		}
+		fmt.Print("> ")
+		value, err := readLine()
+		if err != nil {
+			return "", fmt.Errorf("cancelled")
+		}
+		if m.validate != nil {
+			if err := m.validate(value); err != nil {
+				fmt.Println(i18n.T("ui.error", err.Error()))
+				continue
+			}
+		}
+		return value, nil
+	}
+}
+
+// simpleConfirm asks a yes/no question. A destructive operation has to be
+// confirmed by typing the word for yes in full.
+func simpleConfirm(m *ConfirmModel) bool {
+	if m.danger {
+		fmt.Print(i18n.T("ui.simple.danger", m.message, strings.ToLower(i18n.T("ui.yes"))))
+		answer, err := readLine()
+		return err == nil && strings.EqualFold(answer, i18n.T("ui.yes"))
+	}
+
+	fmt.Print(i18n.T("ui.simple.confirm", m.message))
+	answer, err := readLine()
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(answer)
+	return answer == "" || strings.HasPrefix(answer, "y") || strings.HasPrefix(answer, "j")
+}
+
+// simpleCountdown proceeds after the countdown unless Enter is pressed
+func simpleCountdown(m *CountdownModel) bool {
+	if m.remaining <= 0 {
+		return true
+	}
+
+	fmt.Println(i18n.T("ui.simple.countdown", m.action, m.remaining))
+	select {
+	case <-inputLines():
+		return false
+	case <-time.After(time.Duration(m.remaining) * time.Second):
+		return true
+	}
+}
+
+// simpleWait waits for Enter
+func simpleWait(m *WaitModel) {
+	fmt.Print(m.message + " ")
+	_, _ = readLine()
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ddalab/launcher/pkg/progress"
+	"github.com/ddalab/launcher/pkg/theme"
+)
+
+// spinnerFrames are the animation frames of the operation spinner
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerInterval is how often the spinner redraws
+const spinnerInterval = 100 * time.Millisecond
+
+type spinnerTickMsg time.Time
+
+// spinnerTrackerMsg attaches a progress tracker to the spinner
+type spinnerTrackerMsg struct{ tracker *progress.Tracker }
+
+// spinnerDoneMsg ends the spinner and selects its final line
+type spinnerDoneMsg struct{ failed bool }
+
+// spinnerModel animates a running operation with its elapsed time
+type spinnerModel struct {
+	operation string
+	start     time.Time
+	frame     int
+	tracker   *progress.Tracker // Optional detailed progress (bytes, rate, ETA)
+	finished  bool
+	failed    bool
+}
+
+func spinnerTick() tea.Cmd {
+	return tea.Tick(spinnerInterval, func(t time.Time) tea.Msg {
+		return spinnerTickMsg(t)
+	})
+}
+
+func (m *spinnerModel) Init() tea.Cmd {
+	return spinnerTick()
+}
+
+func (m *spinnerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case spinnerTickMsg:
+		m.frame = (m.frame + 1) % len(spinnerFrames)
+		return m, spinnerTick()
+	case spinnerTrackerMsg:
+		m.tracker = msg.tracker
+		return m, nil
+	case spinnerDoneMsg:
+		m.finished = true
+		m.failed = msg.failed
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m *spinnerModel) View() string {
+	styles := theme.Styles()
+
+	elapsed := progress.FormatDuration(time.Since(m.start))
+	if m.finished {
+		if m.failed {
+			return styles.Error.Render(fmt.Sprintf("%s %s did not complete (%s)", theme.Failed, m.operation, elapsed)) + "\n"
+		}
+		return styles.SpinnerDone.Render(fmt.Sprintf("%s %s finished in %s", theme.Done, m.operation, elapsed)) + "\n"
+	}
+
+	detail := elapsed
+	if m.tracker != nil {
+		detail = m.tracker.String()
+	}
+	return styles.Spinner.Render(fmt.Sprintf("%s %s… %s", spinnerFrames[m.frame], m.operation, detail)) +
+		styles.Help.Render("  (Ctrl+C to cancel)")
+}
+
+// Spinner shows an animated progress line below the operation's output
+type Spinner struct {
+	program *tea.Program
+	done    chan struct{}
+}
+
+// newSpinner starts a spinner for operation. It reads no input and installs
+// no signal handlers, so Ctrl+C still reaches the interrupt handler.
+func newSpinner(operation string) *Spinner {
+	s := &Spinner{
+		program: tea.NewProgram(&spinnerModel{operation: operation, start: time.Now()},
+			tea.WithInput(nil), tea.WithoutSignalHandler()),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(s.done)
+		_, _ = s.program.Run()
+	}()
+
+	return s
+}
+
+// println prints a line above the spinner. Program.Println blocks forever
+// once the program has exited, so the line is then printed directly, and
+// dropped if the program exits before taking it.
+func (s *Spinner) println(line string) {
+	select {
+	case <-s.done:
+		fmt.Println(line)
+		return
+	default:
+	}
+
+	printed := make(chan struct{})
+	go func() {
+		s.program.Println(line)
+		close(printed)
+	}()
+	select {
+	case <-printed:
+	case <-s.done:
+	}
+}
+
+// track shows the tracker's progress instead of the plain elapsed time
+func (s *Spinner) track(tracker *progress.Tracker) {
+	s.program.Send(spinnerTrackerMsg{tracker: tracker})
+}
+
+// pause hands the terminal back, e.g. for an interactive prompt
+func (s *Spinner) pause() {
+	_ = s.program.ReleaseTerminal()
+}
+
+// resume takes the terminal over again after pause
+func (s *Spinner) resume() {
+	_ = s.program.RestoreTerminal()
+}
+
+// stop replaces the spinner with a final success or failure line
+func (s *Spinner) stop(failed bool) {
+	s.program.Send(spinnerDoneMsg{failed: failed})
+	<-s.done
+}
@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSpinnerPrintlnAfterStopDoesNotBlock(t *testing.T) {
+	s := newSpinner("Testing")
+	s.println("while running")
+	s.stop(false)
+
+	printed := make(chan struct{})
+	go func() {
+		s.println("after the spinner stopped")
+		close(printed)
+	}()
+
+	select {
+	case <-printed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("println blocked after the spinner program exited")
+	}
+}
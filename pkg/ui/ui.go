@@ -1,18 +1,32 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/ddalab/launcher/pkg/config"
 	"github.com/ddalab/launcher/pkg/detector"
+	"github.com/ddalab/launcher/pkg/i18n"
+	"github.com/ddalab/launcher/pkg/progress"
+	"github.com/ddalab/launcher/pkg/theme"
 )
 
 // UI handles user interaction through prompts
 type UI struct {
 	configManager *config.ConfigManager
 	detector      *detector.Detector
+	updateNotice  string // Persistent banner shown above the main menu
+
+	assumeYes bool // Confirm all operations without prompting (--yes)
+
+	spinnerMu       sync.Mutex
+	spinner         *Spinner // Active operation spinner, if any
+	spinnerDisabled bool     // Set when output is not a terminal
 }
 
 // NewUI creates a new UI instance
@@ -25,7 +39,7 @@ func NewUI(configManager *config.ConfigManager, detector *detector.Detector) *UI
 
 // ShowWelcome displays the welcome message for first-time users
 func (ui *UI) ShowWelcome() {
-	fmt.Println("🚀 Welcome to DDALAB Launcher!")
+	fmt.Println(withIcon(theme.Start, "Welcome to DDALAB Launcher!"))
 	fmt.Println("This tool will help you manage your DDALAB installation easily.")
 	fmt.Println("")
 }
@@ -39,46 +53,78 @@ func (ui *UI) ShowMainMenu() (string, error) {
 func (ui *UI) ShowMainMenuWithStatus(statusMonitor any) (string, error) {
 	config := ui.configManager.GetConfig()
 
-	fmt.Printf("\n🚀 DDALAB Launcher %s\n", config.Version)
+	fmt.Println("\n" + withIcon(theme.Start, "DDALAB Launcher "+config.Version))
 	if config.DDALABPath != "" {
-		fmt.Printf("📂 Installation: %s\n", config.DDALABPath)
+		installation := "Installation: " + config.DDALABPath
+		if _, err := os.Stat(config.DDALABPath); err != nil {
+			installation += " (not found)"
+		}
+		fmt.Println(withIcon(theme.Folder, installation))
+	}
+	if environment := ui.configManager.GetEnvironment(); environment != "" {
+		fmt.Println(withIcon(theme.Environment, i18n.T("ui.environment", environment)))
+	}
+	if role := ui.configManager.GetRole(); !role.Allows("uninstall") {
+		fmt.Println(withIcon(theme.Role, i18n.T("ui.role", role)))
+	}
+	if last := ui.lastOperation(); last != "" {
+		fmt.Println(last)
+	}
+	if ui.updateNotice != "" {
+		fmt.Println(theme.Styles().Banner.Render(withIcon(theme.Package, ui.updateNotice)))
 	}
 
 	menuManager := NewMenuManager(ui)
 	options := menuManager.GetMainMenuOptions()
+	if ui.configManager.IsOffline() {
+		options = withoutAction(options, "check-updates")
+	}
+	options = allowedActions(options, ui.configManager.GetRole())
 
 	// Use status-aware menu if monitor is provided
-	var action string
-	var err error
+	var monitor interface{ FormatStatus() string }
 	if statusMonitor != nil {
-		if monitor, ok := statusMonitor.(interface{ FormatStatus() string }); ok {
-			action, err = menuManager.ShowMenuWithStatus("What would you like to do?", options, monitor)
-		} else {
-			action, err = menuManager.ShowMenu("What would you like to do?", options)
-		}
-	} else {
-		action, err = menuManager.ShowMenu("What would you like to do?", options)
+		monitor, _ = statusMonitor.(interface{ FormatStatus() string })
 	}
+
+	// Start on the last chosen action so the user need not scroll back
+	action, err := menuManager.ShowMenuFrom(i18n.T("ui.menu.title"), options, monitor, ui.configManager.GetLastMenuAction())
 	if err != nil {
 		return "", err
 	}
+	ui.rememberMenuAction(action)
 
 	// Map actions back to original string format for compatibility
 	actionMap := map[string]string{
-		"start":         "Start DDALAB",
-		"stop":          "Stop DDALAB",
-		"restart":       "Restart DDALAB",
-		"status":        "Check Status",
-		"logs":          "View Logs",
-		"bootstrap":     "Bootstrap DDALAB",
-		"edit-config":   "Edit Configuration",
-		"configure":     "Configure Installation",
-		"backup":        "Backup Database",
-		"update":        "Update DDALAB",
-		"check-updates": "Check for Launcher Updates",
-		"open-gui":      "Open GUI (Experimental)",
-		"uninstall":     "Uninstall DDALAB",
-		"exit":          "Exit",
+		"start":                "Start DDALAB",
+		"stop":                 "Stop DDALAB",
+		"deep-stop":            "Deep Stop (remove orphans)",
+		"restart":              "Restart DDALAB",
+		"restart-unhealthy":    "Restart Unhealthy Services",
+		"status":               "Check Status",
+		"dashboard":            "Live Dashboard",
+		"logs":                 "View Logs",
+		"events":               "Events",
+		"bootstrap":            "Bootstrap DDALAB",
+		"edit-config":          "Edit Configuration",
+		"apply-env":            "Apply .env and Restart",
+		"restore-env":          "Restore Previous .env",
+		"configure":            "Configure Installation",
+		"environment":          "Select Environment",
+		"open-folder":          "Open Installation Folder",
+		"compose-command":      "Show Compose Command",
+		"regenerate-certs":     "Regenerate Certificates",
+		"backup":               "Backup Database",
+		"update":               "Update DDALAB",
+		"check-updates":        "Check for Launcher Updates",
+		"check-ddalab-updates": "Check for DDALAB Updates",
+		"update-channel":       "Switch Update Channel",
+		"diagnostics":          "Export Diagnostics",
+		"telemetry":            "Telemetry Settings",
+		"reload-settings":      "Reload Settings",
+		"open-gui":             "Open GUI (Experimental)",
+		"uninstall":            "Uninstall DDALAB",
+		"exit":                 "Exit",
 	}
 
 	if result, exists := actionMap[action]; exists {
@@ -88,10 +134,74 @@ func (ui *UI) ShowMainMenuWithStatus(statusMonitor any) (string, error) {
 	return action, nil
 }
 
-// SelectInstallation prompts user to select or configure an installation
-func (ui *UI) SelectInstallation() (string, error) {
+// rememberMenuAction stores the chosen main menu action for the next
+// display and the next launch. Exit is not remembered, so a new session
+// does not start on it. Neither is reloading the settings, as saving would
+// overwrite the hand-edited config file about to be read.
+func (ui *UI) rememberMenuAction(action string) {
+	if action == "exit" || action == "reload-settings" || action == ui.configManager.GetLastMenuAction() {
+		return
+	}
+
+	ui.configManager.SetLastMenuAction(action)
+	_ = ui.configManager.Save()
+}
+
+// SetAssumeYes makes ConfirmOperation accept every operation without
+// prompting. This intentionally bypasses the uninstall double confirmation.
+func (ui *UI) SetAssumeYes(assumeYes bool) {
+	ui.assumeYes = assumeYes
+}
+
+// AssumesYes returns true if operations are confirmed without prompting
+func (ui *UI) AssumesYes() bool {
+	return ui.assumeYes
+}
+
+// SetSpinnerEnabled turns the operation spinner on or off
+func (ui *UI) SetSpinnerEnabled(enabled bool) {
+	ui.spinnerMu.Lock()
+	defer ui.spinnerMu.Unlock()
+	ui.spinnerDisabled = !enabled
+}
+
+// withoutAction returns options without the entry for action
+func withoutAction(options []MenuOption, action string) []MenuOption {
+	filtered := make([]MenuOption, 0, len(options))
+	for _, option := range options {
+		if option.Action != action {
+			filtered = append(filtered, option)
+		}
+	}
+	return filtered
+}
+
+// allowedActions returns the options the role may perform
+func allowedActions(options []MenuOption, role config.Role) []MenuOption {
+	filtered := make([]MenuOption, 0, len(options))
+	for _, option := range options {
+		if role.Allows(option.Action) {
+			filtered = append(filtered, option)
+		}
+	}
+	return filtered
+}
+
+// SetUpdateNotice sets a banner shown above the main menu until cleared
+func (ui *UI) SetUpdateNotice(notice string) {
+	ui.updateNotice = notice
+}
+
+// ClearUpdateNotice removes the update banner
+func (ui *UI) ClearUpdateNotice() {
+	ui.updateNotice = ""
+}
+
+// SelectInstallation prompts user to select or configure an installation.
+// Cancelling ctx aborts the search for existing installations.
+func (ui *UI) SelectInstallation(ctx context.Context) (string, error) {
 	// First, try to find existing installations
-	installations, err := ui.detector.FindInstallations()
+	installations, err := ui.detector.FindInstallations(ctx)
 	if err != nil {
 		return "", fmt.Errorf("error searching for installations: %w", err)
 	}
@@ -103,13 +213,13 @@ func (ui *UI) SelectInstallation() (string, error) {
 	// Show detected installations
 	var items []string
 	for _, install := range installations {
-		status := "✅ Valid"
+		status := withIcon(theme.Success, "Valid")
 		if !install.Valid {
-			status = "❌ Invalid"
+			status = withIcon(theme.Error, "Invalid")
 		}
 		items = append(items, fmt.Sprintf("%s (%s) - %s", install.Path, install.Version, status))
 	}
-	items = append(items, "➕ Configure new installation path")
+	items = append(items, withIcon(theme.Add, "Configure new installation path"))
 
 	selectedItem, err := RunMenu("Select DDALAB installation", items)
 	if err != nil {
@@ -136,9 +246,9 @@ func (ui *UI) SelectInstallation() (string, error) {
 
 	selectedInstall := installations[index]
 	if !selectedInstall.Valid {
-		fmt.Printf("⚠️  Warning: The selected installation appears to be invalid.\n")
+		fmt.Println(withIcon(theme.Warning, "Warning: The selected installation appears to be invalid."))
 		if !ui.confirmContinue("Do you want to continue anyway?") {
-			return ui.SelectInstallation()
+			return ui.SelectInstallation(ctx)
 		}
 	}
 
@@ -155,7 +265,7 @@ func (ui *UI) configureNewInstallation() (string, error) {
 		// Basic validation - check if path looks reasonable
 		info := ui.detector.DetectInstallation(input)
 		if !info.Valid {
-			return fmt.Errorf("invalid DDALAB installation at %s", input)
+			return fmt.Errorf("invalid DDALAB installation at %s: %s", input, info.Problem)
 		}
 
 		return nil
@@ -175,24 +285,236 @@ func (ui *UI) configureNewInstallation() (string, error) {
 	return result, nil
 }
 
+// PromptPath asks for a file path, offering defaultPath when the input is
+// left empty. A leading ~/ is expanded to the home directory.
+func (ui *UI) PromptPath(title, defaultPath string) (string, error) {
+	var result string
+	var err error
+	ui.withSpinnerPaused(func() {
+		result, err = RunPrompt(title, defaultPath, nil)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	result = strings.TrimSpace(result)
+	if result == "" {
+		return defaultPath, nil
+	}
+	if strings.HasPrefix(result, "~/") {
+		homeDir, _ := os.UserHomeDir()
+		result = filepath.Join(homeDir, result[2:])
+	}
+	return result, nil
+}
+
 // ConfirmOperation asks user to confirm a potentially destructive operation
 func (ui *UI) ConfirmOperation(operation string) bool {
-	menuManager := NewMenuManager(ui)
-	return menuManager.ShowConfirmation(fmt.Sprintf("Are you sure you want to %s?", operation))
+	if ui.assumeYes {
+		ui.ShowInfo(i18n.T("ui.confirmed_auto", operation))
+		return true
+	}
+
+	var confirmed bool
+	ui.withSpinnerPaused(func() {
+		menuManager := NewMenuManager(ui)
+		confirmed = menuManager.ShowConfirmation(i18n.T("ui.confirm", operation))
+	})
+	return confirmed
+}
+
+// ConfirmDangerousOperation asks the user to confirm an operation that
+// interrupts users or deletes data. The dialog defaults to "No" and has no
+// single-key shortcut.
+func (ui *UI) ConfirmDangerousOperation(operation string) bool {
+	if ui.assumeYes {
+		ui.ShowInfo(i18n.T("ui.confirmed_auto", operation))
+		return true
+	}
+
+	var confirmed bool
+	ui.withSpinnerPaused(func() {
+		menuManager := NewMenuManager(ui)
+		confirmed = menuManager.ShowDangerConfirmation(i18n.T("ui.confirm", operation))
+	})
+	return confirmed
+}
+
+// ConfirmWithCountdown announces an automatic operation and performs it
+// unless the user presses a key before the countdown runs out
+func (ui *UI) ConfirmWithCountdown(operation string, countdown time.Duration) bool {
+	if ui.assumeYes {
+		ui.ShowInfo(i18n.T("ui.confirmed_auto", operation))
+		return true
+	}
+
+	var proceed bool
+	ui.withSpinnerPaused(func() {
+		var err error
+		proceed, err = RunCountdown(operation, countdown)
+		if err != nil {
+			proceed = false
+		}
+	})
+	return proceed
+}
+
+// SelectEnvironment lets the user pick a deployment environment and
+// returns its name. The current selection is marked.
+func (ui *UI) SelectEnvironment(environments []config.Environment, current string) (string, error) {
+	items := make([]string, len(environments))
+	for i, environment := range environments {
+		marker := " "
+		if environment.Name == current {
+			marker = theme.Done.String()
+		}
+		items[i] = fmt.Sprintf("%s %s (%s)", marker, environment.Name, environment.Dir)
+	}
+
+	selected, err := RunMenu(withIcon(theme.Environment, i18n.T("ui.select_environment")), items)
+	if err != nil {
+		return "", err
+	}
+
+	for i, item := range items {
+		if item == selected {
+			return environments[i].Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid selection")
+}
+
+// SelectAPIEndpoint lets the user pick one of the probed API endpoints,
+// described by labels, or type another address. It returns the chosen
+// endpoint, or "" to keep current.
+func (ui *UI) SelectAPIEndpoint(endpoints, labels []string, current string) (string, error) {
+	other := i18n.T("ui.api_endpoint_other")
+	keep := i18n.T("ui.api_endpoint_keep", current)
+	items := append(append([]string(nil), labels...), other, keep)
+
+	var selected string
+	var err error
+	ui.withSpinnerPaused(func() {
+		selected, err = RunMenu(withIcon(theme.Configure, i18n.T("ui.select_api_endpoint")), items)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	switch selected {
+	case keep:
+		return "", nil
+	case other:
+		validate := func(input string) error {
+			_, err := config.NormalizeAPIEndpoint(input)
+			return err
+		}
+		return RunPrompt(i18n.T("ui.api_endpoint_prompt"), current, validate)
+	}
+
+	for i, label := range labels {
+		if label == selected {
+			return endpoints[i], nil
+		}
+	}
+	return "", fmt.Errorf("invalid selection")
+}
+
+// SelectEnvBackup lets the user pick one of the .env backups, listed
+// newest first with their timestamps
+func (ui *UI) SelectEnvBackup(backups []config.EnvBackup) (config.EnvBackup, error) {
+	items := make([]string, len(backups))
+	for i, backup := range backups {
+		items[i] = fmt.Sprintf("%s (%s)", backup.Time.Format("2006-01-02 15:04:05"), filepath.Base(backup.Path))
+	}
+
+	selected, err := RunMenu(withIcon(theme.RestoreEnv, i18n.T("ui.select_env_backup")), items)
+	if err != nil {
+		return config.EnvBackup{}, err
+	}
+
+	for i, item := range items {
+		if item == selected {
+			return backups[i], nil
+		}
+	}
+
+	return config.EnvBackup{}, fmt.Errorf("invalid selection")
+}
+
+// SelectComposeOperation lets the user pick the operation to show the
+// docker compose command for
+func (ui *UI) SelectComposeOperation(operations []string) (string, error) {
+	return RunMenu(withIcon(theme.Compose, i18n.T("ui.select_compose_operation")), operations)
+}
+
+// SelectMissingInstallationAction asks what to do about a configured
+// installation that cannot be found. It returns "retry", "reconfigure" or
+// "continue".
+func (ui *UI) SelectMissingInstallationAction() (string, error) {
+	options := []string{"Retry (after mounting the drive again)", "Choose another installation", "Continue without it"}
+	actions := []string{"retry", "reconfigure", "continue"}
+
+	selected, err := RunMenu(withIcon(theme.Folder, "The DDALAB installation was not found"), options)
+	if err != nil {
+		return "", err
+	}
+
+	for i, option := range options {
+		if option == selected {
+			return actions[i], nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid selection")
+}
+
+// SelectLogOptions lets the user pick a service and how many lines to tail.
+// An empty service means all services; a tail of 0 means all lines.
+func (ui *UI) SelectLogOptions(services []string) (string, int, error) {
+	const allServices = "All services"
+
+	service := ""
+	if len(services) > 0 {
+		items := append([]string{allServices}, services...)
+		selected, err := RunMenu(withIcon(theme.Logs, "Select service"), items)
+		if err != nil {
+			return "", 0, err
+		}
+		if selected != allServices {
+			service = selected
+		}
+	}
+
+	tailOptions := []string{"Last 50 lines", "Last 200 lines", "Last 500 lines", "All lines"}
+	tailValues := []int{50, 200, 500, 0}
+	selected, err := RunMenu(withIcon(theme.Lines, "How many lines?"), tailOptions)
+	if err != nil {
+		return "", 0, err
+	}
+
+	for i, option := range tailOptions {
+		if option == selected {
+			return service, tailValues[i], nil
+		}
+	}
+
+	return service, 0, nil
 }
 
 // ShowServiceMenu displays the service management submenu
 func (ui *UI) ShowServiceMenu() (string, error) {
 	menuManager := NewMenuManager(ui)
 	options := menuManager.GetServiceMenuOptions()
-	return menuManager.ShowMenu("🔧 Service Management", options)
+	return menuManager.ShowMenu(withIcon(theme.Bootstrap, i18n.T("ui.menu.services")), options)
 }
 
 // ShowManagementMenu displays the system management submenu
 func (ui *UI) ShowManagementMenu() (string, error) {
 	menuManager := NewMenuManager(ui)
-	options := menuManager.GetManagementMenuOptions()
-	return menuManager.ShowMenu("⚙️ System Management", options)
+	options := allowedActions(menuManager.GetManagementMenuOptions(), ui.configManager.GetRole())
+	return menuManager.ShowMenu(withIcon(theme.Configure, i18n.T("ui.menu.management")), options)
 }
 
 // confirmContinue shows a yes/no prompt
@@ -205,36 +527,163 @@ func (ui *UI) confirmContinue(message string) bool {
 	return result
 }
 
+// lastOperation formats the outcome of the last operation for the menu
+// header, e.g. "Last: restart ✅ 3m ago" ("" if none was recorded)
+func (ui *UI) lastOperation() string {
+	operation, failed, at := ui.configManager.GetLastOperation()
+	if operation == "" || at.IsZero() {
+		return ""
+	}
+
+	icon, mark := theme.Success, i18n.T("ui.last_operation.ok")
+	if failed {
+		icon, mark = theme.Error, i18n.T("ui.last_operation.failed")
+	}
+	if theme.IsPlain() || i18n.Emoji() {
+		mark = icon.String()
+	}
+
+	return i18n.T("ui.last_operation", operation, mark, formatAge(time.Since(at)))
+}
+
+// formatAge renders a duration in its largest whole unit, e.g. "3m" or "2d"
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// withIcon prefixes text with an icon. Emoji are left out if the locale
+// opts out of them; plain ASCII icons are always shown.
+func withIcon(icon theme.Icon, text string) string {
+	if icon == theme.None || (!theme.IsPlain() && !i18n.Emoji()) {
+		return text
+	}
+	return icon.String() + " " + text
+}
+
 // ShowProgress displays a progress message
 func (ui *UI) ShowProgress(message string) {
-	fmt.Printf("🔄 %s...\n", message)
+	ui.Println(withIcon(theme.Progress, message+"..."))
 }
 
 // ShowSuccess displays a success message
 func (ui *UI) ShowSuccess(message string) {
-	fmt.Printf("✅ %s\n", message)
+	ui.Println(withIcon(theme.Success, message))
 }
 
 // ShowError displays an error message
 func (ui *UI) ShowError(message string) {
-	fmt.Printf("❌ Error: %s\n", message)
+	ui.Println(withIcon(theme.Error, i18n.T("ui.error", message)))
 }
 
 // ShowInfo displays an informational message
 func (ui *UI) ShowInfo(message string) {
-	fmt.Printf("ℹ️  %s\n", message)
+	ui.Println(withIcon(theme.Info, message))
 }
 
 // ShowWarning displays a warning message
 func (ui *UI) ShowWarning(message string) {
-	fmt.Printf("⚠️  Warning: %s\n", message)
+	ui.Println(withIcon(theme.Warning, i18n.T("ui.warning", message)))
+}
+
+// ShowWhatChanged summarizes an installed launcher update: the rendered
+// release notes, a link to the full changelog and, across a major version,
+// a note about breaking changes
+func (ui *UI) ShowWhatChanged(version, releaseNotes, changelogURL string, majorUpgrade bool) {
+	ui.Println("")
+	ui.Println(theme.Styles().Header.UnsetPadding().Render(withIcon(theme.Logs, i18n.T("ui.what_changed", version))))
+
+	if majorUpgrade {
+		ui.ShowWarning(i18n.T("ui.major_upgrade", version))
+	}
+
+	if strings.TrimSpace(releaseNotes) != "" {
+		ui.Println(RenderMarkdown(releaseNotes))
+		ui.Println("")
+	}
+
+	ui.ShowInfo(i18n.T("ui.full_changelog", changelogURL))
+}
+
+// Println prints text, keeping it above the spinner while one is running
+func (ui *UI) Println(text string) {
+	ui.spinnerMu.Lock()
+	defer ui.spinnerMu.Unlock()
+
+	if ui.spinner != nil {
+		ui.spinner.println(text)
+		return
+	}
+	fmt.Println(text)
+}
+
+// StartSpinner shows an animated spinner with the elapsed time for a long
+// operation. The returned function replaces it with a final line.
+func (ui *UI) StartSpinner(operation string) func(failed bool) {
+	ui.spinnerMu.Lock()
+	defer ui.spinnerMu.Unlock()
+
+	if ui.spinner != nil || ui.spinnerDisabled || IsSimple() {
+		// Nested operations share the outer spinner
+		return func(bool) {}
+	}
+
+	spinner := newSpinner(operation)
+	ui.spinner = spinner
+
+	return func(failed bool) {
+		ui.spinnerMu.Lock()
+		defer ui.spinnerMu.Unlock()
+
+		if ui.spinner == spinner {
+			spinner.stop(failed)
+			ui.spinner = nil
+		}
+	}
+}
+
+// TrackProgress shows the tracker's progress (bytes, rate, ETA) in the
+// running spinner
+func (ui *UI) TrackProgress(tracker *progress.Tracker) {
+	ui.spinnerMu.Lock()
+	defer ui.spinnerMu.Unlock()
+
+	if ui.spinner != nil {
+		ui.spinner.track(tracker)
+	}
+}
+
+// withSpinnerPaused runs an interactive prompt with the spinner suspended
+func (ui *UI) withSpinnerPaused(fn func()) {
+	ui.spinnerMu.Lock()
+	spinner := ui.spinner
+	ui.spinnerMu.Unlock()
+
+	if spinner == nil {
+		fn()
+		return
+	}
+
+	spinner.pause()
+	defer spinner.resume()
+	fn()
 }
 
 // WaitForUser waits for user to press Enter
 func (ui *UI) WaitForUser(message string) {
 	if message == "" {
-		message = "Press Enter to continue..."
+		message = i18n.T("ui.continue")
 	}
 
-	_ = RunWait(message)
+	ui.withSpinnerPaused(func() {
+		_ = RunWait(message)
+	})
 }
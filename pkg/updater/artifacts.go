@@ -0,0 +1,138 @@
+package updater
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/blang/semver/v4"
+)
+
+// Files an interrupted update can leave next to the executable
+const (
+	newSuffix    = ".new"        // Downloaded binary waiting to be swapped in (Windows)
+	oldSuffix    = ".old"        // Replaced binary the swap script did not delete (Windows)
+	scriptSuffix = ".update.bat" // Swap script (Windows)
+	backupSuffix = ".backup"     // Previous binary kept during the swap (Unix)
+)
+
+// versionProbeTimeout bounds running a leftover binary to read its version
+const versionProbeTimeout = 5 * time.Second
+
+// CleanupUpdateArtifacts recovers from an interrupted update. A leftover
+// new binary reporting a newer version than the running one is swapped in
+// and used from the next start; every other leftover is removed. Each step
+// is logged.
+func (u *Updater) CleanupUpdateArtifacts() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the launcher executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+
+	return u.cleanupArtifacts(exe)
+}
+
+// cleanupArtifacts handles the update leftovers of the executable at exe
+func (u *Updater) cleanupArtifacts(exe string) error {
+	var errs []error
+
+	swapped := false
+	if newPath := exe + newSuffix; exists(newPath) {
+		if version, ok := u.pendingVersion(newPath); ok {
+			if err := swapIn(exe, newPath); err != nil {
+				errs = append(errs, fmt.Errorf("failed to complete the interrupted update: %w", err))
+			} else {
+				swapped = true
+				log.Printf("Completed an interrupted update: version %s is used from the next start", version)
+			}
+		}
+	}
+
+	for _, suffix := range []string{newSuffix, scriptSuffix, oldSuffix, backupSuffix} {
+		path := exe + suffix
+		if !exists(path) {
+			continue
+		}
+		// Windows cannot delete the running binary, the next start does
+		if suffix == oldSuffix && swapped && runtime.GOOS == "windows" {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove leftover update file: %w", err))
+			continue
+		}
+		log.Printf("Removed leftover update file %s", path)
+	}
+
+	return errors.Join(errs...)
+}
+
+// pendingVersion returns the version of the leftover binary at path if it
+// runs and is newer than the running launcher
+func (u *Updater) pendingVersion(path string) (semver.Version, bool) {
+	current, err := parseVersion(u.currentVersion)
+	if err != nil {
+		return semver.Version{}, false // Development builds are not updated
+	}
+
+	pending, err := binaryVersion(path)
+	if err != nil {
+		log.Printf("Warning: leftover update %s is not usable: %v", path, err)
+		return semver.Version{}, false
+	}
+
+	return pending, pending.GT(current)
+}
+
+// binaryVersion runs the launcher binary at path and returns the version it
+// reports
+func binaryVersion(path string) (semver.Version, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), versionProbeTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, path, "--version", "--json").Output()
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	var info struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(output, &info); err != nil {
+		return semver.Version{}, fmt.Errorf("unexpected version output: %w", err)
+	}
+	return parseVersion(info.Version)
+}
+
+// swapIn replaces exe with the binary at newPath, keeping exe as the .old
+// file. Renaming works even for the running binary on Windows.
+func swapIn(exe, newPath string) error {
+	oldPath := exe + oldSuffix
+	_ = os.Remove(oldPath)
+
+	if err := os.Rename(exe, oldPath); err != nil {
+		return err
+	}
+	if err := os.Rename(newPath, exe); err != nil {
+		_ = os.Rename(oldPath, exe)
+		return err
+	}
+	return os.Chmod(exe, 0755)
+}
+
+// exists reports whether a file exists at path
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return !errors.Is(err, fs.ErrNotExist)
+}
@@ -0,0 +1,171 @@
+package updater
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/inconshreveable/go-update"
+)
+
+// PatchSuffix is the extension of bsdiff patch assets. A patch is named for
+// the platform and the versions it goes between, e.g.
+// ddalab-launcher-linux-amd64-1.2.0-to-1.2.1.bsdiff, and is accompanied by
+// a .sha256 asset holding the checksum of the patched binary.
+const PatchSuffix = ".bsdiff"
+
+// checksumSuffix is the extension of the checksum asset of a patch
+const checksumSuffix = ".sha256"
+
+// PatchAsset is a bsdiff patch from the running version to a release
+type PatchAsset struct {
+	URL         string
+	Size        int64
+	ChecksumURL string // SHA-256 of the patched binary
+}
+
+// patchPrefix is the start of every patch asset name
+const patchPrefix = "ddalab-launcher-"
+
+// patchName is a parsed patch asset name
+type patchName struct {
+	Platform string // GOOS-GOARCH
+	From     string
+	To       string
+}
+
+// parsePatchName splits a name like
+// ddalab-launcher-linux-amd64-v1.2.0-to-v1.2.1.bsdiff into its platform and
+// versions, with any "v" prefix removed from the versions
+func parsePatchName(name string) (patchName, bool) {
+	rest, ok := strings.CutPrefix(name, patchPrefix)
+	if !ok {
+		return patchName{}, false
+	}
+	rest, ok = strings.CutSuffix(rest, PatchSuffix)
+	if !ok {
+		return patchName{}, false
+	}
+	head, to, ok := strings.Cut(rest, "-to-")
+	if !ok {
+		return patchName{}, false
+	}
+
+	// The platform is the first two fields; the rest of the head is the
+	// from version, which may have a pre-release suffix of its own
+	fields := strings.SplitN(head, "-", 3)
+	if len(fields) != 3 || fields[0] == "" || fields[1] == "" || fields[2] == "" || to == "" {
+		return patchName{}, false
+	}
+	return patchName{
+		Platform: fields[0] + "-" + fields[1],
+		From:     strings.TrimPrefix(fields[2], "v"),
+		To:       strings.TrimPrefix(to, "v"),
+	}, true
+}
+
+// findPlatformPatch returns the patch for the current platform from version
+// from to version to, or nil if the release has none with a checksum
+func findPlatformPatch(assets []GitHubAsset, from, to string) *PatchAsset {
+	want := patchName{
+		Platform: fmt.Sprintf("%s-%s", runtime.GOOS, runtime.GOARCH),
+		From:     strings.TrimPrefix(from, "v"),
+		To:       strings.TrimPrefix(to, "v"),
+	}
+
+	checksums := make(map[string]string)
+	for _, asset := range assets {
+		if name, ok := strings.CutSuffix(asset.Name, checksumSuffix); ok {
+			checksums[name] = asset.BrowserDownloadURL
+		}
+	}
+
+	for _, asset := range assets {
+		if name, ok := parsePatchName(asset.Name); !ok || name != want {
+			continue
+		}
+		if checksumURL, ok := checksums[asset.Name]; ok {
+			return &PatchAsset{URL: asset.BrowserDownloadURL, Size: asset.Size, ChecksumURL: checksumURL}
+		}
+	}
+	return nil
+}
+
+// ApplyPatch applies a bsdiff patch to the current binary and returns the
+// patched binary
+func ApplyPatch(current []byte, patch []byte) ([]byte, error) {
+	var patched bytes.Buffer
+	if err := update.NewBSDiffPatcher().Patch(bytes.NewReader(current), &patched, bytes.NewReader(patch)); err != nil {
+		return nil, fmt.Errorf("failed to apply patch: %w", err)
+	}
+	return patched.Bytes(), nil
+}
+
+// downloadPatched downloads the patch, applies it to currentExe and
+// verifies the result against the patch's checksum
+func (u *Updater) downloadPatched(ctx context.Context, currentExe string, patch *PatchAsset) ([]byte, error) {
+	expected, err := u.fetchChecksum(ctx, patch.ChecksumURL)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := os.ReadFile(currentExe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read current binary: %w", err)
+	}
+
+	resp, err := u.get(ctx, patch.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body io.Reader = resp.Body
+	if u.tracker != nil {
+		u.tracker.SetTotal(resp.ContentLength)
+		body = u.tracker.Reader(resp.Body)
+	}
+
+	patchData, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download patch: %w", err)
+	}
+
+	patched, err := ApplyPatch(current, patchData)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(patched)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return nil, fmt.Errorf("checksum mismatch after patching: expected %s, got %s", expected, actual)
+	}
+	return patched, nil
+}
+
+// fetchChecksum downloads a .sha256 asset and returns the hex checksum it
+// holds. The sha256sum format with a trailing file name is accepted.
+func (u *Updater) fetchChecksum(ctx context.Context, url string) (string, error) {
+	resp, err := u.get(ctx, url)
+	if err != nil {
+		return "", fmt.Errorf("failed to download checksum: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to download checksum: %w", err)
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 || len(fields[0]) != sha256.Size*2 {
+		return "", fmt.Errorf("invalid checksum file %s", url)
+	}
+	return strings.ToLower(fields[0]), nil
+}
@@ -0,0 +1,59 @@
+package updater
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func TestParsePatchName(t *testing.T) {
+	tests := []struct {
+		name string
+		want patchName
+		ok   bool
+	}{
+		{"ddalab-launcher-linux-amd64-1.2.0-to-1.2.1.bsdiff", patchName{"linux-amd64", "1.2.0", "1.2.1"}, true},
+		{"ddalab-launcher-darwin-arm64-v1.2.0-to-v1.2.1.bsdiff", patchName{"darwin-arm64", "1.2.0", "1.2.1"}, true},
+		{"ddalab-launcher-linux-amd64-1.2.0-rc1-to-1.2.0.bsdiff", patchName{"linux-amd64", "1.2.0-rc1", "1.2.0"}, true},
+		{"ddalab-launcher-linux-amd64-1.2.0-to-1.2.1.bsdiff.sha256", patchName{}, false},
+		{"ddalab-launcher-linux-amd64-1.2.0.bsdiff", patchName{}, false},
+		{"ddalab-launcher-linux-1.2.0-to-1.2.1.bsdiff", patchName{}, false},
+		{"other-linux-amd64-1.2.0-to-1.2.1.bsdiff", patchName{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parsePatchName(tt.name)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parsePatchName(%q) = %+v, %v; want %+v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFindPlatformPatch(t *testing.T) {
+	platform := runtime.GOOS + "-" + runtime.GOARCH
+	asset := func(name string) GitHubAsset {
+		return GitHubAsset{Name: name, BrowserDownloadURL: "https://example.invalid/" + name}
+	}
+	patch := fmt.Sprintf("ddalab-launcher-%s-v1.2.0-to-v1.2.1.bsdiff", platform)
+	assets := []GitHubAsset{
+		// A patch whose name merely contains the wanted versions must not match
+		asset(fmt.Sprintf("ddalab-launcher-%s-11.2.0-to-1.2.10.bsdiff", platform)),
+		asset(fmt.Sprintf("ddalab-launcher-%s-11.2.0-to-1.2.10.bsdiff.sha256", platform)),
+		asset(patch),
+		asset(patch + ".sha256"),
+	}
+
+	got := findPlatformPatch(assets, "1.2.0", "v1.2.1")
+	if got == nil {
+		t.Fatal("findPlatformPatch found no patch")
+	}
+	if got.URL != "https://example.invalid/"+patch || got.ChecksumURL != got.URL+".sha256" {
+		t.Errorf("findPlatformPatch = %+v, want %s", got, patch)
+	}
+
+	if got := findPlatformPatch(assets, "1.2.0", "1.2.2"); got != nil {
+		t.Errorf("findPlatformPatch to 1.2.2 = %+v, want nil", got)
+	}
+	if got := findPlatformPatch(assets[2:3], "1.2.0", "1.2.1"); got != nil {
+		t.Errorf("findPlatformPatch without checksum = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,54 @@
+package updater
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"runtime"
+	"syscall"
+)
+
+// ErrNothingInstalled is returned by Relaunch if no update was installed
+var ErrNothingInstalled = errors.New("no launcher update installed")
+
+// InstalledPath returns the path the last PerformUpdate installed the new
+// binary to, or "" if it did not install one
+func (u *Updater) InstalledPath() string {
+	return u.installedPath
+}
+
+// Relaunch replaces the running launcher with the binary installed by
+// PerformUpdate, keeping the arguments and environment. The process is
+// exec'd in place, keeping its PID and terminal, so Relaunch only returns
+// if that fails. Where exec is not possible the new binary is started as a
+// child attached to the terminal, and the launcher exits with its exit code
+// once it ends. On Windows the swap only happens after the launcher exits,
+// so it must be reopened by hand there.
+func (u *Updater) Relaunch() error {
+	if u.installedPath == "" {
+		return ErrNothingInstalled
+	}
+	if runtime.GOOS == "windows" {
+		return fmt.Errorf("relaunching is not supported on %s", runtime.GOOS)
+	}
+
+	args := append([]string{u.installedPath}, os.Args[1:]...)
+	err := syscall.Exec(u.installedPath, args, os.Environ())
+	log.Printf("Warning: failed to exec %s, starting it as a child instead: %v", u.installedPath, err)
+
+	cmd := exec.Command(u.installedPath, os.Args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		return fmt.Errorf("failed to start %s: %w", u.installedPath, err)
+	}
+	os.Exit(0)
+	return nil
+}
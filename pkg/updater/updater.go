@@ -9,15 +9,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
 
 	"github.com/blang/semver/v4"
+	"github.com/ddalab/launcher/pkg/config"
+	"github.com/ddalab/launcher/pkg/httpx"
+	"github.com/ddalab/launcher/pkg/progress"
 	"github.com/inconshreveable/go-update"
 )
 
@@ -25,19 +30,53 @@ const (
 	GitHubRepoOwner = "sdraeger"
 	GitHubRepoName  = "DDALAB-launcher"
 	UpdateCheckURL  = "https://api.github.com/repos/sdraeger/DDALAB-launcher/releases/latest"
+	ReleaseListURL  = "https://api.github.com/repos/sdraeger/DDALAB-launcher/releases?per_page=30"
+	ReleasesURL     = "https://github.com/sdraeger/DDALAB-launcher/releases"
 )
 
+// Per-request deadlines for the shared HTTP client
+const (
+	checkTimeout    = 30 * time.Second
+	downloadTimeout = 5 * time.Minute
+)
+
+// Channel selects which releases the updater offers
+type Channel string
+
+const (
+	ChannelStable Channel = "stable" // Only the latest full release
+	ChannelBeta   Channel = "beta"   // The newest release, including pre-releases
+)
+
+// ParseChannel converts a channel name to a Channel
+func ParseChannel(name string) (Channel, error) {
+	switch Channel(strings.ToLower(strings.TrimSpace(name))) {
+	case ChannelStable, "":
+		return ChannelStable, nil
+	case ChannelBeta:
+		return ChannelBeta, nil
+	default:
+		return "", fmt.Errorf("invalid update channel '%s'. Valid channels: stable, beta", name)
+	}
+}
+
 // GitHubRelease represents a GitHub release response
 type GitHubRelease struct {
-	TagName string `json:"tag_name"`
-	Name    string `json:"name"`
-	Body    string `json:"body"`
-	Assets  []struct {
-		Name               string `json:"name"`
-		BrowserDownloadURL string `json:"browser_download_url"`
-		Size               int64  `json:"size"`
-	} `json:"assets"`
-	PublishedAt time.Time `json:"published_at"`
+	TagName     string        `json:"tag_name"`
+	Name        string        `json:"name"`
+	Body        string        `json:"body"`
+	HTMLURL     string        `json:"html_url"`
+	Assets      []GitHubAsset `json:"assets"`
+	PublishedAt time.Time     `json:"published_at"`
+	Prerelease  bool          `json:"prerelease"`
+	Draft       bool          `json:"draft"`
+}
+
+// GitHubAsset is a file attached to a GitHub release
+type GitHubAsset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+	Size               int64  `json:"size"`
 }
 
 // UpdateInfo contains information about an available update
@@ -45,53 +84,64 @@ type UpdateInfo struct {
 	CurrentVersion string
 	LatestVersion  string
 	ReleaseNotes   string
+	ReleaseURL     string // Release page with the full changelog
 	DownloadURL    string
 	Size           int64
+	Patch          *PatchAsset // Delta from CurrentVersion, nil if the release has none
 	PublishedAt    time.Time
 	HasUpdate      bool
+	Channel        Channel // Channel the release was looked up on
+	Prerelease     bool    // The release is a beta build
 }
 
 // Updater handles launcher self-updates
 type Updater struct {
 	currentVersion string
+	channel        Channel
 	githubToken    string // Optional for rate limiting
+	tracker        *progress.Tracker
+	httpClient     *http.Client // Shared by the check and the download
+	installedPath  string       // Where PerformUpdate installed the new binary
 }
 
 // NewUpdater creates a new updater instance
 func NewUpdater(currentVersion string) *Updater {
 	return &Updater{
 		currentVersion: currentVersion,
+		channel:        ChannelStable,
 		githubToken:    os.Getenv("GITHUB_TOKEN"), // Optional
+		httpClient:     httpx.NewClient(),
 	}
 }
 
-// CheckForUpdates checks if a new version is available
-func (u *Updater) CheckForUpdates(ctx context.Context) (*UpdateInfo, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", UpdateCheckURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	// Add GitHub token if available (helps with rate limiting)
-	if u.githubToken != "" {
-		req.Header.Set("Authorization", "token "+u.githubToken)
-	}
-	req.Header.Set("Accept", "application/vnd.github.v3+json")
+// SetTracker sets a tracker that records download progress during PerformUpdate
+func (u *Updater) SetTracker(tracker *progress.Tracker) {
+	u.tracker = tracker
+}
 
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check for updates: %w", err)
-	}
-	defer resp.Body.Close()
+// SetChannel selects the release channel checked by CheckForUpdates
+func (u *Updater) SetChannel(channel Channel) {
+	u.channel = channel
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
-	}
+// CheckForUpdates checks if a new version is available on the channel
+func (u *Updater) CheckForUpdates(ctx context.Context) (*UpdateInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
 
 	var release GitHubRelease
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return nil, fmt.Errorf("failed to decode release info: %w", err)
+	if u.channel == ChannelBeta {
+		var releases []GitHubRelease
+		if err := u.getJSON(ctx, ReleaseListURL, &releases); err != nil {
+			return nil, err
+		}
+		newest, ok := newestRelease(releases)
+		if !ok {
+			return nil, fmt.Errorf("no releases found")
+		}
+		release = newest
+	} else if err := u.getJSON(ctx, UpdateCheckURL, &release); err != nil {
+		return nil, err
 	}
 
 	// Parse versions
@@ -112,18 +162,74 @@ func (u *Updater) CheckForUpdates(ctx context.Context) (*UpdateInfo, error) {
 		CurrentVersion: u.currentVersion,
 		LatestVersion:  release.TagName,
 		ReleaseNotes:   release.Body,
+		ReleaseURL:     release.HTMLURL,
 		DownloadURL:    downloadURL,
 		Size:           size,
+		Patch:          findPlatformPatch(release.Assets, u.currentVersion, release.TagName),
 		PublishedAt:    release.PublishedAt,
 		HasUpdate:      latestVer.GT(currentVer),
+		Channel:        u.channel,
+		Prerelease:     release.Prerelease,
 	}
 
 	return updateInfo, nil
 }
 
-// PerformUpdate downloads and applies the update safely
-func (u *Updater) PerformUpdate(ctx context.Context, downloadURL string) error {
-	if downloadURL == "" {
+// getJSON requests url from the GitHub API and decodes the response into out
+func (u *Updater) getJSON(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Add GitHub token if available (helps with rate limiting)
+	if u.githubToken != "" {
+		req.Header.Set("Authorization", "token "+u.githubToken)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := u.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to check for updates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GitHub API returned status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode release info: %w", err)
+	}
+	return nil
+}
+
+// newestRelease returns the published release with the highest version,
+// pre-releases included. Tags that are not versions are skipped.
+func newestRelease(releases []GitHubRelease) (GitHubRelease, bool) {
+	var newest GitHubRelease
+	var newestVer semver.Version
+	found := false
+	for _, release := range releases {
+		if release.Draft {
+			continue
+		}
+		version, err := parseVersion(release.TagName)
+		if err != nil {
+			continue
+		}
+		if !found || version.GT(newestVer) {
+			newest, newestVer, found = release, version, true
+		}
+	}
+	return newest, found
+}
+
+// PerformUpdate downloads and applies the update safely. A delta patch is
+// preferred if the release has one for the current version; the full
+// binary is downloaded if there is none or it cannot be applied.
+func (u *Updater) PerformUpdate(ctx context.Context, info *UpdateInfo) error {
+	if info.DownloadURL == "" {
 		return fmt.Errorf("no download URL available for this platform")
 	}
 
@@ -139,35 +245,106 @@ func (u *Updater) PerformUpdate(ctx context.Context, downloadURL string) error {
 		return fmt.Errorf("failed to resolve executable path: %w", err)
 	}
 
-	// Download the new binary
-	req, err := http.NewRequestWithContext(ctx, "GET", downloadURL, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create download request: %w", err)
+	// Download the new binary. The deadline also covers reading the body.
+	ctx, cancel := context.WithTimeout(ctx, downloadTimeout)
+	defer cancel()
+
+	if info.Patch != nil {
+		binary, err := u.downloadPatched(ctx, currentExe, info.Patch)
+		if err == nil {
+			return u.install(currentExe, bytes.NewReader(binary))
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("failed to download update: %w", err)
+		}
+		log.Printf("Warning: delta update failed, downloading the full binary: %v", err)
 	}
 
-	client := &http.Client{Timeout: 5 * time.Minute}
-	resp, err := client.Do(req)
+	download, err := u.downloadToCache(ctx, info.DownloadURL)
 	if err != nil {
 		return fmt.Errorf("failed to download update: %w", err)
 	}
+	defer os.Remove(download.Name())
+	defer download.Close()
+
+	// Extract binary from archive if needed
+	binaryReader, err := u.extractBinaryFromArchive(download, info.DownloadURL)
+	if err != nil {
+		return fmt.Errorf("failed to extract binary from archive: %w", err)
+	}
+
+	return u.install(currentExe, binaryReader)
+}
+
+// downloadToCache downloads url into the launcher's cache directory and
+// returns the file, positioned at its start. The caller closes and removes
+// it. The system temp directory is used if there is no cache directory.
+func (u *Updater) downloadToCache(ctx context.Context, url string) (*os.File, error) {
+	resp, err := u.get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("download failed with status %d", resp.StatusCode)
+	var body io.Reader = resp.Body
+	if u.tracker != nil {
+		u.tracker.SetTotal(resp.ContentLength)
+		body = u.tracker.Reader(resp.Body)
 	}
 
-	// Extract binary from archive if needed
-	binaryReader, err := u.extractBinaryFromArchive(resp.Body, downloadURL)
+	dir, err := config.CacheDir()
 	if err != nil {
-		return fmt.Errorf("failed to extract binary from archive: %w", err)
+		log.Printf("Warning: no cache directory, downloading to %s: %v", os.TempDir(), err)
+		dir = os.TempDir()
+	}
+	file, err := os.CreateTemp(dir, "update-*-"+path.Base(url))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create download file: %w", err)
+	}
+
+	if _, err = io.Copy(file, body); err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
 	}
+	return file, nil
+}
 
-	// Use platform-specific update strategy
+// install replaces currentExe with the new binary using the platform's
+// update strategy
+func (u *Updater) install(currentExe string, binary io.Reader) error {
+	var err error
 	if runtime.GOOS == "windows" {
-		return u.performWindowsUpdate(currentExe, binaryReader)
+		err = u.performWindowsUpdate(currentExe, binary)
 	} else {
-		return u.performUnixUpdate(currentExe, binaryReader)
+		err = u.performUnixUpdate(currentExe, binary)
 	}
+	if err == nil {
+		u.installedPath = currentExe
+	}
+	return err
+}
+
+// get requests url and returns the response if it succeeded
+func (u *Updater) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create download request: %w", err)
+	}
+
+	resp, err := u.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("download failed with status %d", resp.StatusCode)
+	}
+	return resp, nil
 }
 
 // ParseVersion parses a version string, handling 'v' prefix (exported for testing)
@@ -177,6 +354,56 @@ func (u *Updater) ParseVersion(version string) (semver.Version, error) {
 
 // parseVersion parses a version string, handling 'v' prefix
 func (u *Updater) parseVersion(version string) (semver.Version, error) {
+	return parseVersion(version)
+}
+
+// ChangelogURL returns the page with the full changelog of the release
+func (i *UpdateInfo) ChangelogURL() string {
+	if i.ReleaseURL != "" {
+		return i.ReleaseURL
+	}
+	return ReleasesURL
+}
+
+// IsAhead reports whether the running version is newer than the latest
+// release of the channel, e.g. a beta build after switching to stable
+func (i *UpdateInfo) IsAhead() bool {
+	if strings.TrimPrefix(i.CurrentVersion, "v") == "dev" {
+		return false
+	}
+
+	current, err := parseVersion(i.CurrentVersion)
+	if err != nil {
+		return false
+	}
+	latest, err := parseVersion(i.LatestVersion)
+	if err != nil {
+		return false
+	}
+	return current.GT(latest)
+}
+
+// IsMajorUpgrade reports whether the update moves to a new major version,
+// which may contain breaking changes. Development builds are never
+// considered to be upgraded across a major version.
+func (i *UpdateInfo) IsMajorUpgrade() bool {
+	if strings.TrimPrefix(i.CurrentVersion, "v") == "dev" {
+		return false
+	}
+
+	current, err := parseVersion(i.CurrentVersion)
+	if err != nil {
+		return false
+	}
+	latest, err := parseVersion(i.LatestVersion)
+	if err != nil {
+		return false
+	}
+	return latest.Major > current.Major
+}
+
+// parseVersion parses a version string, handling 'v' prefix
+func parseVersion(version string) (semver.Version, error) {
 	// Remove 'v' prefix if present
 	cleanVersion := strings.TrimPrefix(version, "v")
 
@@ -189,11 +416,7 @@ func (u *Updater) parseVersion(version string) (semver.Version, error) {
 }
 
 // findPlatformBinary finds the appropriate binary for the current platform
-func (u *Updater) findPlatformBinary(assets []struct {
-	Name               string `json:"name"`
-	BrowserDownloadURL string `json:"browser_download_url"`
-	Size               int64  `json:"size"`
-}) (string, int64) {
+func (u *Updater) findPlatformBinary(assets []GitHubAsset) (string, int64) {
 	platformMap := map[string][]string{
 		"darwin":  {"darwin-amd64", "darwin-arm64"},
 		"linux":   {"linux-amd64", "linux-arm64"},
@@ -252,16 +475,7 @@ func (u *Updater) findPlatformBinary(assets []struct {
 
 // FormatSize formats byte size in human readable format
 func FormatSize(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
-	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
-	}
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+	return progress.FormatBytes(bytes)
 }
 
 // ShouldCheckForUpdates determines if we should check for updates based on last check time
@@ -544,7 +758,7 @@ func (u *Updater) performUnixUpdate(currentExe string, updateBody io.Reader) err
 	}
 
 	// Create backup of current binary
-	backupPath := currentExe + ".backup"
+	backupPath := currentExe + backupSuffix
 	err = os.Rename(currentExe, backupPath)
 	if err != nil {
 		return fmt.Errorf("failed to backup current binary: %w", err)
@@ -569,8 +783,8 @@ func (u *Updater) performWindowsUpdate(currentExe string, updateBody io.Reader)
 	// On Windows, we can't replace a running executable directly
 	// We use a different strategy: download to .new, create a batch script to replace it
 
-	newPath := currentExe + ".new"
-	batchPath := currentExe + ".update.bat"
+	newPath := currentExe + newSuffix
+	batchPath := currentExe + scriptSuffix
 
 	// Ensure cleanup
 	defer func() {
@@ -589,11 +803,11 @@ func (u *Updater) performWindowsUpdate(currentExe string, updateBody io.Reader)
 	// Create a batch script to perform the replacement after this process exits
 	batchContent := fmt.Sprintf(`@echo off
 timeout /t 2 /nobreak >nul
-move "%s" "%s.old"
 move "%s" "%s"
-del "%s.old"
+move "%s" "%s"
+del "%s"
 del "%%~f0"
-`, currentExe, currentExe, newPath, currentExe, currentExe)
+`, currentExe, currentExe+oldSuffix, newPath, currentExe, currentExe+oldSuffix)
 
 	err = os.WriteFile(batchPath, []byte(batchContent), 0644)
 	if err != nil {
@@ -0,0 +1,45 @@
+package updater
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDownloadToCache(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("XDG_CACHE_HOME is only honored on Linux")
+	}
+	cacheHome := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheHome)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("binary data"))
+	}))
+	t.Cleanup(server.Close)
+
+	file, err := NewUpdater("1.0.0").downloadToCache(context.Background(), server.URL+"/ddalab-launcher-linux-amd64.tar.gz")
+	if err != nil {
+		t.Fatalf("downloadToCache failed: %v", err)
+	}
+	defer file.Close()
+
+	if dir := filepath.Dir(file.Name()); dir != filepath.Join(cacheHome, "ddalab-launcher") {
+		t.Errorf("downloaded to %s, want the launcher cache directory", dir)
+	}
+	if !strings.HasSuffix(file.Name(), "-ddalab-launcher-linux-amd64.tar.gz") {
+		t.Errorf("download %s lost the asset name, which selects the archive format", file.Name())
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "binary data" {
+		t.Errorf("download holds %q, want the response body from the start", data)
+	}
+}
@@ -8,6 +8,10 @@ BINARY_NAME="ddalab-launcher"
 VERSION="1.0.0"
 BUILD_DIR="dist"
 
+COMMIT=$(git rev-parse HEAD 2>/dev/null || echo unknown)
+BUILD_DATE=$(date -u +%Y-%m-%dT%H:%M:%SZ)
+LDFLAGS="-s -w -X main.version=${VERSION} -X main.commit=${COMMIT} -X main.buildDate=${BUILD_DATE}"
+
 echo "Building DDALAB Launcher v${VERSION}..."
 
 # Create build directory
@@ -15,19 +19,19 @@ mkdir -p ${BUILD_DIR}
 
 # Build for Linux
 echo "Building for Linux..."
-GOOS=linux GOARCH=amd64 go build -ldflags "-s -w -X main.version=${VERSION}" \
+GOOS=linux GOARCH=amd64 go build -ldflags "${LDFLAGS}" \
     -o ${BUILD_DIR}/${BINARY_NAME}-linux-amd64 ./cmd/launcher
 
 # Build for macOS
 echo "Building for macOS..."
-GOOS=darwin GOARCH=amd64 go build -ldflags "-s -w -X main.version=${VERSION}" \
+GOOS=darwin GOARCH=amd64 go build -ldflags "${LDFLAGS}" \
     -o ${BUILD_DIR}/${BINARY_NAME}-darwin-amd64 ./cmd/launcher
-GOOS=darwin GOARCH=arm64 go build -ldflags "-s -w -X main.version=${VERSION}" \
+GOOS=darwin GOARCH=arm64 go build -ldflags "${LDFLAGS}" \
     -o ${BUILD_DIR}/${BINARY_NAME}-darwin-arm64 ./cmd/launcher
 
 # Build for Windows
 echo "Building for Windows..."
-GOOS=windows GOARCH=amd64 go build -ldflags "-s -w -X main.version=${VERSION}" \
+GOOS=windows GOARCH=amd64 go build -ldflags "${LDFLAGS}" \
     -o ${BUILD_DIR}/${BINARY_NAME}-windows-amd64.exe ./cmd/launcher
 
 # Create macOS app bundle
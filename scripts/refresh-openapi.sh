@@ -0,0 +1,69 @@
+#!/bin/bash
+
+# Refresh the backend API contract fixture for DDALAB Launcher
+# This script exports the OpenAPI document from a running DDALAB backend and
+# keeps the operations the launcher uses in pkg/api/testdata/openapi.json
+#
+# Usage: ./scripts/refresh-openapi.sh [API_ENDPOINT] [-p PATH]...
+#   API_ENDPOINT  backend base URL (default: http://localhost:8080)
+#   -p PATH       also keep PATH, e.g. for the operation of a new client method
+
+set -e
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+PROJECT_ROOT="$(dirname "$SCRIPT_DIR")"
+FIXTURE="$PROJECT_ROOT/pkg/api/testdata/openapi.json"
+
+API_ENDPOINT="http://localhost:8080"
+EXTRA_PATHS=()
+while [ $# -gt 0 ]; do
+    case "$1" in
+        -p)
+            EXTRA_PATHS+=("$2")
+            shift 2
+            ;;
+        *)
+            API_ENDPOINT="$1"
+            shift
+            ;;
+    esac
+done
+API_ENDPOINT="${API_ENDPOINT%/}"
+SOURCE="$API_ENDPOINT/openapi.json"
+
+if ! command -v jq >/dev/null 2>&1; then
+    echo "❌ jq is required to filter the OpenAPI document"
+    exit 1
+fi
+
+echo "📥 Exporting OpenAPI document from $SOURCE..."
+EXPORT=$(mktemp)
+trap 'rm -f "$EXPORT"' EXIT
+
+CURL_ARGS=(-sSfL -o "$EXPORT")
+if [ -n "$DDALAB_API_TOKEN" ]; then
+    CURL_ARGS+=(-H "Authorization: Bearer $DDALAB_API_TOKEN")
+fi
+curl "${CURL_ARGS[@]}" "$SOURCE"
+
+# Keep the paths of the current fixture plus the ones given with -p
+KEEP=$(jq -c '[.paths | keys[]] + $ARGS.positional | unique' "$FIXTURE" --args "${EXTRA_PATHS[@]}")
+
+MISSING=$(jq -r --argjson keep "$KEEP" '$keep - (.paths | keys) | .[]' "$EXPORT")
+if [ -n "$MISSING" ]; then
+    echo "⚠️  Paths the launcher uses are missing from the backend's document:"
+    echo "$MISSING"
+fi
+
+jq --argjson keep "$KEEP" \
+    --arg source "$SOURCE" \
+    --arg fetched "$(date -u +%Y-%m-%dT%H:%M:%SZ)" \
+    '.paths |= with_entries(select(.key as $path | $keep | index($path)))
+     | .info["x-source"] = $source
+     | .info["x-fetched-at"] = $fetched' \
+    "$EXPORT" > "$FIXTURE"
+
+echo "✅ Updated $FIXTURE"
+echo "🧪 Running the contract test..."
+cd "$PROJECT_ROOT"
+go test -run ClientMatchesSpec ./pkg/api/